@@ -0,0 +1,504 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/internal/combat"
+	"github.com/benoit/saga-demonspawn/internal/config"
+	"github.com/benoit/saga-demonspawn/internal/dice"
+	"github.com/benoit/saga-demonspawn/internal/items"
+	"github.com/benoit/saga-demonspawn/internal/rules"
+)
+
+// newFlagSet creates a flag set for a subcommand that reports its own usage.
+func newFlagSet(name, usage string, out io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(out)
+	fs.Usage = func() {
+		fmt.Fprintln(out, "Usage: "+usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseArgs parses flags that may appear before, between or after the
+// positional arguments, which are left in fs.Args().
+func parseArgs(fs *flag.FlagSet, args []string) error {
+	positional := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	return fs.Parse(append([]string{"--"}, positional...))
+}
+
+// newRoller returns seeded dice for a non-zero seed, random dice otherwise.
+func newRoller(seed int64) dice.Roller {
+	if seed != 0 {
+		return dice.NewSeededRoller(seed)
+	}
+	return dice.NewStandardRoller()
+}
+
+// writeJSON prints a value as indented JSON, leaving <, > and & as they are.
+func writeJSON(out io.Writer, v interface{}) error {
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// rollOutput is the JSON form of a dice roll.
+type rollOutput struct {
+	Expression string `json:"expression"`
+	Rolls      []int  `json:"rolls"`
+	Total      int    `json:"total"`
+	Target     *int   `json:"target,omitempty"`  // Right-hand side of a test
+	Success    *bool  `json:"success,omitempty"` // Whether the test passed
+}
+
+// runRoll rolls a dice expression, or a characteristic (2d6 × 8).
+func runRoll(args []string, out io.Writer) error {
+	fs := newFlagSet("roll", "saga roll [flags] <expression>|characteristic", out)
+	seed := fs.Int64("seed", 0, "seed for reproducible rolls (default: random)")
+	asJSON := fs.Bool("json", false, "print the result as JSON")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("a dice expression is required")
+	}
+
+	expression := fs.Arg(0)
+	roller := newRoller(*seed)
+	result := rollOutput{Expression: expression}
+
+	if strings.EqualFold(expression, "characteristic") {
+		result.Total = roller.RollCharacteristic()
+		result.Rolls = []int{result.Total}
+		return writeRoll(out, result, *asJSON)
+	}
+
+	rolled, err := dice.RollExpression(expression, roller, nil)
+	if err != nil {
+		return err
+	}
+	for _, group := range rolled.Groups {
+		result.Rolls = append(result.Rolls, group.Rolls...)
+	}
+	result.Total = rolled.Total
+	if rolled.IsTest {
+		result.Target, result.Success = &rolled.Target, &rolled.Success
+	}
+	if *asJSON {
+		return writeJSON(out, result)
+	}
+	if len(rolled.Groups) != 1 || rolled.Groups[0].Keep != rolled.Groups[0].Count ||
+		rolled.Total != rolled.Groups[0].Total || rolled.IsTest {
+		fmt.Fprintln(out, rolled)
+		return nil
+	}
+	return writeRoll(out, result, false)
+}
+
+// writeRoll prints a plain roll as "2d6: 3 + 4 = 7", or as JSON.
+func writeRoll(out io.Writer, result rollOutput, asJSON bool) error {
+	if asJSON {
+		return writeJSON(out, result)
+	}
+	if len(result.Rolls) > 1 {
+		parts := make([]string, len(result.Rolls))
+		for i, roll := range result.Rolls {
+			parts[i] = strconv.Itoa(roll)
+		}
+		fmt.Fprintf(out, "%s: %s = %d\n", result.Expression, strings.Join(parts, " + "), result.Total)
+	} else {
+		fmt.Fprintf(out, "%s: %d\n", result.Expression, result.Total)
+	}
+	return nil
+}
+
+// runCharacter dispatches "character show" and "character new".
+func runCharacter(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: saga character show|new [flags]")
+	}
+	switch args[0] {
+	case "show":
+		return runCharacterShow(args[1:], out)
+	case "new":
+		return runCharacterNew(args[1:], out)
+	default:
+		return fmt.Errorf("unknown character command %q (show or new)", args[0])
+	}
+}
+
+// runCharacterShow prints a saved character sheet.
+func runCharacterShow(args []string, out io.Writer) error {
+	fs := newFlagSet("character show", "saga character show [flags] <character.json>", out)
+	asJSON := fs.Bool("json", false, "print the character as JSON")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("a character file is required")
+	}
+
+	char, err := character.Load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return writeJSON(out, char)
+	}
+	writeCharacterSheet(out, char)
+	return nil
+}
+
+// runCharacterNew rolls a new character, equips it and saves it.
+func runCharacterNew(args []string, out io.Writer) error {
+	fs := newFlagSet("character new", "saga character new [flags]", out)
+	seed := fs.Int64("seed", 0, "seed for reproducible rolls (default: random)")
+	weapon := fs.String("weapon", items.StartingWeapons()[0].Name, "starting weapon (Sword, Dagger or Club)")
+	armor := fs.String("armor", items.StartingArmor()[0].Name, "starting armor (None or Leather Armor)")
+	dir := fs.String("dir", "", "directory to save in (default: the configured save directory)")
+	slot := fs.String("slot", "", "name of the save slot (default: named after the creation date)")
+	asJSON := fs.Bool("json", false, "print the character as JSON")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	chosenWeapon := startingWeapon(*weapon)
+	if chosenWeapon == nil {
+		return fmt.Errorf("%q is not a starting weapon", *weapon)
+	}
+	chosenArmor := startingArmor(*armor)
+	if chosenArmor == nil {
+		return fmt.Errorf("%q is not a starting armor", *armor)
+	}
+
+	saveDir := *dir
+	if saveDir == "" {
+		cfg, err := config.LoadDefault()
+		if err != nil {
+			return err
+		}
+		saveDir = cfg.SaveDirectory
+	}
+
+	roller := newRoller(*seed)
+	char, err := character.New(roller.RollCharacteristic(), roller.RollCharacteristic(), roller.RollCharacteristic(),
+		roller.RollCharacteristic(), roller.RollCharacteristic(), roller.RollCharacteristic(), roller.RollCharacteristic())
+	if err != nil {
+		return err
+	}
+	char.EquipWeapon(chosenWeapon)
+	char.EquipArmor(chosenArmor)
+
+	if *slot != "" {
+		err = char.SaveAs(saveDir, *slot)
+	} else {
+		err = char.Save(saveDir)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return writeJSON(out, char)
+	}
+	writeCharacterSheet(out, char)
+	fmt.Fprintf(out, "\nSaved to %s\n", char.SavePath(saveDir))
+	return nil
+}
+
+// startingWeapon finds a starting weapon by name, ignoring case.
+func startingWeapon(name string) *items.Weapon {
+	for _, w := range items.StartingWeapons() {
+		if strings.EqualFold(w.Name, name) {
+			return &w
+		}
+	}
+	return nil
+}
+
+// startingArmor finds a starting armor by name, ignoring case.
+func startingArmor(name string) *items.Armor {
+	for _, a := range items.StartingArmor() {
+		if strings.EqualFold(a.Name, name) {
+			return &a
+		}
+	}
+	return nil
+}
+
+// writeCharacterSheet prints a character's stats and equipment.
+func writeCharacterSheet(out io.Writer, c *character.Character) {
+	fmt.Fprintln(out, "Fire*Wolf")
+	fmt.Fprintf(out, "  STR %-3d SPD %-3d STA %-3d CRG %d\n", c.Strength, c.Speed, c.Stamina, c.Courage)
+	fmt.Fprintf(out, "  LCK %-3d CHM %-3d ATT %d\n", c.Luck, c.Charm, c.Attraction)
+	fmt.Fprintf(out, "  LP %d/%d  Skill %d", c.CurrentLP, c.MaximumLP, c.Skill)
+	if c.MagicUnlocked {
+		fmt.Fprintf(out, "  POW %d/%d", c.CurrentPOW, c.MaximumPOW)
+	}
+	fmt.Fprintln(out)
+
+	weapon, armor := "None", "None"
+	if c.EquippedWeapon != nil {
+		weapon = fmt.Sprintf("%s (+%d)", c.EquippedWeapon.Name, c.EquippedWeapon.DamageBonus)
+	}
+	if c.EquippedArmor != nil {
+		armor = fmt.Sprintf("%s (-%d)", c.EquippedArmor.Name, c.EquippedArmor.Protection)
+	}
+	fmt.Fprintf(out, "  Weapon: %s  Armor: %s", weapon, armor)
+	if c.HasShield {
+		fmt.Fprint(out, "  Shield")
+	}
+	fmt.Fprintln(out)
+
+	special := []string{}
+	if c.HealingStoneCharges > 0 {
+		special = append(special, fmt.Sprintf("Healing Stone (%d charges)", c.HealingStoneCharges))
+	}
+	if c.DoombringerPossessed {
+		special = append(special, items.DoombringerName)
+	}
+	if c.OrbPossessed && !c.OrbDestroyed {
+		special = append(special, "The Orb")
+	}
+	if len(special) > 0 {
+		fmt.Fprintf(out, "  Special: %s\n", strings.Join(special, ", "))
+	}
+
+	fmt.Fprintf(out, "  Section %d  Enemies defeated %d\n", c.CurrentSection, c.EnemiesDefeated)
+}
+
+// fightOutput is the JSON form of an auto-resolved fight.
+type fightOutput struct {
+	combat.FightOutcome
+	PlayerLP int            `json:"player_lp"`
+	Enemies  []enemyOutput  `json:"enemies"` // In the order the enemy files were given
+	Log      []string       `json:"log"`
+	Events   []combat.Event `json:"events"`
+}
+
+// enemyOutput is an enemy's LP at the end of a fight.
+type enemyOutput struct {
+	Name string `json:"name"`
+	LP   int    `json:"lp"`
+}
+
+// runFight auto-resolves a fight between a saved character and enemy files
+// and prints the combat log. The character file is not modified.
+func runFight(args []string, out io.Writer) error {
+	fs := newFlagSet("fight", "saga fight [flags] <character.json> <enemy.json> [enemy.json...]", out)
+	seed := fs.Int64("seed", 0, "seed for a reproducible fight (default: random)")
+	healBelow := fs.Int("heal-below", 0, "use the Healing Stone below this % of maximum LP (0 never)")
+	record := fs.String("record", "", "record every dice roll to this session file")
+	replay := fs.String("replay", "", "replay the dice rolls of a recorded session file")
+	ruleset := fs.String("rules", "", "ruleset profile (original, revised, custom name or .json file; default: the configured one)")
+	asJSON := fs.Bool("json", false, "print the outcome and log as JSON")
+	export := fs.String("export", "", "also export the combat log to the save directory (markdown, csv or json)")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return fmt.Errorf("a character file and at least one enemy file are required")
+	}
+	exportFormat := ""
+	if *export != "" {
+		format, err := combat.ParseExportFormat(*export)
+		if err != nil {
+			return err
+		}
+		exportFormat = format
+	}
+
+	player, enemies, err := loadCombatants(fs.Args())
+	if err != nil {
+		return err
+	}
+	profile, err := loadRuleset(*ruleset)
+	if err != nil {
+		return err
+	}
+
+	session, err := openDiceSession(*seed, *record, *replay)
+	if err != nil {
+		return err
+	}
+	roller := session.roller
+	cs := combat.StartEncounterWithRules(player, enemies, profile, roller)
+	cs.LogStart(player)
+	outcome := combat.AutoResolve(cs, player, roller, combat.FightOptions{HealBelow: *healBelow, Narrate: true})
+	if outcome.Victory {
+		combat.ResolveEncounterVictory(player, cs)
+		cs.LogVictory(player)
+	}
+	if err := session.Close(); err != nil {
+		return err
+	}
+	if report := session.ReplayReport(); report != "" {
+		fmt.Fprintln(os.Stderr, report)
+	}
+	if exportFormat != "" {
+		cfg, err := config.LoadDefault()
+		if err != nil {
+			return err
+		}
+		path, err := combat.WriteExport(cs, cfg.SaveDirectory, exportFormat, time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "Combat log exported to "+path)
+	}
+
+	if *asJSON {
+		result := fightOutput{FightOutcome: outcome, PlayerLP: player.CurrentLP, Enemies: []enemyOutput{}, Log: cs.Log(), Events: cs.Events}
+		for _, enemy := range enemies {
+			result.Enemies = append(result.Enemies, enemyOutput{Name: enemy.Name, LP: enemy.CurrentLP})
+		}
+		return writeJSON(out, result)
+	}
+
+	for _, entry := range cs.Log() {
+		fmt.Fprintln(out, entry)
+	}
+	fmt.Fprintln(out)
+	switch {
+	case outcome.Victory:
+		fmt.Fprintf(out, "Victory in %d rounds with %d/%d LP left.\n", outcome.Rounds, player.CurrentLP, player.MaximumLP)
+	case outcome.Unfinished:
+		fmt.Fprintf(out, "No winner after %d rounds.\n", outcome.Rounds)
+	default:
+		fmt.Fprintf(out, "Defeat after %d rounds.\n", outcome.Rounds)
+	}
+	return nil
+}
+
+// loadRuleset returns the ruleset profile named on the command line: a
+// built-in or custom profile, or a profile file. Without one, the profile
+// chosen in Settings is used.
+func loadRuleset(name string) (rules.Ruleset, error) {
+	if strings.HasSuffix(name, ".json") {
+		return rules.Load(name)
+	}
+	cfg, err := config.LoadDefault()
+	if err != nil {
+		return rules.Ruleset{}, err
+	}
+	if name == "" {
+		name = cfg.Ruleset
+	}
+	return rules.Find(cfg.SaveDirectory, name)
+}
+
+// loadCombatants reads a character file followed by enemy files.
+func loadCombatants(paths []string) (*character.Character, []*combat.Enemy, error) {
+	player, err := character.Load(paths[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	enemies := []*combat.Enemy{}
+	for _, path := range paths[1:] {
+		enemy, err := combat.LoadEnemy(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", path, err)
+		}
+		enemies = append(enemies, enemy)
+	}
+	return player, enemies, nil
+}
+
+// validateOutput is the JSON form of a validation report.
+type validateOutput struct {
+	File  string `json:"file"`
+	Kind  string `json:"kind"`
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+// runValidate checks a character, enemy, bestiary or save manifest file. The kind of file
+// is recognised from its fields.
+func runValidate(args []string, out io.Writer) error {
+	fs := newFlagSet("validate", "saga validate [flags] <file.json>", out)
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("a file is required")
+	}
+
+	path := fs.Arg(0)
+	report := validateOutput{File: path}
+	kind, err := validateFile(path)
+	report.Kind = kind
+	if err != nil {
+		report.Error = err.Error()
+	}
+	report.Valid = err == nil
+
+	if *asJSON {
+		if err := writeJSON(out, report); err != nil {
+			return err
+		}
+	} else if report.Valid {
+		fmt.Fprintf(out, "%s: valid %s\n", path, report.Kind)
+	}
+	if !report.Valid {
+		return fmt.Errorf("%s: invalid %s: %s", path, report.Kind, report.Error)
+	}
+	return nil
+}
+
+// validateFile loads a file as the kind of data it holds and checks it.
+func validateFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "file", err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "file", fmt.Errorf("not a JSON object: %w", err)
+	}
+
+	switch {
+	case fields["entries"] != nil:
+		_, err := combat.LoadBestiary(path)
+		return "bestiary", err
+	case fields["name"] != nil:
+		_, err := combat.LoadEnemy(path)
+		return "enemy", err
+	case fields["slots"] != nil:
+		var manifest character.Manifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return "save manifest", err
+		}
+		return "save manifest", manifest.Validate()
+	default:
+		char, err := character.Load(path)
+		if err != nil {
+			return "character", err
+		}
+		return "character", char.Validate()
+	}
+}
@@ -2,23 +2,109 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/benoit/saga-demonspawn/pkg/ui"
 )
 
+// command is a headless subcommand run instead of the TUI.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, out io.Writer) error
+}
+
+// commands lists the headless subcommands, in the order shown by usage.
+var commands = []command{
+	{"roll", "Roll dice: saga roll 2d6", runRoll},
+	{"character", "Show or create a character: saga character show|new", runCharacter},
+	{"fight", "Auto-resolve a fight and print the combat log", runFight},
+	{"simulate", "Estimate the odds of a fight over many runs", runSimulate},
+	{"validate", "Check a character, enemy, bestiary or save manifest file", runValidate},
+}
+
 func main() {
+	// Headless commands run without the TUI
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		if err := runCommand(os.Args[1], os.Args[2:], os.Stdout); err != nil && !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := runTUI(os.Args[1:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintf(os.Stderr, "Error running application: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runTUI starts the interactive companion, optionally recording or
+// replaying the session's dice rolls.
+func runTUI(args []string) error {
+	fs := newFlagSet("saga", "saga [flags] | saga <command> [flags] [arguments]", os.Stderr)
+	record := fs.String("record", "", "record every dice roll to this session file")
+	replay := fs.String("replay", "", "replay the dice rolls of a recorded session file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	session, err := openDiceSession(0, *record, *replay)
+	if err != nil {
+		return err
+	}
+
 	// Create the root model
-	model := ui.NewModel()
+	model := ui.NewModelWithRoller(session.roller)
 
 	// Create the Bubble Tea program
 	p := tea.NewProgram(model, tea.WithAltScreen())
 
 	// Run the program
-	if _, err := p.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error running application: %v\n", err)
-		os.Exit(1)
+	final, err := p.Run()
+	if m, ok := final.(ui.Model); ok {
+		// Let other instances open the slot that was in use
+		m.ReleaseSlot()
+	}
+	if err != nil {
+		session.Close()
+		return err
+	}
+	if report := session.ReplayReport(); report != "" {
+		fmt.Fprintln(os.Stderr, report)
+	}
+	return session.Close()
+}
+
+// runCommand dispatches a headless subcommand by name.
+func runCommand(name string, args []string, out io.Writer) error {
+	if name == "help" || name == "-h" || name == "--help" {
+		writeUsage(out)
+		return nil
+	}
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd.run(args, out)
+		}
+	}
+	writeUsage(os.Stderr)
+	return fmt.Errorf("unknown command %q", name)
+}
+
+// writeUsage lists the headless subcommands.
+func writeUsage(out io.Writer) {
+	fmt.Fprintln(out, "Usage: saga [command] [flags] [arguments]")
+	fmt.Fprintln(out, "\nWithout a command, saga starts the interactive companion;")
+	fmt.Fprintln(out, "-record <file> saves its dice rolls and -replay <file> plays them back.")
+	fmt.Fprintln(out, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-10s %s\n", cmd.name, cmd.summary)
 	}
+	fmt.Fprintln(out, "\nRun 'saga <command> -h' for the flags of a command.")
 }
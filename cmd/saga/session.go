@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/benoit/saga-demonspawn/internal/dice"
+)
+
+// diceSession is the roller for a run, possibly replaying a session file
+// and recording to another.
+type diceSession struct {
+	roller   dice.Roller
+	recorder *dice.RecordingRoller
+	replay   *dice.ReplayRoller
+	file     *os.File
+}
+
+// openDiceSession builds the roller for a run. Rolls are replayed from
+// replayPath first, if given, and every roll is recorded to recordPath, if
+// given. Without a replay the dice are seeded with seed (0 for random).
+func openDiceSession(seed int64, recordPath, replayPath string) (*diceSession, error) {
+	s := &diceSession{roller: newRoller(seed)}
+
+	if replayPath != "" {
+		rolls, err := dice.LoadSession(replayPath)
+		if err != nil {
+			return nil, err
+		}
+		s.replay = dice.NewReplayRoller(rolls, s.roller)
+		s.roller = s.replay
+	}
+
+	if recordPath != "" {
+		file, err := os.Create(recordPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create session file: %w", err)
+		}
+		s.file = file
+		s.recorder = dice.NewRecordingRoller(s.roller, file)
+		s.roller = s.recorder
+	}
+
+	return s, nil
+}
+
+// Close closes the session file and reports any recording error.
+func (s *diceSession) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to close session file: %w", err)
+	}
+	return s.recorder.Err()
+}
+
+// ReplayReport describes how far the replay got, or "" without a replay.
+func (s *diceSession) ReplayReport() string {
+	switch {
+	case s.replay == nil:
+		return ""
+	case s.replay.Err() != nil:
+		return fmt.Sprintf("Replay stopped: %v", s.replay.Err())
+	case s.replay.Remaining() > 0:
+		return fmt.Sprintf("Replay finished with %d recorded rolls unused", s.replay.Remaining())
+	default:
+		return ""
+	}
+}
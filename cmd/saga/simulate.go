@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/benoit/saga-demonspawn/internal/combat"
+)
+
+// simulationOutput is the JSON form of a simulation, with the derived odds.
+type simulationOutput struct {
+	combat.SimulationResult
+	WinProbability     float64
+	ExpectedRounds     float64
+	DeathSaveRate      float64
+	AverageLPRemaining float64
+}
+
+// runSimulate plays out many fights between a saved character and one or
+// more enemy files and prints the odds.
+func runSimulate(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	fs.SetOutput(out)
+	fights := fs.Int("n", 10000, "number of fights to simulate")
+	workers := fs.Int("workers", 0, "goroutines to use (default: one per CPU)")
+	seed := fs.Int64("seed", 0, "seed for reproducible results (default: random)")
+	healBelow := fs.Int("heal-below", 0, "use the Healing Stone below this % of maximum LP (0 never)")
+	ruleset := fs.String("rules", "", "ruleset profile (original, revised, custom name or .json file; default: the configured one)")
+	asJSON := fs.Bool("json", false, "print the result as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(out, "Usage: saga simulate [flags] <character.json> <enemy.json> [enemy.json...]")
+		fs.PrintDefaults()
+	}
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return fmt.Errorf("a character file and at least one enemy file are required")
+	}
+
+	player, enemies, err := loadCombatants(fs.Args())
+	if err != nil {
+		return err
+	}
+	profile, err := loadRuleset(*ruleset)
+	if err != nil {
+		return err
+	}
+
+	result, err := combat.Simulate(player, enemies, combat.SimulationOptions{
+		Fights:    *fights,
+		Workers:   *workers,
+		Seed:      *seed,
+		HealBelow: *healBelow,
+		Rules:     profile,
+	})
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return writeJSON(out, simulationOutput{
+			SimulationResult:   result,
+			WinProbability:     result.WinProbability(),
+			ExpectedRounds:     result.ExpectedRounds(),
+			DeathSaveRate:      result.DeathSaveRate(),
+			AverageLPRemaining: result.AverageLPRemaining(),
+		})
+	}
+
+	names := make([]string, len(enemies))
+	for i, enemy := range enemies {
+		names[i] = enemy.Name
+	}
+	fmt.Fprintf(out, "Fire*Wolf (%d/%d LP) vs %s\n", player.CurrentLP, player.MaximumLP, strings.Join(names, ", "))
+	writeSimulationResult(out, result)
+	return nil
+}
+
+// writeSimulationResult prints a simulation summary and LP distribution.
+func writeSimulationResult(out io.Writer, r combat.SimulationResult) {
+	fmt.Fprintf(out, "Fights simulated:   %d\n", r.Fights)
+	fmt.Fprintf(out, "Win probability:    %.1f%%\n", r.WinProbability()*100)
+	fmt.Fprintf(out, "Expected rounds:    %.1f\n", r.ExpectedRounds())
+	fmt.Fprintf(out, "Death save needed:  %.1f%% (survived %d of %d)\n", r.DeathSaveRate()*100, r.DeathSavesSurvived, r.DeathSaves)
+	fmt.Fprintf(out, "Average LP on win:  %.0f\n", r.AverageLPRemaining())
+	if r.HealingStoneUses > 0 {
+		fmt.Fprintf(out, "Healing Stone uses: %d\n", r.HealingStoneUses)
+	}
+	if r.Unfinished > 0 {
+		fmt.Fprintf(out, "Unfinished fights:  %d\n", r.Unfinished)
+	}
+
+	fmt.Fprintln(out, "\nLP remaining (% of maximum):")
+	for bucket, count := range r.LPDistribution {
+		share := 0.0
+		if r.Fights > 0 {
+			share = float64(count) / float64(r.Fights)
+		}
+		fmt.Fprintf(out, "  %-8s %6.1f%% %s\n", combat.LPBucketLabel(bucket), share*100, strings.Repeat("█", int(share*40+0.5)))
+	}
+}
@@ -0,0 +1,200 @@
+package character
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/benoit/saga-demonspawn/internal/safefile"
+)
+
+// BackupDirName is the folder of the save directory holding backups.
+const BackupDirName = "backups"
+
+// backupTimeFormat is the timestamp in backup file names.
+const backupTimeFormat = "20060102-150405.000"
+
+// BackupPolicy limits the backups kept for each save slot.
+type BackupPolicy struct {
+	MaxCount int           // Most backups kept per slot
+	MaxAge   time.Duration // Older backups are deleted; 0 keeps them regardless of age
+}
+
+// Backup is a copy of a slot's save file taken before it was overwritten.
+type Backup struct {
+	Slot string    // Slot ID the backup belongs to
+	Time time.Time // When the backup was taken
+	Path string    // Backup file
+}
+
+// BackupDir returns the backup folder of a save directory.
+func BackupDir(directory string) string {
+	return filepath.Join(directory, BackupDirName)
+}
+
+// SaveWithBackup saves the character like Save, first copying the slot's
+// previous save to the backup folder and deleting the backups the policy
+// no longer allows.
+func (c *Character) SaveWithBackup(directory string, policy BackupPolicy) error {
+	if c.Slot != "" {
+		if err := BackupSlot(directory, c.Slot, policy); err != nil {
+			return err
+		}
+	}
+	return c.Save(directory)
+}
+
+// BackupSlot copies a slot's save file to the backup folder, then prunes
+// the slot's backups. A slot without a save file yet has nothing to back up.
+func BackupSlot(directory, slot string, policy BackupPolicy) error {
+	data, err := os.ReadFile(SlotPath(directory, slot))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read save file for backup: %w", err)
+	}
+
+	if err := os.MkdirAll(BackupDir(directory), 0755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	now := time.Now()
+	path := backupPath(directory, slot, now)
+	for fileExists(path) {
+		// Saved twice within a millisecond: keep both backups
+		now = now.Add(time.Millisecond)
+		path = backupPath(directory, slot, now)
+	}
+	if err := safefile.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	return PruneBackups(directory, slot, policy, now)
+}
+
+// backupPath returns the file of a slot's backup taken at the given time.
+func backupPath(directory, slot string, taken time.Time) string {
+	return filepath.Join(BackupDir(directory), slot+"_"+taken.Format(backupTimeFormat)+".json")
+}
+
+// fileExists reports whether a file is present at path.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// ListBackups returns the backups of a slot, newest first.
+func ListBackups(directory, slot string) ([]Backup, error) {
+	paths, err := filepath.Glob(filepath.Join(BackupDir(directory), slot+"_*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	backups := []Backup{}
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		stamp := strings.TrimPrefix(name, slot+"_")
+		taken, err := time.ParseInLocation(backupTimeFormat, stamp, time.Local)
+		if err != nil {
+			continue // Another slot whose ID starts the same way
+		}
+		backups = append(backups, Backup{Slot: slot, Time: taken, Path: path})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Time.After(backups[j].Time)
+	})
+	return backups, nil
+}
+
+// PruneBackups deletes the backups of a slot beyond the policy's count,
+// and those older than its maximum age at the given time.
+func PruneBackups(directory, slot string, policy BackupPolicy, now time.Time) error {
+	backups, err := ListBackups(directory, slot)
+	if err != nil {
+		return err
+	}
+	for i, backup := range backups {
+		tooMany := policy.MaxCount > 0 && i >= policy.MaxCount
+		tooOld := policy.MaxAge > 0 && now.Sub(backup.Time) > policy.MaxAge
+		if !tooMany && !tooOld {
+			continue
+		}
+		if err := os.Remove(backup.Path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete old backup: %w", err)
+		}
+	}
+	return nil
+}
+
+// RestoreBackup replaces a slot's save with one of its backups and returns
+// the restored character. The save being replaced is backed up first, so a
+// restore can itself be undone.
+func RestoreBackup(directory string, backup Backup, policy BackupPolicy) (*Character, error) {
+	restored, err := backup.Load()
+	if err != nil {
+		return nil, err
+	}
+	restored.Slot = backup.Slot
+	if err := restored.SaveWithBackup(directory, policy); err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// Load reads the character saved in the backup.
+func (b Backup) Load() (*Character, error) {
+	return Load(b.Path)
+}
+
+// StatChange is a value that differs between two saves of a character.
+type StatChange struct {
+	Name string // e.g. "LCK" or "Weapon"
+	From string // Value in the current character
+	To   string // Value in the other save
+}
+
+// Diff lists the values that differ between the character and another
+// save of it, in character sheet order.
+func (c *Character) Diff(other *Character) []StatChange {
+	changes := []StatChange{}
+	add := func(name string, from, to interface{}) {
+		if f, t := fmt.Sprint(from), fmt.Sprint(to); f != t {
+			changes = append(changes, StatChange{Name: name, From: f, To: t})
+		}
+	}
+
+	for _, abbrev := range Characteristics {
+		from, _ := c.Characteristic(abbrev)
+		to, _ := other.Characteristic(abbrev)
+		add(abbrev, from, to)
+	}
+	add("LP", fmt.Sprintf("%d/%d", c.CurrentLP, c.MaximumLP), fmt.Sprintf("%d/%d", other.CurrentLP, other.MaximumLP))
+	add("SKL", c.Skill, other.Skill)
+	add("POW", fmt.Sprintf("%d/%d", c.CurrentPOW, c.MaximumPOW), fmt.Sprintf("%d/%d", other.CurrentPOW, other.MaximumPOW))
+	add("Section", c.CurrentSection, other.CurrentSection)
+	add("Weapon", weaponName(c), weaponName(other))
+	add("Armor", armorName(c), armorName(other))
+	add("Shield", c.HasShield, other.HasShield)
+	add("Healing Stone", c.HealingStoneCharges, other.HealingStoneCharges)
+	add("Enemies defeated", c.EnemiesDefeated, other.EnemiesDefeated)
+	return changes
+}
+
+// weaponName returns the name of the equipped weapon, or "none".
+func weaponName(c *Character) string {
+	if c.EquippedWeapon == nil {
+		return "none"
+	}
+	return c.EquippedWeapon.Name
+}
+
+// armorName returns the name of the equipped armor, or "none".
+func armorName(c *Character) string {
+	if c.EquippedArmor == nil {
+		return "none"
+	}
+	return c.EquippedArmor.Name
+}
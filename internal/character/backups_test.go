@@ -0,0 +1,157 @@
+package character
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/benoit/saga-demonspawn/internal/items"
+)
+
+// TestSaveWithBackup verifies each save backs up the previous one and the
+// number of backups is capped.
+func TestSaveWithBackup(t *testing.T) {
+	dir := t.TempDir()
+	policy := BackupPolicy{MaxCount: 3}
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+
+	// The first save has nothing to back up
+	if err := char.SaveWithBackup(dir, policy); err != nil {
+		t.Fatalf("SaveWithBackup() unexpected error: %v", err)
+	}
+	if backups, _ := ListBackups(dir, char.Slot); len(backups) != 0 {
+		t.Fatalf("ListBackups() = %d backups after the first save; want 0", len(backups))
+	}
+
+	for section := 1; section <= 5; section++ {
+		char.EnterSection(section)
+		if err := char.SaveWithBackup(dir, policy); err != nil {
+			t.Fatalf("SaveWithBackup() unexpected error: %v", err)
+		}
+	}
+
+	backups, err := ListBackups(dir, char.Slot)
+	if err != nil {
+		t.Fatalf("ListBackups() unexpected error: %v", err)
+	}
+	if len(backups) != 3 {
+		t.Fatalf("ListBackups() = %d backups; want 3", len(backups))
+	}
+	for i := 1; i < len(backups); i++ {
+		if !backups[i-1].Time.After(backups[i].Time) {
+			t.Errorf("backups not listed newest first: %v before %v", backups[i-1].Time, backups[i].Time)
+		}
+	}
+
+	// The newest backup is the save before the last one
+	latest, err := backups[0].Load()
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if latest.CurrentSection != 4 {
+		t.Errorf("newest backup at section %d; want 4", latest.CurrentSection)
+	}
+
+	// Backups stay out of the slot list
+	if slots, _ := ListSlots(dir); len(slots) != 1 {
+		t.Errorf("ListSlots() = %d slots; want 1", len(slots))
+	}
+}
+
+// TestPruneBackupsByAge verifies backups older than the age limit are deleted.
+func TestPruneBackupsByAge(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(BackupDir(dir), 0755)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.Local)
+	for _, age := range []time.Duration{time.Hour, 5 * 24 * time.Hour, 40 * 24 * time.Hour} {
+		os.WriteFile(backupPath(dir, "hero", now.Add(-age)), []byte("{}"), 0644)
+	}
+	// Another slot sharing the prefix is left alone
+	os.WriteFile(backupPath(dir, "hero-2", now.Add(-90*24*time.Hour)), []byte("{}"), 0644)
+
+	if err := PruneBackups(dir, "hero", BackupPolicy{MaxAge: 30 * 24 * time.Hour}, now); err != nil {
+		t.Fatalf("PruneBackups() unexpected error: %v", err)
+	}
+	if backups, _ := ListBackups(dir, "hero"); len(backups) != 2 {
+		t.Errorf("ListBackups() = %d backups; want the 2 younger than 30 days", len(backups))
+	}
+	if backups, _ := ListBackups(dir, "hero-2"); len(backups) != 1 {
+		t.Errorf("ListBackups(hero-2) = %d backups; want 1", len(backups))
+	}
+
+	// No age limit keeps everything
+	PruneBackups(dir, "hero", BackupPolicy{}, now.Add(365*24*time.Hour))
+	if backups, _ := ListBackups(dir, "hero"); len(backups) != 2 {
+		t.Errorf("ListBackups() = %d backups without limits; want 2", len(backups))
+	}
+}
+
+// TestRestoreBackup verifies a restore rolls the slot back and can be undone.
+func TestRestoreBackup(t *testing.T) {
+	dir := t.TempDir()
+	policy := BackupPolicy{MaxCount: 10}
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+	char.SaveAs(dir, "Hero")
+	char.EnterSection(100)
+	char.ModifyLP(-200)
+	char.SaveWithBackup(dir, policy)
+
+	backups, _ := ListBackups(dir, char.Slot)
+	if len(backups) != 1 {
+		t.Fatalf("ListBackups() = %d backups; want 1", len(backups))
+	}
+	restored, err := RestoreBackup(dir, backups[0], policy)
+	if err != nil {
+		t.Fatalf("RestoreBackup() unexpected error: %v", err)
+	}
+	if restored.CurrentSection != 0 || restored.CurrentLP != restored.MaximumLP || restored.Slot != char.Slot {
+		t.Errorf("restored = section %d, LP %d/%d, slot %q; want the first save", restored.CurrentSection,
+			restored.CurrentLP, restored.MaximumLP, restored.Slot)
+	}
+
+	saved, _ := Load(SlotPath(dir, char.Slot))
+	if saved.CurrentSection != 0 {
+		t.Errorf("slot saved at section %d after restore; want 0", saved.CurrentSection)
+	}
+	// The save that was replaced is now a backup too
+	backups, _ = ListBackups(dir, char.Slot)
+	if len(backups) != 2 {
+		t.Fatalf("ListBackups() = %d backups after restore; want 2", len(backups))
+	}
+	undo, _ := backups[0].Load()
+	if undo.CurrentSection != 100 {
+		t.Errorf("newest backup at section %d; want 100", undo.CurrentSection)
+	}
+
+	if _, err := RestoreBackup(dir, Backup{Slot: "hero", Path: filepath.Join(dir, "missing.json")}, policy); err == nil {
+		t.Error("RestoreBackup() expected error for a missing backup")
+	}
+}
+
+// TestDiff verifies the changed values are listed in character sheet order.
+func TestDiff(t *testing.T) {
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+	other := char.Clone()
+	if changes := char.Diff(other); len(changes) != 0 {
+		t.Errorf("Diff() = %v for identical saves; want none", changes)
+	}
+
+	other.Luck = 44
+	other.ModifyLP(-10)
+	other.EquippedWeapon = &items.WeaponAxe
+	changes := char.Diff(other)
+	want := []StatChange{
+		{Name: "LCK", From: "50", To: "44"},
+		{Name: "LP", From: "350/350", To: "340/350"},
+		{Name: "Weapon", From: items.WeaponSword.Name, To: items.WeaponAxe.Name},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("Diff() = %v; want %v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("Diff()[%d] = %+v; want %+v", i, changes[i], want[i])
+		}
+	}
+}
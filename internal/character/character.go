@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 	"time"
 
 	"github.com/benoit/saga-demonspawn/internal/items"
@@ -13,6 +12,9 @@ import (
 
 // Character represents Fire*Wolf with all stats, equipment, and progress.
 type Character struct {
+	// Save file format (see SchemaVersion)
+	SchemaVersion int `json:"schema_version"`
+
 	// Core characteristics (rolled at creation)
 	Strength   int `json:"strength"`   // STR: Physical power
 	Speed      int `json:"speed"`      // SPD: Agility and reaction
@@ -45,10 +47,31 @@ type Character struct {
 	OrbEquipped          bool `json:"orb_equipped"`          // Whether The Orb is held in left hand
 	OrbDestroyed         bool `json:"orb_destroyed"`         // Whether The Orb has been thrown
 
+	// Adventure progress (gamebook sections)
+	CurrentSection     int               `json:"current_section"`      // Section currently being played (0 = not started)
+	SectionHistory     []int             `json:"section_history"`      // Every section entered, in order
+	SectionFirstVisits map[int]time.Time `json:"section_first_visits"` // When each section was first entered
+	SectionStart       *Character        `json:"section_start,omitempty"` // Snapshot taken on entering the current section
+	SectionMagic       SectionMagic      `json:"section_magic"`           // Magic used in the current section
+
+	// Characteristic tests (luck tests, charm checks...)
+	TestLog []CharacteristicTest `json:"test_log,omitempty"` // Most recent tests, oldest first
+
+	// Encounter journal
+	Encounters []Encounter `json:"encounters,omitempty"` // Most recent finished fights, oldest first
+
+	// Fight in progress when the character was saved, as combat.CombatState
+	// JSON. It is kept raw because the combat package builds on this one.
+	Combat json.RawMessage `json:"combat,omitempty"`
+
+	// Save slot (see SaveAs); empty until the first save
+	Slot string `json:"slot,omitempty"` // Slot ID, named in the save manifest
+
 	// Progress tracking
-	EnemiesDefeated int       `json:"enemies_defeated"` // Total enemies killed
-	CreatedAt       time.Time `json:"created_at"`       // Character creation timestamp
-	LastSaved       time.Time `json:"last_saved"`       // Last save timestamp
+	EnemiesDefeated int        `json:"enemies_defeated"` // Total enemies killed
+	Statistics      Statistics `json:"statistics"`       // Lifetime combat and magic record
+	CreatedAt       time.Time  `json:"created_at"`       // Character creation timestamp
+	LastSaved       time.Time  `json:"last_saved"`       // Last save timestamp
 }
 
 // New creates a new character with the specified characteristics.
@@ -82,6 +105,7 @@ func New(str, spd, sta, crg, lck, chm, att int) (*Character, error) {
 	maxLP := str + spd + sta + crg + lck + chm + att
 
 	char := &Character{
+		SchemaVersion: SchemaVersion,
 		Strength:   str,
 		Speed:      spd,
 		Stamina:    sta,
@@ -105,6 +129,9 @@ func New(str, spd, sta, crg, lck, chm, att int) (*Character, error) {
 		OrbPossessed:         false,
 		OrbEquipped:          false,
 		OrbDestroyed:         false,
+		CurrentSection:     0,
+		SectionHistory:     []int{},
+		SectionFirstVisits: make(map[int]time.Time),
 		EnemiesDefeated: 0,
 		CreatedAt:      time.Now(),
 		LastSaved:      time.Now(),
@@ -261,6 +288,44 @@ func (c *Character) SetPOW(value int) {
 	c.CurrentPOW = value
 }
 
+// Resurrect replaces the seven characteristics with freshly rolled values
+// after a RESURRECTION. LP is recalculated from the new characteristics and
+// restored to full; POW is replaced by pow if magic has been unlocked.
+// Skill, equipment, special items and enemies defeated are kept.
+func (c *Character) Resurrect(str, spd, sta, crg, lck, chm, att, pow int) error {
+	values := []struct {
+		name  string
+		value int
+	}{
+		{"Strength", str}, {"Speed", spd}, {"Stamina", sta}, {"Courage", crg},
+		{"Luck", lck}, {"Charm", chm}, {"Attraction", att},
+	}
+	for _, v := range values {
+		if err := validateCharacteristic(v.name, v.value); err != nil {
+			return err
+		}
+	}
+	if c.MagicUnlocked && pow < 0 {
+		return fmt.Errorf("POW cannot be negative: %d", pow)
+	}
+
+	c.Strength = str
+	c.Speed = spd
+	c.Stamina = sta
+	c.Courage = crg
+	c.Luck = lck
+	c.Charm = chm
+	c.Attraction = att
+	c.MaximumLP = str + spd + sta + crg + lck + chm + att
+	c.CurrentLP = c.MaximumLP
+
+	if c.MagicUnlocked {
+		c.MaximumPOW = pow
+		c.CurrentPOW = pow
+	}
+	return nil
+}
+
 // SetMaxPOW sets maximum power.
 func (c *Character) SetMaxPOW(value int) error {
 	if value < 0 {
@@ -385,33 +450,90 @@ func (c *Character) GetWeaponDamageBonus() int {
 	return 0
 }
 
-// Save saves the character to a JSON file in the specified directory.
-// The filename includes a timestamp for versioning.
+// Save writes the character to its save slot in the given directory and
+// records the slot in the manifest. A character without a slot, such as a
+// new one, gets a slot named after its creation date.
 func (c *Character) Save(directory string) error {
-	c.LastSaved = time.Now()
+	return withManifestLock(directory, func() error {
+		manifest, err := LoadManifest(directory)
+		if err != nil {
+			return err
+		}
 
-	// Create filename with timestamp
-	timestamp := c.LastSaved.Format("20060102-150405")
-	filename := fmt.Sprintf("character_%s.json", timestamp)
-	filepath := filepath.Join(directory, filename)
+		name := ""
+		if c.Slot == "" || manifest.Find(c.Slot) == nil {
+			name = manifest.freeName(c.DefaultSlotName())
+			if c.Slot == "" {
+				c.Slot = manifest.newSlotID(directory, name)
+			}
+		}
+		return c.saveSlot(directory, manifest, name)
+	})
+}
 
-	// Ensure directory exists
-	if err := os.MkdirAll(directory, 0755); err != nil {
-		return fmt.Errorf("failed to create save directory: %w", err)
+// SavePath returns the file of the character's save slot in the given
+// directory.
+func (c *Character) SavePath(directory string) string {
+	return SlotPath(directory, c.Slot)
+}
+
+// Validate checks that the character's values are consistent.
+func (c *Character) Validate() error {
+	characteristics := []struct {
+		name  string
+		value int
+	}{
+		{"strength", c.Strength}, {"speed", c.Speed}, {"stamina", c.Stamina},
+		{"courage", c.Courage}, {"luck", c.Luck}, {"charm", c.Charm},
+		{"attraction", c.Attraction},
+	}
+	for _, ch := range characteristics {
+		if err := validateCharacteristic(ch.name, ch.value); err != nil {
+			return err
+		}
 	}
 
-	// Marshal character to JSON with indentation for readability
-	data, err := json.MarshalIndent(c, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal character: %w", err)
+	if c.MaximumLP < 0 {
+		return fmt.Errorf("maximum LP cannot be negative: %d", c.MaximumLP)
+	}
+	if c.Skill < 0 {
+		return fmt.Errorf("skill cannot be negative: %d", c.Skill)
+	}
+	if c.CurrentPOW < 0 || c.MaximumPOW < 0 {
+		return fmt.Errorf("POW cannot be negative: %d/%d", c.CurrentPOW, c.MaximumPOW)
+	}
+	if c.CurrentSection < 0 {
+		return fmt.Errorf("current section cannot be negative: %d", c.CurrentSection)
 	}
 
-	// Write to file
-	if err := os.WriteFile(filepath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write save file: %w", err)
+	if c.EquippedWeapon != nil && c.EquippedWeapon.Name == "" {
+		return fmt.Errorf("equipped weapon has no name")
+	}
+	if c.EquippedArmor != nil && c.EquippedArmor.Name == "" {
+		return fmt.Errorf("equipped armor has no name")
+	}
+	for effect := range c.ActiveSpellEffects {
+		if effect == "" {
+			return fmt.Errorf("active spell effect has no name")
+		}
+	}
+	if c.Slot != "" && !validSlotID(c.Slot) {
+		return fmt.Errorf("invalid save slot ID: %q", c.Slot)
+	}
+	if err := validateProgress(c); err != nil {
+		return err
+	}
+	if err := validateTestLog(c.TestLog); err != nil {
+		return err
+	}
+	if err := c.Statistics.validate(); err != nil {
+		return err
+	}
+	if err := validateEncounters(c.Encounters); err != nil {
+		return err
 	}
 
-	return nil
+	return validateSpecialItems(c)
 }
 
 // validateSpecialItems validates special item state consistency.
@@ -442,13 +564,20 @@ func validateSpecialItems(c *Character) error {
 	return nil
 }
 
-// Load loads a character from a JSON file.
+// Load loads a character from a JSON file. Saves written by older versions
+// are migrated to the current schema; saves from newer versions are
+// refused with ErrNewerSchema.
 func Load(filepath string) (*Character, error) {
 	data, err := os.ReadFile(filepath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read save file: %w", err)
 	}
 
+	data, _, err = migrateSave(data)
+	if err != nil {
+		return nil, err
+	}
+
 	var char Character
 	if err := json.Unmarshal(data, &char); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal character: %w", err)
@@ -458,10 +587,15 @@ func Load(filepath string) (*Character, error) {
 	if char.ActiveSpellEffects == nil {
 		char.ActiveSpellEffects = make(map[string]int)
 	}
+	if char.SectionHistory == nil {
+		char.SectionHistory = []int{}
+	}
+	if char.SectionFirstVisits == nil {
+		char.SectionFirstVisits = make(map[int]time.Time)
+	}
 	
-	// Validate special item state
-	if err := validateSpecialItems(&char); err != nil {
-		return nil, fmt.Errorf("invalid special item state: %w", err)
+	if err := char.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid save file: %w", err)
 	}
 
 	return &char, nil
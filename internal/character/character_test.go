@@ -1,8 +1,6 @@
 package character
 
 import (
-	"os"
-	"path/filepath"
 	"testing"
 
 	"github.com/benoit/saga-demonspawn/internal/items"
@@ -351,6 +349,47 @@ func TestIncrementEnemiesDefeated(t *testing.T) {
 	}
 }
 
+// TestResurrect verifies that a resurrection rerolls stats but keeps progress.
+func TestResurrect(t *testing.T) {
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+	char.SetSkill(12)
+	char.IncrementEnemiesDefeated()
+	char.AcquireHealingStone()
+	char.UnlockMagic(40)
+	char.ModifyLP(-char.CurrentLP)
+
+	if err := char.Resurrect(16, 24, 32, 40, 48, 56, 64, 72); err != nil {
+		t.Fatalf("Resurrect() unexpected error: %v", err)
+	}
+
+	if char.Strength != 16 || char.Attraction != 64 {
+		t.Errorf("Resurrect() stats = STR %d ATT %d; want 16 and 64", char.Strength, char.Attraction)
+	}
+	if char.MaximumLP != 280 || char.CurrentLP != 280 {
+		t.Errorf("Resurrect() LP = %d/%d; want 280/280", char.CurrentLP, char.MaximumLP)
+	}
+	if char.CurrentPOW != 72 || char.MaximumPOW != 72 {
+		t.Errorf("Resurrect() POW = %d/%d; want 72/72", char.CurrentPOW, char.MaximumPOW)
+	}
+	if char.Skill != 12 || char.EnemiesDefeated != 1 || char.HealingStoneCharges != 50 {
+		t.Errorf("Resurrect() lost progress: skill %d, enemies %d, stone %d", char.Skill, char.EnemiesDefeated, char.HealingStoneCharges)
+	}
+
+	if err := char.Resurrect(-1, 24, 32, 40, 48, 56, 64, 72); err == nil {
+		t.Error("Resurrect() expected error for negative characteristic")
+	}
+}
+
+// TestResurrectWithoutMagic verifies that POW stays locked.
+func TestResurrectWithoutMagic(t *testing.T) {
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+
+	char.Resurrect(40, 40, 40, 40, 40, 40, 40, 60)
+	if char.MagicUnlocked || char.MaximumPOW != 0 {
+		t.Errorf("Resurrect() POW = %d, unlocked %v; want 0 and locked", char.MaximumPOW, char.MagicUnlocked)
+	}
+}
+
 // TestSaveAndLoad verifies character persistence.
 func TestSaveAndLoad(t *testing.T) {
 	// Create a temporary directory for testing
@@ -370,18 +409,8 @@ func TestSaveAndLoad(t *testing.T) {
 		t.Fatalf("Save() unexpected error: %v", err)
 	}
 
-	// Find the saved file
-	files, err := os.ReadDir(tempDir)
-	if err != nil {
-		t.Fatalf("Failed to read temp dir: %v", err)
-	}
-	if len(files) != 1 {
-		t.Fatalf("Expected 1 save file, got %d", len(files))
-	}
-
-	// Load character
-	savePath := filepath.Join(tempDir, files[0].Name())
-	loaded, err := Load(savePath)
+	// Load character from its slot
+	loaded, err := Load(original.SavePath(tempDir))
 	if err != nil {
 		t.Fatalf("Load() unexpected error: %v", err)
 	}
@@ -420,3 +449,38 @@ func TestLoadNonexistentFile(t *testing.T) {
 		t.Error("Load() expected error for nonexistent file")
 	}
 }
+
+// TestValidate verifies consistency checks on a character.
+func TestValidate(t *testing.T) {
+	char, _ := New(64, 56, 72, 48, 80, 40, 56)
+	if err := char.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error for a new character: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *Character)
+	}{
+		{"Negative characteristic", func(c *Character) { c.Luck = -1 }},
+		{"Characteristic too high", func(c *Character) { c.Strength = 1000 }},
+		{"Negative skill", func(c *Character) { c.Skill = -3 }},
+		{"Negative POW", func(c *Character) { c.CurrentPOW = -1 }},
+		{"Negative section", func(c *Character) { c.CurrentSection = -2 }},
+		{"Orb equipped and destroyed", func(c *Character) { c.OrbPossessed, c.OrbEquipped, c.OrbDestroyed = true, true, true }},
+		{"Invalid section in history", func(c *Character) { c.SectionHistory = []int{3, -1} }},
+		{"Nested section snapshot", func(c *Character) { c.SectionStart = c.Clone(); c.SectionStart.SectionStart = c.Clone() }},
+		{"Invalid section snapshot", func(c *Character) { c.SectionStart = c.Clone(); c.SectionStart.Skill = -1 }},
+		{"Unknown characteristic tested", func(c *Character) { c.TestLog = []CharacteristicTest{{Characteristic: "POW", Multiplier: 8, Dice: 6, Roll: 48}} }},
+		{"Invalid slot ID", func(c *Character) { c.Slot = "a/b" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := char.Clone()
+			tt.modify(c)
+			if err := c.Validate(); err == nil {
+				t.Error("Validate() expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package character
+
+import (
+	"fmt"
+	"time"
+)
+
+// Characteristics lists the abbreviations of the seven characteristics,
+// in the order of the character sheet.
+var Characteristics = []string{"STR", "SPD", "STA", "CRG", "LCK", "CHM", "ATT"}
+
+// characteristicNames gives the full name of each characteristic.
+var characteristicNames = map[string]string{
+	"STR": "Strength",
+	"SPD": "Speed",
+	"STA": "Stamina",
+	"CRG": "Courage",
+	"LCK": "Luck",
+	"CHM": "Charm",
+	"ATT": "Attraction",
+}
+
+// CharacteristicName returns the full name of a characteristic, e.g. "Luck"
+// for "LCK".
+func CharacteristicName(abbrev string) string {
+	return characteristicNames[abbrev]
+}
+
+// Characteristic returns the value of a characteristic by abbreviation.
+func (c *Character) Characteristic(abbrev string) (int, error) {
+	switch abbrev {
+	case "STR":
+		return c.Strength, nil
+	case "SPD":
+		return c.Speed, nil
+	case "STA":
+		return c.Stamina, nil
+	case "CRG":
+		return c.Courage, nil
+	case "LCK":
+		return c.Luck, nil
+	case "CHM":
+		return c.Charm, nil
+	case "ATT":
+		return c.Attraction, nil
+	}
+	return 0, fmt.Errorf("unknown characteristic %q", abbrev)
+}
+
+// TestFormula is a way of testing a characteristic: roll 2d6, multiply by
+// Multiplier, and pass if the result is at most the characteristic.
+type TestFormula struct {
+	Name       string // e.g. "2d6 × 8 ≤ stat"
+	Multiplier int    // Applied to the 2d6 roll
+}
+
+// TestFormulas lists the tests the gamebook calls for. 2d6 × 8 matches the
+// range characteristics are rolled in; 2d6 × 10 is the death save formula.
+var TestFormulas = []TestFormula{
+	{Name: "2d6 × 8 ≤ stat", Multiplier: 8},
+	{Name: "2d6 × 10 ≤ stat", Multiplier: 10},
+}
+
+// maxTestLog is how many characteristic tests are kept in the log.
+const maxTestLog = 200
+
+// CharacteristicTest is one test against a characteristic, as logged.
+type CharacteristicTest struct {
+	Characteristic string    `json:"characteristic"`    // Abbreviation, e.g. "LCK"
+	Value          int       `json:"value"`             // Characteristic at the time of the test
+	Multiplier     int       `json:"multiplier"`        // 8 or 10
+	Dice           int       `json:"dice"`              // The 2d6 roll
+	Roll           int       `json:"roll"`              // Dice × Multiplier
+	Passed         bool      `json:"passed"`            // Whether Roll ≤ Value
+	Section        int       `json:"section,omitempty"` // Section the test was made in
+	Time           time.Time `json:"time"`              // When the test was made
+}
+
+// String describes the test, e.g. "LCK 2d6×10: 6×10 = 60 ≤ 56 FAIL".
+func (t CharacteristicTest) String() string {
+	outcome := "FAIL"
+	if t.Passed {
+		outcome = "PASS"
+	}
+	return fmt.Sprintf("%s 2d6×%d: %d×%d = %d ≤ %d %s",
+		t.Characteristic, t.Multiplier, t.Dice, t.Multiplier, t.Roll, t.Value, outcome)
+}
+
+// TestCharacteristic resolves a test against a characteristic with the
+// given 2d6 roll and adds it to the test log. The log keeps the most recent
+// tests and, like the section history, survives TIMEWARP.
+func (c *Character) TestCharacteristic(abbrev string, formula TestFormula, dice int) (CharacteristicTest, error) {
+	value, err := c.Characteristic(abbrev)
+	if err != nil {
+		return CharacteristicTest{}, err
+	}
+	if dice < 2 || dice > 12 {
+		return CharacteristicTest{}, fmt.Errorf("2d6 roll must be between 2 and 12: %d", dice)
+	}
+	if formula.Multiplier <= 0 {
+		return CharacteristicTest{}, fmt.Errorf("test multiplier must be positive: %d", formula.Multiplier)
+	}
+
+	test := CharacteristicTest{
+		Characteristic: abbrev,
+		Value:          value,
+		Multiplier:     formula.Multiplier,
+		Dice:           dice,
+		Roll:           dice * formula.Multiplier,
+		Section:        c.CurrentSection,
+		Time:           time.Now(),
+	}
+	test.Passed = test.Roll <= value
+
+	c.TestLog = append(c.TestLog, test)
+	if len(c.TestLog) > maxTestLog {
+		c.TestLog = c.TestLog[len(c.TestLog)-maxTestLog:]
+	}
+	return test, nil
+}
+
+// validateTestLog checks that every logged test could have been made by
+// TestCharacteristic.
+func validateTestLog(log []CharacteristicTest) error {
+	if len(log) > maxTestLog {
+		return fmt.Errorf("test log holds %d tests (%d at most)", len(log), maxTestLog)
+	}
+	for i, test := range log {
+		switch {
+		case characteristicNames[test.Characteristic] == "":
+			return fmt.Errorf("test %d: unknown characteristic %q", i+1, test.Characteristic)
+		case test.Multiplier <= 0:
+			return fmt.Errorf("test %d: multiplier must be positive: %d", i+1, test.Multiplier)
+		case test.Dice < 2 || test.Dice > 12:
+			return fmt.Errorf("test %d: 2d6 roll must be between 2 and 12: %d", i+1, test.Dice)
+		case test.Roll != test.Dice*test.Multiplier:
+			return fmt.Errorf("test %d: roll %d is not %d×%d", i+1, test.Roll, test.Dice, test.Multiplier)
+		}
+	}
+	return nil
+}
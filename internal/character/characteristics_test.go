@@ -0,0 +1,79 @@
+package character
+
+import "testing"
+
+// TestCharacteristic verifies characteristics are looked up by abbreviation.
+func TestCharacteristic(t *testing.T) {
+	char, _ := New(10, 20, 30, 40, 50, 60, 70)
+
+	for i, abbrev := range Characteristics {
+		value, err := char.Characteristic(abbrev)
+		if err != nil || value != (i+1)*10 {
+			t.Errorf("Characteristic(%q) = %d, %v; want %d", abbrev, value, err, (i+1)*10)
+		}
+		if CharacteristicName(abbrev) == "" {
+			t.Errorf("CharacteristicName(%q) is empty", abbrev)
+		}
+	}
+	if _, err := char.Characteristic("POW"); err == nil {
+		t.Error("Characteristic(POW) expected error")
+	}
+}
+
+// TestTestCharacteristic verifies tests are resolved and logged.
+func TestTestCharacteristic(t *testing.T) {
+	char, _ := New(50, 50, 50, 50, 56, 50, 50)
+	char.EnterSection(12)
+
+	tests := []struct {
+		formula TestFormula
+		dice    int
+		roll    int
+		passed  bool
+	}{
+		{TestFormulas[0], 7, 56, true},  // 2d6 × 8, equal passes
+		{TestFormulas[0], 8, 64, false}, // 2d6 × 8
+		{TestFormulas[1], 5, 50, true},  // 2d6 × 10
+		{TestFormulas[1], 6, 60, false}, // 2d6 × 10
+	}
+	for _, tt := range tests {
+		result, err := char.TestCharacteristic("LCK", tt.formula, tt.dice)
+		if err != nil {
+			t.Fatalf("TestCharacteristic() unexpected error: %v", err)
+		}
+		if result.Roll != tt.roll || result.Passed != tt.passed || result.Value != 56 || result.Section != 12 {
+			t.Errorf("TestCharacteristic(%s, %d) = %+v; want roll %d, passed %v", tt.formula.Name, tt.dice, result, tt.roll, tt.passed)
+		}
+	}
+
+	if len(char.TestLog) != len(tests) {
+		t.Errorf("TestLog has %d entries; want %d", len(char.TestLog), len(tests))
+	}
+	if got, want := char.TestLog[3].String(), "LCK 2d6×10: 6×10 = 60 ≤ 56 FAIL"; got != want {
+		t.Errorf("String() = %q; want %q", got, want)
+	}
+
+	if _, err := char.TestCharacteristic("LCK", TestFormulas[0], 13); err == nil {
+		t.Error("TestCharacteristic() expected error for a roll of 13")
+	}
+	if _, err := char.TestCharacteristic("XYZ", TestFormulas[0], 7); err == nil {
+		t.Error("TestCharacteristic() expected error for an unknown characteristic")
+	}
+	if len(char.TestLog) != len(tests) {
+		t.Error("rejected tests should not be logged")
+	}
+}
+
+// TestTestLogSurvivesTimewarp verifies rolling back a section keeps the test log.
+func TestTestLogSurvivesTimewarp(t *testing.T) {
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+	char.EnterSection(1)
+	char.TestCharacteristic("CHM", TestFormulas[0], 6)
+
+	if err := char.RestoreSectionStart(); err != nil {
+		t.Fatalf("RestoreSectionStart() unexpected error: %v", err)
+	}
+	if len(char.TestLog) != 1 {
+		t.Errorf("TestLog has %d entries after TIMEWARP; want 1", len(char.TestLog))
+	}
+}
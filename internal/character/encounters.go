@@ -0,0 +1,117 @@
+package character
+
+import (
+	"fmt"
+	"time"
+)
+
+// How an encounter in the journal ended.
+const (
+	EncounterVictory      = "victory"      // Every enemy died
+	EncounterFled         = "fled"         // Fire*Wolf ran away
+	EncounterParalysis    = "paralysis"    // PARALYSIS let Fire*Wolf escape
+	EncounterInvisibility = "invisibility" // INVISIBILITY hid Fire*Wolf from the enemy
+	EncounterDefeat       = "defeat"       // Fire*Wolf died, or was drained by Doombringer
+)
+
+// encounterOutcomes lists the valid encounter outcomes.
+var encounterOutcomes = map[string]bool{
+	EncounterVictory:      true,
+	EncounterFled:         true,
+	EncounterParalysis:    true,
+	EncounterInvisibility: true,
+	EncounterDefeat:       true,
+}
+
+// maxEncounters is how many encounters are kept in the journal.
+const maxEncounters = 100
+
+// EnemySnapshot is an enemy as it stood when an encounter began.
+type EnemySnapshot struct {
+	Name            string `json:"name"`
+	Strength        int    `json:"strength"`
+	Speed           int    `json:"speed"`
+	Stamina         int    `json:"stamina"`
+	Courage         int    `json:"courage"`
+	Luck            int    `json:"luck"`
+	Skill           int    `json:"skill"`
+	MaximumLP       int    `json:"maximum_lp"`
+	LPBefore        int    `json:"lp_before"`
+	LPAfter         int    `json:"lp_after"`
+	WeaponBonus     int    `json:"weapon_bonus"`
+	ArmorProtection int    `json:"armor_protection"`
+	IsDemonspawn    bool   `json:"is_demonspawn"`
+}
+
+// Encounter is one finished fight, as kept in the encounter journal.
+type Encounter struct {
+	Time     time.Time       `json:"time"`              // When the fight ended
+	Section  int             `json:"section,omitempty"` // Section the fight was in
+	Enemies  []EnemySnapshot `json:"enemies"`
+	Outcome  string          `json:"outcome"` // One of the Encounter* outcomes
+	Rounds   int             `json:"rounds"`  // Rounds fought, including those before a death save
+	LPBefore int             `json:"lp_before"`
+	LPAfter  int             `json:"lp_after"`
+	Items    []string        `json:"items,omitempty"`  // Special items used, e.g. "Healing Stone"
+	Spells   []string        `json:"spells,omitempty"` // Spells cast, whether they worked or not
+	Log      []string        `json:"log"`              // The combat log, as shown in the fight
+}
+
+// EnemyNames lists the encounter's enemies, e.g. "Orc, Wolf".
+func (e Encounter) EnemyNames() string {
+	names := ""
+	for i, enemy := range e.Enemies {
+		if i > 0 {
+			names += ", "
+		}
+		names += enemy.Name
+	}
+	return names
+}
+
+// AddEncounter adds a finished fight to the encounter journal. The journal
+// keeps the most recent encounters and, like the test log, survives
+// TIMEWARP.
+func (c *Character) AddEncounter(encounter Encounter) error {
+	if err := encounter.validate(); err != nil {
+		return err
+	}
+	c.Encounters = append(c.Encounters, encounter)
+	if len(c.Encounters) > maxEncounters {
+		c.Encounters = c.Encounters[len(c.Encounters)-maxEncounters:]
+	}
+	return nil
+}
+
+// validate checks that the encounter could have been recorded by a fight.
+func (e Encounter) validate() error {
+	switch {
+	case len(e.Enemies) == 0:
+		return fmt.Errorf("encounter has no enemies")
+	case !encounterOutcomes[e.Outcome]:
+		return fmt.Errorf("encounter against %s: unknown outcome %q", e.EnemyNames(), e.Outcome)
+	case e.Rounds < 0:
+		return fmt.Errorf("encounter against %s: rounds cannot be negative: %d", e.EnemyNames(), e.Rounds)
+	case e.Section < 0:
+		return fmt.Errorf("encounter against %s: invalid section: %d", e.EnemyNames(), e.Section)
+	}
+	for i, enemy := range e.Enemies {
+		if enemy.Name == "" {
+			return fmt.Errorf("encounter enemy %d has no name", i+1)
+		}
+	}
+	return nil
+}
+
+// validateEncounters checks every encounter in the journal.
+func validateEncounters(encounters []Encounter) error {
+	if len(encounters) > maxEncounters {
+		return fmt.Errorf("encounter journal holds %d encounters (%d at most)", len(encounters), maxEncounters)
+	}
+	for i, encounter := range encounters {
+		if err := encounter.validate(); err != nil {
+			return fmt.Errorf("encounter %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package character
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// testEncounter returns a valid encounter against a Goblin.
+func testEncounter(outcome string) Encounter {
+	return Encounter{
+		Time:     time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Section:  12,
+		Enemies:  []EnemySnapshot{{Name: "Goblin", MaximumLP: 150, LPBefore: 150}},
+		Outcome:  outcome,
+		Rounds:   3,
+		LPBefore: 400,
+		LPAfter:  350,
+		Log:      []string{"Combat begins against Goblin!"},
+	}
+}
+
+// TestAddEncounter verifies the journal keeps the most recent encounters,
+// is saved, and survives a section rollback.
+func TestAddEncounter(t *testing.T) {
+	dir := t.TempDir()
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+	char.EnterSection(12)
+
+	for i := 0; i < maxEncounters+5; i++ {
+		encounter := testEncounter(EncounterVictory)
+		encounter.Rounds = i
+		if err := char.AddEncounter(encounter); err != nil {
+			t.Fatalf("AddEncounter() unexpected error: %v", err)
+		}
+	}
+	if len(char.Encounters) != maxEncounters || char.Encounters[0].Rounds != 5 {
+		t.Errorf("journal holds %d encounters starting at %d rounds; want %d starting at 5",
+			len(char.Encounters), char.Encounters[0].Rounds, maxEncounters)
+	}
+
+	if err := char.Save(dir); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+	loaded, err := Load(char.SavePath(dir))
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Encounters, char.Encounters) {
+		t.Error("loaded encounters differ from the saved ones")
+	}
+
+	if err := char.RestoreSectionStart(); err != nil {
+		t.Fatalf("RestoreSectionStart() unexpected error: %v", err)
+	}
+	if len(char.Encounters) != maxEncounters {
+		t.Errorf("journal after rollback holds %d encounters; want %d", len(char.Encounters), maxEncounters)
+	}
+	if char.SectionStart.Encounters != nil {
+		t.Error("section snapshot should leave out the encounter journal")
+	}
+}
+
+// TestAddEncounterInvalid verifies an encounter that no fight could have
+// produced is rejected.
+func TestAddEncounterInvalid(t *testing.T) {
+	noEnemies := testEncounter(EncounterFled)
+	noEnemies.Enemies = nil
+	negativeRounds := testEncounter(EncounterDefeat)
+	negativeRounds.Rounds = -1
+	unnamed := testEncounter(EncounterParalysis)
+	unnamed.Enemies[0].Name = ""
+
+	tests := []struct {
+		name      string
+		encounter Encounter
+	}{
+		{"No enemies", noEnemies},
+		{"Unknown outcome", testEncounter("draw")},
+		{"Negative rounds", negativeRounds},
+		{"Unnamed enemy", unnamed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char, _ := New(50, 50, 50, 50, 50, 50, 50)
+			if err := char.AddEncounter(tt.encounter); err == nil {
+				t.Error("AddEncounter() expected error")
+			}
+			char.Encounters = []Encounter{tt.encounter}
+			if err := char.Validate(); err == nil {
+				t.Error("Validate() expected error")
+			}
+		})
+	}
+}
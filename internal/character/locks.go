@@ -0,0 +1,80 @@
+package character
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/benoit/saga-demonspawn/internal/safefile"
+)
+
+// manifestLockName is the lock file taken while the manifest is updated.
+const manifestLockName = "saves.lock"
+
+// manifestLockWait is how long a save waits for another instance to finish
+// updating the manifest.
+const manifestLockWait = 2 * time.Second
+
+// SlotLockPath returns the lock file of a slot in the save directory.
+func SlotLockPath(directory, id string) string {
+	return filepath.Join(directory, "slot_"+id+".lock")
+}
+
+// LockSlot marks a save slot as open in this instance of saga until the
+// lock is released. Another instance then cannot open or save the slot:
+// it gets an error wrapping safefile.ErrLocked.
+func LockSlot(directory, id string) (*safefile.Lock, error) {
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create save directory: %w", err)
+	}
+	lock, err := safefile.TryLock(SlotLockPath(directory, id))
+	if err != nil {
+		return nil, slotInUse(directory, id, err)
+	}
+	return lock, nil
+}
+
+// checkSlotLock returns an error if another instance has the slot open.
+func checkSlotLock(directory, id string) error {
+	if err := safefile.HeldByOther(SlotLockPath(directory, id)); err != nil {
+		return slotInUse(directory, id, err)
+	}
+	return nil
+}
+
+// slotInUse names the slot in a lock error, e.g. `save "Hero" is in use by
+// another instance of saga (...)`.
+func slotInUse(directory, id string, err error) error {
+	if !errors.Is(err, safefile.ErrLocked) {
+		return err
+	}
+	name := SlotName(directory, id)
+	if name == "" {
+		name = id
+	}
+	return fmt.Errorf("save %q is %w", name, err)
+}
+
+// withManifestLock runs update while holding the manifest lock, so two
+// instances saving at once do not lose each other's slots.
+func withManifestLock(directory string, update func() error) error {
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return fmt.Errorf("failed to create save directory: %w", err)
+	}
+
+	path := filepath.Join(directory, manifestLockName)
+	deadline := time.Now().Add(manifestLockWait)
+	for {
+		lock, err := safefile.TryLock(path)
+		if err == nil {
+			defer lock.Unlock()
+			return update()
+		}
+		if !errors.Is(err, safefile.ErrLocked) || time.Now().After(deadline) {
+			return fmt.Errorf("save manifest is %w", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,71 @@
+package character
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benoit/saga-demonspawn/internal/safefile"
+)
+
+// TestLockSlot verifies a slot open in this instance can still be saved
+// and is free again once released.
+func TestLockSlot(t *testing.T) {
+	dir := t.TempDir()
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+	char.SaveAs(dir, "Hero")
+
+	lock, err := LockSlot(dir, char.Slot)
+	if err != nil {
+		t.Fatalf("LockSlot() unexpected error: %v", err)
+	}
+	if err := char.Save(dir); err != nil {
+		t.Errorf("Save() unexpected error on a slot locked by this instance: %v", err)
+	}
+	lock.Unlock()
+	if _, err := os.Stat(SlotLockPath(dir, char.Slot)); !os.IsNotExist(err) {
+		t.Error("lock file left after Unlock()")
+	}
+	if _, err := os.Stat(filepath.Join(dir, manifestLockName)); !os.IsNotExist(err) {
+		t.Error("manifest lock file left after saving")
+	}
+}
+
+// TestSaveInUse verifies a slot open in another instance cannot be
+// opened or overwritten.
+func TestSaveInUse(t *testing.T) {
+	dir := t.TempDir()
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+	char.SaveAs(dir, "Hero")
+
+	// Another instance, still running, has the slot open
+	host, _ := os.Hostname()
+	data, _ := json.Marshal(safefile.LockOwner{PID: os.Getppid(), Host: host, Since: time.Now()})
+	os.WriteFile(SlotLockPath(dir, char.Slot), data, 0644)
+
+	_, err := LockSlot(dir, char.Slot)
+	if !errors.Is(err, safefile.ErrLocked) {
+		t.Fatalf("LockSlot() error = %v; want ErrLocked", err)
+	}
+	if !strings.Contains(err.Error(), `save "Hero" is in use`) {
+		t.Errorf("LockSlot() error = %q; want it to name the slot", err)
+	}
+
+	char.EnterSection(12)
+	if err := char.Save(dir); !errors.Is(err, safefile.ErrLocked) {
+		t.Errorf("Save() error = %v; want ErrLocked", err)
+	}
+	if saved, _ := Load(char.SavePath(dir)); saved.CurrentSection != 0 {
+		t.Error("Save() overwrote a slot open in another instance")
+	}
+
+	// Other slots are unaffected
+	other, _ := New(40, 40, 40, 40, 40, 40, 40)
+	if err := other.Save(dir); err != nil {
+		t.Errorf("Save() unexpected error for another slot: %v", err)
+	}
+}
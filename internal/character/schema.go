@@ -0,0 +1,125 @@
+package character
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// SchemaVersion is the version of the save file format written by Save.
+// Bump it, and register a migration from the previous version, whenever a
+// change to Character would misread older save files.
+const SchemaVersion = 2
+
+// ErrNewerSchema is returned when loading a save file written by a newer
+// version of saga than this one.
+var ErrNewerSchema = errors.New("save file was written by a newer version of saga")
+
+// migration upgrades a decoded save file by one schema version.
+type migration struct {
+	from        int                                     // Version read; the save is at from+1 afterwards
+	description string                                  // What changed in the format
+	apply       func(save map[string]interface{}) error // Edits the save in place
+}
+
+// migrations holds one migration per schema version before SchemaVersion,
+// oldest first. Files from before schema_version existed are version 1.
+var migrations = []migration{
+	{
+		from:        1,
+		description: "record schema_version and fill the collections missing from early saves",
+		apply:       migrateUnversioned,
+	},
+}
+
+// migrateSave upgrades the JSON of a save file to SchemaVersion, one step at
+// a time, and returns it with the version it was read at.
+func migrateSave(data []byte) ([]byte, int, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var save map[string]interface{}
+	if err := decoder.Decode(&save); err != nil {
+		return nil, 0, fmt.Errorf("failed to unmarshal character: %w", err)
+	}
+
+	version, err := saveVersion(save)
+	if err != nil {
+		return nil, 0, err
+	}
+	if version > SchemaVersion {
+		return nil, version, fmt.Errorf("%w (schema version %d; this version reads up to %d)", ErrNewerSchema, version, SchemaVersion)
+	}
+	if version == SchemaVersion {
+		return data, version, nil
+	}
+
+	for v := version; v < SchemaVersion; v++ {
+		step := findMigration(v)
+		if step == nil {
+			return nil, version, fmt.Errorf("no migration from save schema version %d", v)
+		}
+		if err := step.apply(save); err != nil {
+			return nil, version, fmt.Errorf("failed to migrate save from schema version %d: %w", v, err)
+		}
+		save["schema_version"] = v + 1
+	}
+
+	migrated, err := json.Marshal(save)
+	if err != nil {
+		return nil, version, fmt.Errorf("failed to marshal migrated save: %w", err)
+	}
+	return migrated, version, nil
+}
+
+// saveVersion reads the schema version of a decoded save file.
+func saveVersion(save map[string]interface{}) (int, error) {
+	raw, ok := save["schema_version"]
+	if !ok || raw == nil {
+		return 1, nil
+	}
+	number, ok := raw.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("invalid schema_version: %v", raw)
+	}
+	version, err := number.Int64()
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid schema_version: %s", number)
+	}
+	return int(version), nil
+}
+
+// findMigration returns the migration reading the given version, or nil.
+func findMigration(from int) *migration {
+	for i := range migrations {
+		if migrations[i].from == from {
+			return &migrations[i]
+		}
+	}
+	return nil
+}
+
+// migrateUnversioned upgrades a save written before schema_version existed.
+// Early saves have no section tracking and may hold null for the spell
+// effects; the section snapshot, if any, gets the same treatment.
+func migrateUnversioned(save map[string]interface{}) error {
+	fillMissing(save, "active_spell_effects", map[string]interface{}{})
+	fillMissing(save, "section_history", []interface{}{})
+	fillMissing(save, "section_first_visits", map[string]interface{}{})
+
+	switch snapshot := save["section_start"].(type) {
+	case nil:
+	case map[string]interface{}:
+		fillMissing(snapshot, "active_spell_effects", map[string]interface{}{})
+	default:
+		return fmt.Errorf("section_start is not an object")
+	}
+	return nil
+}
+
+// fillMissing sets a field of a decoded save that is absent or null.
+func fillMissing(save map[string]interface{}, field string, value interface{}) {
+	if save[field] == nil {
+		save[field] = value
+	}
+}
@@ -0,0 +1,121 @@
+package character
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// unversionedSave is a save file as written before schema_version existed.
+const unversionedSave = `{
+  "strength": 64, "speed": 56, "stamina": 72, "courage": 48,
+  "luck": 80, "charm": 40, "attraction": 56,
+  "current_lp": 300, "maximum_lp": 416, "skill": 3,
+  "current_pow": 0, "maximum_pow": 0, "magic_unlocked": false,
+  "active_spell_effects": null,
+  "equipped_weapon": {"name": "Sword", "damage_bonus": 10},
+  "equipped_armor": null,
+  "has_shield": false,
+  "healing_stone_charges": 0,
+  "enemies_defeated": 4,
+  "created_at": "2024-01-01T12:00:00Z",
+  "last_saved": "2024-01-02T12:00:00Z"
+}`
+
+// TestMigrationsCoverEveryVersion verifies each older schema version has
+// a migration to the next one.
+func TestMigrationsCoverEveryVersion(t *testing.T) {
+	for v := 1; v < SchemaVersion; v++ {
+		if findMigration(v) == nil {
+			t.Errorf("no migration from schema version %d", v)
+		}
+	}
+	for _, m := range migrations {
+		if m.from < 1 || m.from >= SchemaVersion {
+			t.Errorf("migration from version %d is outside 1..%d", m.from, SchemaVersion-1)
+		}
+		if m.description == "" {
+			t.Errorf("migration from version %d has no description", m.from)
+		}
+	}
+}
+
+// TestLoadUnversionedSave verifies saves from before schema_version load
+// and are written back at the current version.
+func TestLoadUnversionedSave(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "character_20240102-120000.json")
+	os.WriteFile(path, []byte(unversionedSave), 0644)
+
+	char, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if char.SchemaVersion != SchemaVersion {
+		t.Errorf("SchemaVersion = %d; want %d", char.SchemaVersion, SchemaVersion)
+	}
+	if char.Luck != 80 || char.CurrentLP != 300 || char.EnemiesDefeated != 4 {
+		t.Errorf("loaded LCK %d, LP %d, enemies %d; want 80, 300, 4", char.Luck, char.CurrentLP, char.EnemiesDefeated)
+	}
+	if char.ActiveSpellEffects == nil || char.SectionHistory == nil || char.SectionFirstVisits == nil {
+		t.Error("collections missing from the old save should be empty, not nil")
+	}
+
+	if err := char.Save(dir); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+	data, _ := os.ReadFile(char.SavePath(dir))
+	var saved struct {
+		SchemaVersion int `json:"schema_version"`
+	}
+	json.Unmarshal(data, &saved)
+	if saved.SchemaVersion != SchemaVersion {
+		t.Errorf("saved schema_version = %d; want %d", saved.SchemaVersion, SchemaVersion)
+	}
+}
+
+// TestLoadNewerSave verifies a save from a newer version is refused.
+func TestLoadNewerSave(t *testing.T) {
+	dir := t.TempDir()
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+	char.Save(dir)
+
+	data, _ := os.ReadFile(char.SavePath(dir))
+	var save map[string]interface{}
+	json.Unmarshal(data, &save)
+	save["schema_version"] = SchemaVersion + 1
+	data, _ = json.Marshal(save)
+	os.WriteFile(char.SavePath(dir), data, 0644)
+
+	_, err := Load(char.SavePath(dir))
+	if !errors.Is(err, ErrNewerSchema) {
+		t.Fatalf("Load() error = %v; want ErrNewerSchema", err)
+	}
+}
+
+// TestLoadInvalidSave verifies broken saves are reported instead of loaded.
+func TestLoadInvalidSave(t *testing.T) {
+	tests := []struct {
+		name    string
+		replace [2]string
+	}{
+		{"Invalid schema version", [2]string{`"strength"`, `"schema_version": "two", "strength"`}},
+		{"Negative characteristic", [2]string{`"luck": 80`, `"luck": -5`}},
+		{"Invalid section history", [2]string{`"has_shield"`, `"section_history": [12, 0], "has_shield"`}},
+		{"Slot ID with a path", [2]string{`"has_shield"`, `"slot": "../other", "has_shield"`}},
+		{"Orb equipped but not possessed", [2]string{`"has_shield"`, `"orb_equipped": true, "has_shield"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "save.json")
+			os.WriteFile(path, []byte(strings.Replace(unversionedSave, tt.replace[0], tt.replace[1], 1)), 0644)
+			if _, err := Load(path); err == nil {
+				t.Error("Load() expected error")
+			}
+		})
+	}
+}
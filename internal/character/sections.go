@@ -0,0 +1,288 @@
+package character
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SectionMagic records the magic used in the current section. A failed
+// natural inclination check forbids magic until the next section, and no
+// spell may be cast twice in the same section.
+type SectionMagic struct {
+	InclinationChecked bool     `json:"inclination_checked"` // Whether the natural inclination check was rolled
+	InclinationPassed  bool     `json:"inclination_passed"`  // Whether Fire*Wolf agreed to use magic
+	SpellsCast         []string `json:"spells_cast"`         // Spells already cast, in order
+}
+
+// HasCast returns true if the spell was already cast in this section.
+func (s SectionMagic) HasCast(spellName string) bool {
+	for _, name := range s.SpellsCast {
+		if name == spellName {
+			return true
+		}
+	}
+	return false
+}
+
+// SectionTransition describes a move into a gamebook section. It is passed
+// to every section handler, which may leave notices for the player.
+type SectionTransition struct {
+	From       int      // Section left (0 before the adventure starts)
+	To         int      // Section entered
+	FirstVisit bool     // Whether To had never been visited before
+	Notices    []string // Messages for the player, in handler order
+}
+
+// SectionHandler applies a per-section rule when Fire*Wolf enters a section.
+type SectionHandler func(c *Character, t *SectionTransition)
+
+// sectionHandlers run in order on every section change.
+var sectionHandlers = []SectionHandler{
+	resetSectionMagic,
+	expireSectionSpells,
+	regeneratePower,
+}
+
+// RegisterSectionHandler adds a rule applied on every section change.
+// Handlers run after the built-in rules, in registration order.
+func RegisterSectionHandler(handler SectionHandler) {
+	sectionHandlers = append(sectionHandlers, handler)
+}
+
+// EnterSection moves Fire*Wolf to the given gamebook section.
+// The visit is appended to the section history, the time of the first
+// visit is recorded and the section handlers are applied before the
+// section-start snapshot is taken.
+func (c *Character) EnterSection(section int) (SectionTransition, error) {
+	if section <= 0 {
+		return SectionTransition{}, fmt.Errorf("section must be a positive number: %d", section)
+	}
+
+	if c.SectionFirstVisits == nil {
+		c.SectionFirstVisits = make(map[int]time.Time)
+	}
+
+	transition := SectionTransition{
+		From:       c.CurrentSection,
+		To:         section,
+		FirstVisit: !c.HasVisitedSection(section),
+	}
+	if transition.FirstVisit {
+		c.SectionFirstVisits[section] = time.Now()
+	}
+
+	c.SectionHistory = append(c.SectionHistory, section)
+	c.CurrentSection = section
+
+	for _, handler := range sectionHandlers {
+		handler(c, &transition)
+	}
+	c.captureSectionStart()
+
+	return transition, nil
+}
+
+// resetSectionMagic clears the natural inclination and spells cast.
+func resetSectionMagic(c *Character, t *SectionTransition) {
+	c.SectionMagic = SectionMagic{}
+}
+
+// expireSectionSpells ends spell effects such as ARMOUR, which last
+// for a single section.
+func expireSectionSpells(c *Character, t *SectionTransition) {
+	if len(c.ActiveSpellEffects) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(c.ActiveSpellEffects))
+	for name := range c.ActiveSpellEffects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c.RemoveSpellEffect(name)
+	}
+	t.Notices = append(t.Notices, fmt.Sprintf("%s wore off", strings.Join(names, ", ")))
+}
+
+// regeneratePower grants 1 POW for exploring a new section. POW never
+// rises above the starting maximum this way.
+func regeneratePower(c *Character, t *SectionTransition) {
+	if !t.FirstVisit || !c.MagicUnlocked || c.CurrentPOW >= c.MaximumPOW {
+		return
+	}
+	c.ModifyPOW(1)
+	t.Notices = append(t.Notices, fmt.Sprintf("+1 POW for exploring a new section (%d/%d)", c.CurrentPOW, c.MaximumPOW))
+}
+
+// HasVisitedSection returns true if the section has been entered at least once.
+func (c *Character) HasVisitedSection(section int) bool {
+	_, visited := c.SectionFirstVisits[section]
+	return visited
+}
+
+// VisitedSections returns each visited section once, in order of first visit.
+func (c *Character) VisitedSections() []int {
+	seen := make(map[int]bool)
+	sections := []int{}
+	for _, section := range c.SectionHistory {
+		if !seen[section] {
+			seen[section] = true
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// PreviousSections returns the visited sections other than the current one,
+// most recently visited first. These are the valid RETRACE destinations.
+func (c *Character) PreviousSections() []int {
+	seen := map[int]bool{c.CurrentSection: true}
+	sections := []int{}
+	for i := len(c.SectionHistory) - 1; i >= 0; i-- {
+		section := c.SectionHistory[i]
+		if !seen[section] {
+			seen[section] = true
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// SectionFirstVisit returns when the section was first entered.
+// The boolean is false if the section has never been visited.
+func (c *Character) SectionFirstVisit(section int) (time.Time, bool) {
+	visitedAt, ok := c.SectionFirstVisits[section]
+	return visitedAt, ok
+}
+
+// RecordInclinationCheck stores the natural inclination check for this section.
+func (c *Character) RecordInclinationCheck(passed bool) {
+	c.SectionMagic.InclinationChecked = true
+	c.SectionMagic.InclinationPassed = passed
+}
+
+// RecordSpellCast marks a spell as cast in this section.
+func (c *Character) RecordSpellCast(spellName string) {
+	if !c.SectionMagic.HasCast(spellName) {
+		c.SectionMagic.SpellsCast = append(c.SectionMagic.SpellsCast, spellName)
+	}
+}
+
+// validateProgress checks the section history and the section-start snapshot.
+func validateProgress(c *Character) error {
+	for _, section := range c.SectionHistory {
+		if section <= 0 {
+			return fmt.Errorf("section history holds an invalid section: %d", section)
+		}
+	}
+	for section := range c.SectionFirstVisits {
+		if section <= 0 {
+			return fmt.Errorf("first visits hold an invalid section: %d", section)
+		}
+	}
+
+	if c.SectionStart == nil {
+		return nil
+	}
+	if c.SectionStart.SectionStart != nil {
+		return fmt.Errorf("section-start snapshot holds a snapshot of its own")
+	}
+	if err := c.SectionStart.Validate(); err != nil {
+		return fmt.Errorf("invalid section-start snapshot: %w", err)
+	}
+	return nil
+}
+
+// Clone returns a deep copy of the character.
+func (c *Character) Clone() *Character {
+	clone := *c
+
+	if c.ActiveSpellEffects != nil {
+		clone.ActiveSpellEffects = make(map[string]int, len(c.ActiveSpellEffects))
+		for name, value := range c.ActiveSpellEffects {
+			clone.ActiveSpellEffects[name] = value
+		}
+	}
+	if c.EquippedWeapon != nil {
+		weapon := *c.EquippedWeapon
+		clone.EquippedWeapon = &weapon
+	}
+	if c.EquippedArmor != nil {
+		armor := *c.EquippedArmor
+		clone.EquippedArmor = &armor
+	}
+	if c.SectionHistory != nil {
+		clone.SectionHistory = append([]int{}, c.SectionHistory...)
+	}
+	if c.SectionFirstVisits != nil {
+		clone.SectionFirstVisits = make(map[int]time.Time, len(c.SectionFirstVisits))
+		for section, visitedAt := range c.SectionFirstVisits {
+			clone.SectionFirstVisits[section] = visitedAt
+		}
+	}
+	if c.SectionStart != nil {
+		clone.SectionStart = c.SectionStart.Clone()
+	}
+	if c.SectionMagic.SpellsCast != nil {
+		clone.SectionMagic.SpellsCast = append([]string{}, c.SectionMagic.SpellsCast...)
+	}
+	if c.TestLog != nil {
+		clone.TestLog = append([]CharacteristicTest{}, c.TestLog...)
+	}
+	if c.Encounters != nil {
+		clone.Encounters = append([]Encounter{}, c.Encounters...)
+	}
+	if c.Combat != nil {
+		clone.Combat = append(json.RawMessage{}, c.Combat...)
+	}
+	clone.Statistics = c.Statistics.clone()
+
+	return &clone
+}
+
+// captureSectionStart records the character's state on entering a section.
+// The snapshot leaves out the section history, test log, encounter journal
+// and saved fight, which are never rolled back.
+func (c *Character) captureSectionStart() {
+	snapshot := c.Clone()
+	snapshot.SectionHistory = nil
+	snapshot.SectionFirstVisits = nil
+	snapshot.TestLog = nil
+	snapshot.Encounters = nil
+	snapshot.Combat = nil
+	snapshot.SectionStart = nil
+	c.SectionStart = snapshot
+}
+
+// RestoreSectionStart rolls the character back to the state recorded when
+// the current section was entered (TIMEWARP, RESURRECTION). The section
+// history, test log, encounter journal, lifetime statistics and the
+// snapshot itself are kept so the rollback can happen again.
+// Magic used in the section is kept too: a rollback never allows a spell
+// to be cast twice.
+func (c *Character) RestoreSectionStart() error {
+	if c.SectionStart == nil {
+		return fmt.Errorf("no snapshot recorded for the current section")
+	}
+
+	restored := c.SectionStart.Clone()
+	restored.CurrentSection = c.CurrentSection
+	restored.SectionHistory = c.SectionHistory
+	restored.SectionFirstVisits = c.SectionFirstVisits
+	restored.TestLog = c.TestLog
+	restored.Encounters = c.Encounters
+	restored.Statistics = c.Statistics
+	restored.Combat = c.Combat
+	restored.SectionStart = c.SectionStart
+	restored.SectionMagic = c.SectionMagic
+	restored.Slot = c.Slot
+	restored.CreatedAt = c.CreatedAt
+	restored.LastSaved = c.LastSaved
+
+	*c = *restored
+	return nil
+}
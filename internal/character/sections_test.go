@@ -0,0 +1,240 @@
+package character
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestEnterSection verifies section navigation and history tracking.
+func TestEnterSection(t *testing.T) {
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+
+	if char.CurrentSection != 0 {
+		t.Errorf("CurrentSection = %d; want 0 before the adventure starts", char.CurrentSection)
+	}
+
+	transition, err := char.EnterSection(1)
+	if err != nil {
+		t.Fatalf("EnterSection(1) unexpected error: %v", err)
+	}
+	if !transition.FirstVisit || transition.From != 0 || transition.To != 1 {
+		t.Errorf("EnterSection(1) = %+v; want first visit from 0 to 1", transition)
+	}
+
+	char.EnterSection(42)
+	transition, _ = char.EnterSection(1)
+	if transition.FirstVisit {
+		t.Error("EnterSection(1) should not report a first visit when returning")
+	}
+	if transition.From != 42 {
+		t.Errorf("EnterSection(1) From = %d; want 42", transition.From)
+	}
+
+	if char.CurrentSection != 1 {
+		t.Errorf("CurrentSection = %d; want 1", char.CurrentSection)
+	}
+	if want := []int{1, 42, 1}; !reflect.DeepEqual(char.SectionHistory, want) {
+		t.Errorf("SectionHistory = %v; want %v", char.SectionHistory, want)
+	}
+	if want := []int{1, 42}; !reflect.DeepEqual(char.VisitedSections(), want) {
+		t.Errorf("VisitedSections() = %v; want %v", char.VisitedSections(), want)
+	}
+	if want := []int{42}; !reflect.DeepEqual(char.PreviousSections(), want) {
+		t.Errorf("PreviousSections() = %v; want %v", char.PreviousSections(), want)
+	}
+	if _, ok := char.SectionFirstVisit(42); !ok {
+		t.Error("SectionFirstVisit(42) should be recorded")
+	}
+	if _, ok := char.SectionFirstVisit(7); ok {
+		t.Error("SectionFirstVisit(7) should not be recorded")
+	}
+}
+
+// TestEnterSectionInvalid verifies that non-positive sections are rejected.
+func TestEnterSectionInvalid(t *testing.T) {
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+
+	for _, section := range []int{0, -5} {
+		if _, err := char.EnterSection(section); err == nil {
+			t.Errorf("EnterSection(%d) expected error", section)
+		}
+	}
+	if len(char.SectionHistory) != 0 {
+		t.Errorf("SectionHistory = %v; want empty after invalid sections", char.SectionHistory)
+	}
+}
+
+// TestSectionPersistence verifies section tracking survives save and load.
+func TestSectionPersistence(t *testing.T) {
+	tempDir := t.TempDir()
+
+	original, _ := New(50, 50, 50, 50, 50, 50, 50)
+	original.EnterSection(1)
+	original.EnterSection(17)
+
+	if err := original.Save(tempDir); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+	loaded, err := Load(original.SavePath(tempDir))
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+
+	if loaded.CurrentSection != 17 {
+		t.Errorf("Loaded CurrentSection = %d; want 17", loaded.CurrentSection)
+	}
+	if !reflect.DeepEqual(loaded.SectionHistory, original.SectionHistory) {
+		t.Errorf("Loaded SectionHistory = %v; want %v", loaded.SectionHistory, original.SectionHistory)
+	}
+	if !loaded.HasVisitedSection(1) || !loaded.HasVisitedSection(17) {
+		t.Error("Loaded character should remember visited sections")
+	}
+}
+
+// TestRestoreSectionStart verifies the rollback to the section-entry snapshot.
+func TestRestoreSectionStart(t *testing.T) {
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+
+	if err := char.RestoreSectionStart(); err == nil {
+		t.Error("RestoreSectionStart() expected error before entering a section")
+	}
+
+	char.EnterSection(1)
+	char.AcquireHealingStone()
+	char.EnterSection(12)
+	startLP := char.CurrentLP
+
+	char.ModifyLP(-30)
+	char.UseHealingStone(10)
+	char.AddSpellEffect("ARMOUR", 10)
+
+	if err := char.RestoreSectionStart(); err != nil {
+		t.Fatalf("RestoreSectionStart() unexpected error: %v", err)
+	}
+	if char.CurrentLP != startLP {
+		t.Errorf("CurrentLP = %d; want %d", char.CurrentLP, startLP)
+	}
+	if char.HealingStoneCharges != 50 {
+		t.Errorf("HealingStoneCharges = %d; want 50", char.HealingStoneCharges)
+	}
+	if char.HasSpellEffect("ARMOUR") {
+		t.Error("ARMOUR should not survive a rollback to before it was cast")
+	}
+	if char.CurrentSection != 12 {
+		t.Errorf("CurrentSection = %d; want 12", char.CurrentSection)
+	}
+	if want := []int{1, 12}; !reflect.DeepEqual(char.SectionHistory, want) {
+		t.Errorf("SectionHistory = %v; want %v", char.SectionHistory, want)
+	}
+
+	// The snapshot is kept, so the section can be rewound again
+	char.ModifyLP(-10)
+	if err := char.RestoreSectionStart(); err != nil {
+		t.Fatalf("second RestoreSectionStart() unexpected error: %v", err)
+	}
+	if char.CurrentLP != startLP {
+		t.Errorf("CurrentLP after second rollback = %d; want %d", char.CurrentLP, startLP)
+	}
+}
+
+// TestClone verifies that a cloned character shares no mutable state.
+func TestClone(t *testing.T) {
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+	char.EnterSection(3)
+	char.AddSpellEffect("ARMOUR", 10)
+
+	clone := char.Clone()
+	clone.EnterSection(4)
+	clone.RemoveSpellEffect("ARMOUR")
+	clone.EquippedWeapon.Name = "Changed"
+
+	if char.CurrentSection != 3 || len(char.SectionHistory) != 1 {
+		t.Errorf("original section state changed: section %d, history %v", char.CurrentSection, char.SectionHistory)
+	}
+	if !char.HasSpellEffect("ARMOUR") {
+		t.Error("original lost its ARMOUR effect")
+	}
+	if char.EquippedWeapon.Name == "Changed" {
+		t.Error("original weapon changed through the clone")
+	}
+}
+
+// TestSectionMagic verifies per-section magic state and its reset.
+func TestSectionMagic(t *testing.T) {
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+	char.EnterSection(5)
+
+	char.RecordInclinationCheck(true)
+	char.RecordSpellCast("ARMOUR")
+	char.RecordSpellCast("ARMOUR")
+
+	if !char.SectionMagic.InclinationChecked || !char.SectionMagic.InclinationPassed {
+		t.Error("inclination check should be recorded as passed")
+	}
+	if want := []string{"ARMOUR"}; !reflect.DeepEqual(char.SectionMagic.SpellsCast, want) {
+		t.Errorf("SpellsCast = %v; want %v", char.SectionMagic.SpellsCast, want)
+	}
+
+	// A rollback does not forget what was cast
+	char.RestoreSectionStart()
+	if !char.SectionMagic.HasCast("ARMOUR") {
+		t.Error("RestoreSectionStart() should keep spells cast in the section")
+	}
+
+	char.EnterSection(6)
+	if char.SectionMagic.InclinationChecked || char.SectionMagic.HasCast("ARMOUR") {
+		t.Errorf("SectionMagic = %+v; want reset on entering a new section", char.SectionMagic)
+	}
+}
+
+// TestSectionPowerRegeneration verifies +1 POW for new sections only, capped at the maximum.
+func TestSectionPowerRegeneration(t *testing.T) {
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+	char.UnlockMagic(20)
+	char.SetPOW(18)
+
+	transition, _ := char.EnterSection(1)
+	if char.CurrentPOW != 19 {
+		t.Errorf("CurrentPOW = %d; want 19 after a new section", char.CurrentPOW)
+	}
+	if len(transition.Notices) != 1 {
+		t.Errorf("Notices = %v; want one POW notice", transition.Notices)
+	}
+
+	char.EnterSection(2)
+	char.EnterSection(3)
+	if char.CurrentPOW != 20 {
+		t.Errorf("CurrentPOW = %d; want 20 (capped at maximum)", char.CurrentPOW)
+	}
+
+	char.SetPOW(10)
+	char.EnterSection(1)
+	if char.CurrentPOW != 10 {
+		t.Errorf("CurrentPOW = %d; want 10 when returning to a visited section", char.CurrentPOW)
+	}
+}
+
+// TestSectionPowerRegenerationLocked verifies no POW is granted before magic is unlocked.
+func TestSectionPowerRegenerationLocked(t *testing.T) {
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+
+	char.EnterSection(1)
+	if char.CurrentPOW != 0 {
+		t.Errorf("CurrentPOW = %d; want 0 while magic is locked", char.CurrentPOW)
+	}
+}
+
+// TestSectionSpellEffectsExpire verifies that section-long spell effects end on a section change.
+func TestSectionSpellEffectsExpire(t *testing.T) {
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+	char.EnterSection(1)
+	char.AddSpellEffect("ARMOUR", 10)
+
+	transition, _ := char.EnterSection(2)
+	if char.HasSpellEffect("ARMOUR") {
+		t.Error("ARMOUR should wear off on entering a new section")
+	}
+	if want := []string{"ARMOUR wore off"}; !reflect.DeepEqual(transition.Notices, want) {
+		t.Errorf("Notices = %v; want %v", transition.Notices, want)
+	}
+}
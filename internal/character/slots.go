@@ -0,0 +1,335 @@
+package character
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/benoit/saga-demonspawn/internal/safefile"
+)
+
+// ManifestFileName is the name of the save slot manifest in the save directory.
+const ManifestFileName = "saves.json"
+
+// legacySavePattern matches the timestamped save files written before
+// save slots existed.
+const legacySavePattern = "character_*.json"
+
+// SlotInfo describes a save slot, as listed on the load screen.
+type SlotInfo struct {
+	ID         string    `json:"id"`          // Stable identifier, part of the file name
+	Name       string    `json:"name"`        // Name chosen by the player
+	Section    int       `json:"section"`     // Section the character was on
+	CurrentLP  int       `json:"current_lp"`  // LP when last saved
+	MaximumLP  int       `json:"maximum_lp"`  // Maximum LP when last saved
+	LastPlayed time.Time `json:"last_played"` // When the slot was last saved
+
+	// Path is the save file. It is not stored in the manifest.
+	Path string `json:"-"`
+	// Legacy is true for a timestamped save file from before save slots;
+	// saving it again moves it to a slot.
+	Legacy bool `json:"-"`
+}
+
+// Manifest lists the save slots of a save directory.
+type Manifest struct {
+	Slots []SlotInfo `json:"slots"`
+}
+
+// ManifestPath returns the manifest location in the save directory.
+func ManifestPath(directory string) string {
+	return filepath.Join(directory, ManifestFileName)
+}
+
+// SlotPath returns the save file of a slot in the save directory.
+func SlotPath(directory, id string) string {
+	return filepath.Join(directory, "slot_"+id+".json")
+}
+
+// LoadManifest reads the manifest of a save directory. A missing file gives
+// an empty manifest.
+func LoadManifest(directory string) (*Manifest, error) {
+	data, err := os.ReadFile(ManifestPath(directory))
+	if os.IsNotExist(err) {
+		return &Manifest{Slots: []SlotInfo{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read save manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse save manifest: %w", err)
+	}
+	if m.Slots == nil {
+		m.Slots = []SlotInfo{}
+	}
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid save manifest: %w", err)
+	}
+	return &m, nil
+}
+
+// Save writes the manifest to the save directory.
+func (m *Manifest) Save(directory string) error {
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return fmt.Errorf("failed to create save directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal save manifest: %w", err)
+	}
+
+	if err := safefile.WriteFile(ManifestPath(directory), data, 0644); err != nil {
+		return fmt.Errorf("failed to write save manifest: %w", err)
+	}
+	return nil
+}
+
+// Validate checks that every slot has a unique ID and a name.
+func (m *Manifest) Validate() error {
+	seen := make(map[string]bool, len(m.Slots))
+	for _, slot := range m.Slots {
+		if slot.ID == "" {
+			return fmt.Errorf("slot %q has no ID", slot.Name)
+		}
+		if !validSlotID(slot.ID) {
+			return fmt.Errorf("slot %q has an invalid ID: %q", slot.Name, slot.ID)
+		}
+		if slot.Name == "" {
+			return fmt.Errorf("slot %q has no name", slot.ID)
+		}
+		if seen[slot.ID] {
+			return fmt.Errorf("slot %q is listed twice", slot.ID)
+		}
+		seen[slot.ID] = true
+	}
+	return nil
+}
+
+// Find returns the slot with the given ID, or nil.
+func (m *Manifest) Find(id string) *SlotInfo {
+	for i := range m.Slots {
+		if m.Slots[i].ID == id {
+			return &m.Slots[i]
+		}
+	}
+	return nil
+}
+
+// findName returns the slot with the given name, ignoring case, or nil.
+func (m *Manifest) findName(name string) *SlotInfo {
+	for i := range m.Slots {
+		if strings.EqualFold(m.Slots[i].Name, name) {
+			return &m.Slots[i]
+		}
+	}
+	return nil
+}
+
+// freeName returns name, numbered if a slot already has it, e.g. "Fire*Wolf (2)".
+func (m *Manifest) freeName(name string) string {
+	free := name
+	for n := 2; m.findName(free) != nil; n++ {
+		free = fmt.Sprintf("%s (%d)", name, n)
+	}
+	return free
+}
+
+// newSlotID derives a free slot ID from a slot name, e.g. "fire-wolf-2".
+func (m *Manifest) newSlotID(directory, name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "-"):
+			b.WriteByte('-')
+		}
+	}
+	base := strings.Trim(b.String(), "-")
+	if len(base) > 40 {
+		base = strings.Trim(base[:40], "-")
+	}
+	if base == "" {
+		base = "slot"
+	}
+
+	id := base
+	for n := 2; ; n++ {
+		if _, err := os.Stat(SlotPath(directory, id)); m.Find(id) == nil && os.IsNotExist(err) {
+			return id
+		}
+		id = fmt.Sprintf("%s-%d", base, n)
+	}
+}
+
+// validSlotID reports whether id could have been made by newSlotID:
+// lowercase letters, digits and dashes, so it is safe in a file name.
+func validSlotID(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, r := range id {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// validSlotName checks a slot name typed by the player.
+func validSlotName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("slot name cannot be empty")
+	}
+	if len([]rune(name)) > 40 {
+		return "", fmt.Errorf("slot name is too long (40 characters at most)")
+	}
+	return name, nil
+}
+
+// DefaultSlotName names the slot of a character saved for the first time
+// after its creation date, so each playthrough gets its own slot.
+func (c *Character) DefaultSlotName() string {
+	return "Fire*Wolf " + c.CreatedAt.Format("2006-01-02 15:04")
+}
+
+// SaveAs saves the character to a new slot with the given name, which
+// becomes the character's slot from then on. The previous slot, if any,
+// is left as it was.
+func (c *Character) SaveAs(directory, name string) error {
+	name, err := validSlotName(name)
+	if err != nil {
+		return err
+	}
+	return withManifestLock(directory, func() error {
+		manifest, err := LoadManifest(directory)
+		if err != nil {
+			return err
+		}
+		if manifest.findName(name) != nil {
+			return fmt.Errorf("a slot named %q already exists", name)
+		}
+
+		c.Slot = manifest.newSlotID(directory, name)
+		return c.saveSlot(directory, manifest, name)
+	})
+}
+
+// RenameSlot gives a save slot a new name. The slot keeps its file.
+func RenameSlot(directory, id, name string) error {
+	name, err := validSlotName(name)
+	if err != nil {
+		return err
+	}
+	return withManifestLock(directory, func() error {
+		manifest, err := LoadManifest(directory)
+		if err != nil {
+			return err
+		}
+		slot := manifest.Find(id)
+		if slot == nil {
+			return fmt.Errorf("no save slot %q", id)
+		}
+		if other := manifest.findName(name); other != nil && other.ID != id {
+			return fmt.Errorf("a slot named %q already exists", name)
+		}
+		slot.Name = name
+		return manifest.Save(directory)
+	})
+}
+
+// SlotName returns the name of a slot, or "" if it is not in the manifest.
+func SlotName(directory, id string) string {
+	manifest, err := LoadManifest(directory)
+	if err != nil {
+		return ""
+	}
+	if slot := manifest.Find(id); slot != nil {
+		return slot.Name
+	}
+	return ""
+}
+
+// saveSlot writes the character to its slot file and records the slot in
+// the manifest, naming it name if it is new. The caller holds the manifest
+// lock; the slot must not be open in another instance.
+func (c *Character) saveSlot(directory string, manifest *Manifest, name string) error {
+	if err := checkSlotLock(directory, c.Slot); err != nil {
+		return err
+	}
+	c.SchemaVersion = SchemaVersion
+	c.LastSaved = time.Now()
+
+	// Ensure directory exists
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return fmt.Errorf("failed to create save directory: %w", err)
+	}
+
+	// Marshal character to JSON with indentation for readability
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal character: %w", err)
+	}
+
+	// Write to file
+	if err := safefile.WriteFile(c.SavePath(directory), data, 0644); err != nil {
+		return fmt.Errorf("failed to write save file: %w", err)
+	}
+
+	slot := manifest.Find(c.Slot)
+	if slot == nil {
+		manifest.Slots = append(manifest.Slots, SlotInfo{ID: c.Slot, Name: name})
+		slot = &manifest.Slots[len(manifest.Slots)-1]
+	}
+	slot.Section = c.CurrentSection
+	slot.CurrentLP = c.CurrentLP
+	slot.MaximumLP = c.MaximumLP
+	slot.LastPlayed = c.LastSaved
+	return manifest.Save(directory)
+}
+
+// ListSlots returns the save slots of a directory, most recently played
+// first, followed by any timestamped save files from before save slots.
+// Slots in the manifest whose file is missing are left out.
+func ListSlots(directory string) ([]SlotInfo, error) {
+	manifest, err := LoadManifest(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := []SlotInfo{}
+	for _, slot := range manifest.Slots {
+		slot.Path = SlotPath(directory, slot.ID)
+		if _, err := os.Stat(slot.Path); err == nil {
+			slots = append(slots, slot)
+		}
+	}
+	sort.SliceStable(slots, func(i, j int) bool {
+		return slots[i].LastPlayed.After(slots[j].LastPlayed)
+	})
+
+	legacy, err := filepath.Glob(filepath.Join(directory, legacySavePattern))
+	if err != nil {
+		return nil, err
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(legacy)))
+	for _, path := range legacy {
+		slot := SlotInfo{Name: filepath.Base(path), Path: path, Legacy: true}
+		if c, err := Load(path); err == nil {
+			slot.Section = c.CurrentSection
+			slot.CurrentLP = c.CurrentLP
+			slot.MaximumLP = c.MaximumLP
+			slot.LastPlayed = c.LastSaved
+		}
+		slots = append(slots, slot)
+	}
+	return slots, nil
+}
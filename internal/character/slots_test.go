@@ -0,0 +1,158 @@
+package character
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestSaveReusesSlot verifies repeated saves write one stable file per slot.
+func TestSaveReusesSlot(t *testing.T) {
+	dir := t.TempDir()
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+
+	if err := char.Save(dir); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+	first := char.SavePath(dir)
+	char.EnterSection(42)
+	char.ModifyLP(-30)
+	if err := char.Save(dir); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+	if char.SavePath(dir) != first {
+		t.Errorf("SavePath() = %s after a second save; want %s", char.SavePath(dir), first)
+	}
+
+	files, _ := os.ReadDir(dir)
+	if len(files) != 2 {
+		t.Errorf("save directory has %d files; want the slot and the manifest", len(files))
+	}
+
+	slots, err := ListSlots(dir)
+	if err != nil {
+		t.Fatalf("ListSlots() unexpected error: %v", err)
+	}
+	if len(slots) != 1 {
+		t.Fatalf("ListSlots() = %d slots; want 1", len(slots))
+	}
+	slot := slots[0]
+	if slot.Name != char.DefaultSlotName() || slot.Section != 42 || slot.CurrentLP != 320 || slot.MaximumLP != 350 {
+		t.Errorf("slot = %+v; want the default name, section 42 and LP 320/350", slot)
+	}
+	if slot.Path != first || !slot.LastPlayed.Equal(char.LastSaved) {
+		t.Errorf("slot path %s, last played %v; want %s, %v", slot.Path, slot.LastPlayed, first, char.LastSaved)
+	}
+}
+
+// TestSaveAs verifies Save as creates a new slot and leaves the old one.
+func TestSaveAs(t *testing.T) {
+	dir := t.TempDir()
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+	char.Save(dir)
+	oldSlot := char.Slot
+
+	if err := char.SaveAs(dir, "Before the Tower"); err != nil {
+		t.Fatalf("SaveAs() unexpected error: %v", err)
+	}
+	if char.Slot == oldSlot || char.Slot != "before-the-tower" {
+		t.Errorf("Slot = %q; want a new slot before-the-tower", char.Slot)
+	}
+	if SlotName(dir, char.Slot) != "Before the Tower" {
+		t.Errorf("SlotName() = %q; want Before the Tower", SlotName(dir, char.Slot))
+	}
+	if slots, _ := ListSlots(dir); len(slots) != 2 {
+		t.Errorf("ListSlots() = %d slots; want 2", len(slots))
+	}
+
+	if err := char.SaveAs(dir, "before the tower"); err == nil {
+		t.Error("SaveAs() expected error for a name already in use")
+	}
+	if err := char.SaveAs(dir, "  "); err == nil {
+		t.Error("SaveAs() expected error for an empty name")
+	}
+
+	// Reusing an ID from a renamed slot is avoided
+	RenameSlot(dir, "before-the-tower", "Tower")
+	other, _ := New(40, 40, 40, 40, 40, 40, 40)
+	if err := other.SaveAs(dir, "Before the Tower"); err != nil {
+		t.Fatalf("SaveAs() unexpected error: %v", err)
+	}
+	if other.Slot != "before-the-tower-2" {
+		t.Errorf("Slot = %q; want before-the-tower-2", other.Slot)
+	}
+}
+
+// TestRenameSlot verifies slots are renamed in the manifest only.
+func TestRenameSlot(t *testing.T) {
+	dir := t.TempDir()
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+	char.SaveAs(dir, "First")
+	other, _ := New(50, 50, 50, 50, 50, 50, 50)
+	other.SaveAs(dir, "Second")
+
+	if err := RenameSlot(dir, char.Slot, "Renamed"); err != nil {
+		t.Fatalf("RenameSlot() unexpected error: %v", err)
+	}
+	if SlotName(dir, char.Slot) != "Renamed" {
+		t.Errorf("SlotName() = %q; want Renamed", SlotName(dir, char.Slot))
+	}
+	if _, err := os.Stat(SlotPath(dir, "first")); err != nil {
+		t.Errorf("slot file should keep its name: %v", err)
+	}
+
+	if err := RenameSlot(dir, char.Slot, "second"); err == nil {
+		t.Error("RenameSlot() expected error for a name already in use")
+	}
+	if err := RenameSlot(dir, "missing", "Name"); err == nil {
+		t.Error("RenameSlot() expected error for an unknown slot")
+	}
+}
+
+// TestListSlotsLegacy verifies timestamped saves are listed after the slots.
+func TestListSlotsLegacy(t *testing.T) {
+	dir := t.TempDir()
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+	char.SaveAs(dir, "Current")
+
+	// A save file written before slots existed
+	old, _ := New(60, 60, 60, 60, 60, 60, 60)
+	old.EnterSection(7)
+	data, _ := json.MarshalIndent(old, "", "  ")
+	os.WriteFile(filepath.Join(dir, "character_20240101-120000.json"), data, 0644)
+
+	slots, err := ListSlots(dir)
+	if err != nil {
+		t.Fatalf("ListSlots() unexpected error: %v", err)
+	}
+	if len(slots) != 2 || slots[0].Name != "Current" || !slots[1].Legacy {
+		t.Fatalf("ListSlots() = %+v; want the slot, then the legacy file", slots)
+	}
+	if slots[1].Section != 7 || slots[1].MaximumLP != 420 {
+		t.Errorf("legacy slot = %+v; want section 7 and LP read from the file", slots[1])
+	}
+
+	// A legacy character saved again moves to a slot of its own
+	loaded, _ := Load(slots[1].Path)
+	if err := loaded.Save(dir); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+	if slots, _ := ListSlots(dir); len(slots) != 3 {
+		t.Errorf("ListSlots() = %d entries; want 3", len(slots))
+	}
+}
+
+// TestLoadManifestInvalid verifies a corrupt manifest is reported.
+func TestLoadManifestInvalid(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(ManifestPath(dir), []byte("{not json"), 0644)
+	if _, err := LoadManifest(dir); err == nil {
+		t.Error("LoadManifest() expected error for invalid JSON")
+	}
+
+	os.WriteFile(ManifestPath(dir), []byte(`{"slots": [{"id": "a", "name": "A"}, {"id": "a", "name": "B"}]}`), 0644)
+	if _, err := LoadManifest(dir); err == nil {
+		t.Error("LoadManifest() expected error for a duplicate slot ID")
+	}
+}
@@ -0,0 +1,187 @@
+package character
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Sources of healing counted in Statistics.LPHealed.
+const (
+	HealSourceHealingStone = "Healing Stone"
+	HealSourceDoombringer  = "Doombringer"
+)
+
+// Statistics is a character's lifetime record, kept across fights and
+// sessions. Fights are added by the combat package when they end; spells
+// are added when cast, in a fight or not.
+type Statistics struct {
+	// Fights, by how they ended
+	FightsWon     int `json:"fights_won"`
+	FightsLost    int `json:"fights_lost"`
+	FightsFled    int `json:"fights_fled"`
+	FightsEscaped int `json:"fights_escaped"` // Left by a spell such as INVISIBILITY
+
+	// Attacks made by Fire*Wolf
+	AttacksMade  int     `json:"attacks_made"`
+	AttacksHit   int     `json:"attacks_hit"`
+	ExpectedHits float64 `json:"expected_hits"` // Sum of each attack's chance to hit
+
+	// Life points
+	DamageDealt    int            `json:"damage_dealt"`
+	DamageTaken    int            `json:"damage_taken"`
+	BloodPricePaid int            `json:"blood_price_paid"` // LP paid to Doombringer before attacking
+	LPHealed       map[string]int `json:"lp_healed"`        // By source, e.g. HealSourceHealingStone
+
+	// Death saves
+	DeathSavesUsed   int `json:"death_saves_used"`
+	DeathSavesPassed int `json:"death_saves_passed"`
+
+	// Spells, by name
+	Spells map[string]SpellStatistics `json:"spells"`
+}
+
+// SpellStatistics is the lifetime record of one spell.
+type SpellStatistics struct {
+	Cast      int `json:"cast"`      // Times cast, fizzled or not
+	Succeeded int `json:"succeeded"` // Times it passed the Fundamental Failure Rate
+	POWSpent  int `json:"pow_spent"`
+}
+
+// RecordHeal adds LP healed by the given source.
+func (s *Statistics) RecordHeal(source string, lp int) {
+	if lp <= 0 {
+		return
+	}
+	if s.LPHealed == nil {
+		s.LPHealed = map[string]int{}
+	}
+	s.LPHealed[source] += lp
+}
+
+// RecordSpell adds a cast of the given spell.
+func (s *Statistics) RecordSpell(name string, powSpent int, success bool) {
+	if s.Spells == nil {
+		s.Spells = map[string]SpellStatistics{}
+	}
+	spell := s.Spells[name]
+	spell.Cast++
+	if success {
+		spell.Succeeded++
+	}
+	spell.POWSpent += powSpent
+	s.Spells[name] = spell
+}
+
+// Fights returns the number of fights finished.
+func (s Statistics) Fights() int {
+	return s.FightsWon + s.FightsLost + s.FightsFled + s.FightsEscaped
+}
+
+// HitRate returns the share of attacks that hit, from 0 to 1.
+func (s Statistics) HitRate() float64 {
+	if s.AttacksMade == 0 {
+		return 0
+	}
+	return float64(s.AttacksHit) / float64(s.AttacksMade)
+}
+
+// ExpectedHitRate returns the share of attacks the dice should have let
+// hit, from 0 to 1.
+func (s Statistics) ExpectedHitRate() float64 {
+	if s.AttacksMade == 0 {
+		return 0
+	}
+	return s.ExpectedHits / float64(s.AttacksMade)
+}
+
+// TotalHealed returns the LP healed from every source.
+func (s Statistics) TotalHealed() int {
+	total := 0
+	for _, lp := range s.LPHealed {
+		total += lp
+	}
+	return total
+}
+
+// SpellTotals returns the casts, successes and POW spent over every spell.
+func (s Statistics) SpellTotals() SpellStatistics {
+	total := SpellStatistics{}
+	for _, spell := range s.Spells {
+		total.Cast += spell.Cast
+		total.Succeeded += spell.Succeeded
+		total.POWSpent += spell.POWSpent
+	}
+	return total
+}
+
+// SpellNames returns the spells cast at least once, in name order.
+func (s Statistics) SpellNames() []string {
+	names := make([]string, 0, len(s.Spells))
+	for name := range s.Spells {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// clone returns a copy of the statistics sharing no maps.
+func (s Statistics) clone() Statistics {
+	if s.LPHealed != nil {
+		healed := make(map[string]int, len(s.LPHealed))
+		for source, lp := range s.LPHealed {
+			healed[source] = lp
+		}
+		s.LPHealed = healed
+	}
+	if s.Spells != nil {
+		spells := make(map[string]SpellStatistics, len(s.Spells))
+		for name, spell := range s.Spells {
+			spells[name] = spell
+		}
+		s.Spells = spells
+	}
+	return s
+}
+
+// validate checks that no count is negative and no more attacks hit, or
+// spells succeeded, than were made.
+func (s Statistics) validate() error {
+	counts := []struct {
+		name  string
+		value int
+	}{
+		{"fights won", s.FightsWon},
+		{"fights lost", s.FightsLost},
+		{"fights fled", s.FightsFled},
+		{"fights escaped", s.FightsEscaped},
+		{"attacks made", s.AttacksMade},
+		{"attacks hit", s.AttacksHit},
+		{"damage dealt", s.DamageDealt},
+		{"damage taken", s.DamageTaken},
+		{"blood price paid", s.BloodPricePaid},
+		{"death saves used", s.DeathSavesUsed},
+		{"death saves passed", s.DeathSavesPassed},
+	}
+	for _, count := range counts {
+		if count.value < 0 {
+			return fmt.Errorf("statistics: %s cannot be negative: %d", count.name, count.value)
+		}
+	}
+	if s.AttacksHit > s.AttacksMade || s.ExpectedHits < 0 || s.ExpectedHits > float64(s.AttacksMade) {
+		return fmt.Errorf("statistics: %d hits (%.1f expected) out of %d attacks", s.AttacksHit, s.ExpectedHits, s.AttacksMade)
+	}
+	if s.DeathSavesPassed > s.DeathSavesUsed {
+		return fmt.Errorf("statistics: %d death saves passed out of %d", s.DeathSavesPassed, s.DeathSavesUsed)
+	}
+	for source, lp := range s.LPHealed {
+		if lp < 0 {
+			return fmt.Errorf("statistics: LP healed by %s cannot be negative: %d", source, lp)
+		}
+	}
+	for name, spell := range s.Spells {
+		if spell.Cast < 0 || spell.POWSpent < 0 || spell.Succeeded < 0 || spell.Succeeded > spell.Cast {
+			return fmt.Errorf("statistics: invalid record for %s: %+v", name, spell)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package character
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestStatistics verifies the recorded heals and spells and the rates
+// derived from them.
+func TestStatistics(t *testing.T) {
+	s := Statistics{AttacksMade: 4, AttacksHit: 3, ExpectedHits: 2}
+	s.RecordHeal(HealSourceHealingStone, 40)
+	s.RecordHeal(HealSourceDoombringer, 25)
+	s.RecordHeal(HealSourceDoombringer, 0)
+	s.RecordSpell("FIREBALL", 15, true)
+	s.RecordSpell("FIREBALL", 15, false)
+	s.RecordSpell("ARMOUR", 25, true)
+
+	if s.HitRate() != 0.75 || s.ExpectedHitRate() != 0.5 {
+		t.Errorf("HitRate() = %v, ExpectedHitRate() = %v; want 0.75, 0.5", s.HitRate(), s.ExpectedHitRate())
+	}
+	if s.TotalHealed() != 65 {
+		t.Errorf("TotalHealed() = %d; want 65", s.TotalHealed())
+	}
+	if want := (SpellStatistics{Cast: 2, Succeeded: 1, POWSpent: 30}); s.Spells["FIREBALL"] != want {
+		t.Errorf("FIREBALL = %+v; want %+v", s.Spells["FIREBALL"], want)
+	}
+	if want := (SpellStatistics{Cast: 3, Succeeded: 2, POWSpent: 55}); s.SpellTotals() != want {
+		t.Errorf("SpellTotals() = %+v; want %+v", s.SpellTotals(), want)
+	}
+	if want := []string{"ARMOUR", "FIREBALL"}; !reflect.DeepEqual(s.SpellNames(), want) {
+		t.Errorf("SpellNames() = %v; want %v", s.SpellNames(), want)
+	}
+	if (Statistics{}).HitRate() != 0 {
+		t.Error("HitRate() without attacks should be 0")
+	}
+}
+
+// TestStatisticsPersistence verifies the statistics are saved, survive a
+// section rollback and are not shared with a clone.
+func TestStatisticsPersistence(t *testing.T) {
+	dir := t.TempDir()
+	char, _ := New(50, 50, 50, 50, 50, 50, 50)
+	char.EnterSection(1)
+	char.Statistics.FightsWon = 2
+	char.Statistics.RecordHeal(HealSourceHealingStone, 30)
+	char.Statistics.RecordSpell("FIREBALL", 15, true)
+
+	if err := char.Save(dir); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+	loaded, err := Load(char.SavePath(dir))
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Statistics, char.Statistics) {
+		t.Errorf("loaded statistics = %+v; want %+v", loaded.Statistics, char.Statistics)
+	}
+
+	clone := char.Clone()
+	clone.Statistics.RecordHeal(HealSourceHealingStone, 10)
+	clone.Statistics.RecordSpell("FIREBALL", 15, false)
+	if char.Statistics.LPHealed[HealSourceHealingStone] != 30 || char.Statistics.Spells["FIREBALL"].Cast != 1 {
+		t.Errorf("original statistics changed through the clone: %+v", char.Statistics)
+	}
+
+	// What happened since the section began is not undone by TIMEWARP
+	char.Statistics.FightsLost++
+	if err := char.RestoreSectionStart(); err != nil {
+		t.Fatalf("RestoreSectionStart() unexpected error: %v", err)
+	}
+	if char.Statistics.FightsLost != 1 || char.Statistics.FightsWon != 2 {
+		t.Errorf("statistics after rollback = %+v; want them kept", char.Statistics)
+	}
+}
+
+// TestStatisticsValidate verifies inconsistent statistics are rejected.
+func TestStatisticsValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats Statistics
+	}{
+		{"Negative count", Statistics{DamageTaken: -1}},
+		{"More hits than attacks", Statistics{AttacksMade: 1, AttacksHit: 2}},
+		{"More expected hits than attacks", Statistics{AttacksMade: 1, ExpectedHits: 1.5}},
+		{"More death saves passed than used", Statistics{DeathSavesPassed: 1}},
+		{"Negative heal", Statistics{LPHealed: map[string]int{HealSourceDoombringer: -5}}},
+		{"More successes than casts", Statistics{Spells: map[string]SpellStatistics{"FIREBALL": {Cast: 1, Succeeded: 2}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			char, _ := New(50, 50, 50, 50, 50, 50, 50)
+			char.Statistics = tt.stats
+			if err := char.Validate(); err == nil {
+				t.Error("Validate() expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package combat
+
+import (
+	"fmt"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/internal/dice"
+	"github.com/benoit/saga-demonspawn/internal/items"
+	"github.com/benoit/saga-demonspawn/internal/rules"
+)
+
+const (
+	// DoombringerBloodPrice is the LP Doombringer takes before every attack.
+	DoombringerBloodPrice = 10
+	// OrbThrowRequirement is the 2d6 roll needed to hit with a thrown Orb.
+	OrbThrowRequirement = 4
+	// OrbThrowDamage is dealt to a Demonspawn when a thrown Orb misses.
+	OrbThrowDamage = 200
+)
+
+// PlayerAttackResult is the outcome of a player attack, with every item
+// effect applied.
+type PlayerAttackResult struct {
+	AttackResult
+	Target      *Enemy // Enemy attacked
+	Doombringer bool   // Whether Doombringer was wielded
+	BloodPrice  int    // LP paid to Doombringer before the attack
+	Drained     bool   // Doombringer took the player's last LP; no attack was made
+	OrbBonus    int    // Extra damage from The Orb against a Demonspawn
+	SoulThirst  int    // LP Doombringer healed from the damage dealt
+}
+
+// EnemyAttack is the outcome of one enemy's attack on the player.
+type EnemyAttack struct {
+	AttackResult
+	Enemy *Enemy // Enemy that attacked
+}
+
+// RestResult is the outcome of the player resting: every living enemy
+// attacks while the player recovers.
+type RestResult struct {
+	Attacks []EnemyAttack // Free attacks, in setup order
+}
+
+// EnemyTurnResult is the outcome of an enemy's turn. An enemy out of
+// endurance rests and the player gets a free attack on it.
+type EnemyTurnResult struct {
+	Enemy      *Enemy              // Enemy whose turn it was
+	Rested     bool                // Whether the enemy rested instead of attacking
+	Attack     *AttackResult       // The enemy's attack (nil when it rested)
+	FreeAttack *PlayerAttackResult // The player's attack on the resting enemy
+}
+
+// HealingStoneResult is the outcome of invoking the Healing Stone.
+type HealingStoneResult struct {
+	Roll        int // Total of the Healing Stone dice
+	Healed      int // LP actually restored
+	ChargesLeft int // Charges remaining in the stone
+}
+
+// OrbThrowResult is the outcome of throwing The Orb.
+type OrbThrowResult struct {
+	Target      *Enemy // Enemy the Orb was thrown at
+	Roll        int    // The 2d6 roll
+	Hit         bool   // Whether the roll met OrbThrowRequirement
+	Demonspawn  bool   // Whether the target was Demonspawn (others are unharmed)
+	Annihilated bool   // A hit destroys a Demonspawn outright
+	Damage      int    // LP lost by the target
+}
+
+// PlayerMustRest returns true when the player's endurance is depleted.
+func (cs *CombatState) PlayerMustRest() bool {
+	return CheckEndurance(cs.RoundsSinceLastRest, cs.EnduranceLimit)
+}
+
+// Attack performs the player's attack on the current target.
+func Attack(cs *CombatState, player *character.Character, roller dice.Roller) PlayerAttackResult {
+	return attackWithItems(cs.Enemy, player, cs.Ruleset(), roller)
+}
+
+// attackWithItems attacks an enemy, applying Doombringer's blood price
+// and soul thirst and The Orb's doubled damage against Demonspawn.
+func attackWithItems(target *Enemy, player *character.Character, ruleset rules.Ruleset, roller dice.Roller) PlayerAttackResult {
+	result := PlayerAttackResult{Target: target}
+
+	// Doombringer takes its blood price before the attack
+	result.Doombringer = player.EquippedWeapon != nil && player.EquippedWeapon.Name == items.DoombringerName
+	if result.Doombringer {
+		player.ModifyLP(-DoombringerBloodPrice)
+		result.BloodPrice = DoombringerBloodPrice
+		if player.CurrentLP <= 0 {
+			result.Drained = true
+			return result
+		}
+	}
+
+	lpBeforeHit := target.CurrentLP
+	result.AttackResult = ExecuteAttackOn(target, player, ruleset, roller)
+	if !result.Hit {
+		return result
+	}
+
+	// The Orb held in the left hand doubles damage against Demonspawn
+	if player.OrbEquipped && target.IsDemonspawn {
+		result.OrbBonus = result.FinalDamage
+		target.CurrentLP -= result.OrbBonus
+		if target.CurrentLP < 0 {
+			target.CurrentLP = 0
+		}
+		result.FinalDamage += result.OrbBonus
+		result.TargetLP = target.CurrentLP
+		result.Modifiers = append(result.Modifiers, Term{Name: items.TheOrbName, Value: result.OrbBonus})
+	}
+
+	// Doombringer heals the damage dealt, no more than the enemy had left
+	if result.Doombringer && result.FinalDamage > 0 {
+		heal := result.FinalDamage
+		if heal > lpBeforeHit {
+			heal = lpBeforeHit
+		}
+		if player.CurrentLP+heal > player.MaximumLP {
+			heal = player.MaximumLP - player.CurrentLP
+		}
+		if heal > 0 {
+			player.ModifyLP(heal)
+			result.SoulThirst = heal
+		}
+	}
+
+	return result
+}
+
+// Rest lets the player recover endurance. Every living enemy gets a free
+// attack first; the attacks stop if the player falls.
+func Rest(cs *CombatState, player *character.Character, roller dice.Roller) RestResult {
+	result := RestResult{}
+	for _, opponent := range cs.LivingOpponents() {
+		attack := ExecuteOpponentAttack(opponent.Enemy, player, cs.Ruleset(), roller)
+		result.Attacks = append(result.Attacks, EnemyAttack{AttackResult: attack, Enemy: opponent.Enemy})
+		if player.CurrentLP <= 0 {
+			break
+		}
+	}
+	ProcessRest(cs)
+	return result
+}
+
+// EnemyTurn plays the turn of the acting enemy: it attacks, or rests if
+// its endurance is depleted while the player attacks it for free.
+func EnemyTurn(cs *CombatState, player *character.Character, roller dice.Roller) EnemyTurnResult {
+	acting := cs.ActingOpponent()
+	result := EnemyTurnResult{Enemy: acting.Enemy}
+
+	if CheckEndurance(acting.RoundsSinceLastRest, acting.EnduranceLimit) {
+		result.Rested = true
+		freeAttack := attackWithItems(acting.Enemy, player, cs.Ruleset(), roller)
+		result.FreeAttack = &freeAttack
+		ProcessEnemyRest(cs)
+		return result
+	}
+
+	attack := ExecuteOpponentAttack(acting.Enemy, player, cs.Ruleset(), roller)
+	result.Attack = &attack
+	return result
+}
+
+// UseHealingStone invokes the Healing Stone, restoring LP by the ruleset's
+// formula (1d6 × 10 in the book).
+func UseHealingStone(player *character.Character, ruleset rules.Ruleset, roller dice.Roller) (HealingStoneResult, error) {
+	if player.HealingStoneCharges <= 0 {
+		return HealingStoneResult{}, fmt.Errorf("the stone is depleted")
+	}
+	if player.CurrentLP >= player.MaximumLP {
+		return HealingStoneResult{}, fmt.Errorf("you are already at full health")
+	}
+
+	roll := 0
+	for i := 0; i < ruleset.HealingStoneDice; i++ {
+		dice.Label(roller, dice.LabelHealingStone)
+		roll += roller.Roll1D6()
+	}
+	healed, err := player.UseHealingStone(ruleset.HealingStoneLP(roll))
+	if err != nil {
+		return HealingStoneResult{}, err
+	}
+
+	return HealingStoneResult{
+		Roll:        roll,
+		Healed:      healed,
+		ChargesLeft: player.HealingStoneCharges,
+	}, nil
+}
+
+// ThrowOrb hurls The Orb at the current target. A hit annihilates a
+// Demonspawn and a miss still deals OrbThrowDamage; other creatures are
+// unharmed. The Orb is destroyed either way.
+func ThrowOrb(cs *CombatState, player *character.Character, roller dice.Roller) (OrbThrowResult, error) {
+	if player.OrbDestroyed || !player.OrbPossessed {
+		return OrbThrowResult{}, fmt.Errorf("the Orb has been destroyed")
+	}
+	if player.OrbEquipped {
+		return OrbThrowResult{}, fmt.Errorf("unequip the Orb before throwing it")
+	}
+
+	target := cs.Enemy
+	dice.Label(roller, dice.LabelOrbThrow)
+	roll := roller.Roll2D6()
+	result := OrbThrowResult{
+		Target:     target,
+		Roll:       roll,
+		Hit:        roll >= OrbThrowRequirement,
+		Demonspawn: target.IsDemonspawn,
+	}
+
+	if target.IsDemonspawn {
+		lpBefore := target.CurrentLP
+		if result.Hit {
+			target.CurrentLP = 0
+			result.Annihilated = true
+		} else {
+			target.CurrentLP -= OrbThrowDamage
+			if target.CurrentLP < 0 {
+				target.CurrentLP = 0
+			}
+		}
+		result.Damage = lpBefore - target.CurrentLP
+	}
+
+	player.DestroyOrb()
+	return result, nil
+}
+
+// ApplySpellDamage deals a spell's damage to the current target and logs
+// it. A slain target gives way to the next living opponent. Returns true
+// when every enemy is defeated; the caller then resolves the victory.
+func (cs *CombatState) ApplySpellDamage(spell string, damage int) bool {
+	cs.Enemy.CurrentLP -= damage
+	if cs.Enemy.CurrentLP < 0 {
+		cs.Enemy.CurrentLP = 0
+	}
+	cs.LogSpellDamage(spell, damage)
+	cs.retarget()
+	return CheckVictory(cs)
+}
+
+// Flee ends the fight without a winner.
+func Flee(cs *CombatState) error {
+	if !cs.IsActive {
+		return fmt.Errorf("combat is not active")
+	}
+	cs.IsActive = false
+	return nil
+}
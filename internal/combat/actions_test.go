@@ -0,0 +1,212 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/internal/items"
+	"github.com/benoit/saga-demonspawn/internal/rules"
+)
+
+// TestAttackDoombringer verifies the blood price and soul thirst.
+func TestAttackDoombringer(t *testing.T) {
+	tests := []struct {
+		name        string
+		playerLP    int
+		enemyLP     int
+		wantDrained bool
+		wantThirst  int
+		wantPlayer  int
+		wantEnemy   int
+	}{
+		// Roll 9: (9×5) + (6×5) + 20 = 95 damage
+		{"Heals damage dealt", 300, 150, false, 95, 385, 55},
+		{"Heals no more than the enemy had left", 200, 40, false, 40, 230, 0},
+		{"Capped at maximum LP", 410, 150, false, 16, 416, 55},
+		{"Blood price kills", 5, 150, true, 0, -5, 150},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player, _ := character.New(64, 56, 72, 48, 80, 40, 56) // 416 LP
+			player.EquipWeapon(&items.WeaponDoombringer)
+			player.SetLP(tt.playerLP)
+			enemy, _ := NewEnemy("Goblin", 40, 35, 30, 25, 20, 0, tt.enemyLP, 150, 5, 0, false)
+			cs := NewCombatState(enemy, 3)
+
+			result := Attack(cs, player, &MockRoller{NextRoll: 9})
+
+			if result.BloodPrice != DoombringerBloodPrice || result.Drained != tt.wantDrained {
+				t.Errorf("blood price = %d, drained = %v; want %d, %v", result.BloodPrice, result.Drained, DoombringerBloodPrice, tt.wantDrained)
+			}
+			if result.SoulThirst != tt.wantThirst {
+				t.Errorf("SoulThirst = %d, want %d", result.SoulThirst, tt.wantThirst)
+			}
+			if player.CurrentLP != tt.wantPlayer || enemy.CurrentLP != tt.wantEnemy {
+				t.Errorf("player LP = %d, enemy LP = %d; want %d, %d", player.CurrentLP, enemy.CurrentLP, tt.wantPlayer, tt.wantEnemy)
+			}
+		})
+	}
+}
+
+// TestEnemyTurnRestOrbBonus verifies the free attack on a resting enemy
+// gets The Orb's doubled damage against Demonspawn.
+func TestEnemyTurnRestOrbBonus(t *testing.T) {
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	player.EquipWeapon(&items.WeaponSword)
+	player.AcquireOrb()
+	player.OrbEquipped = true
+
+	demon, _ := NewEnemy("Demon", 40, 35, 20, 25, 20, 0, 300, 300, 5, 0, true)
+	cs := NewCombatState(demon, 3)
+	cs.TurnOrder = []int{0, PlayerActor}
+	cs.startRound()
+	cs.Opponents[0].RoundsSinceLastRest = 1 // Endurance limit is 20 ÷ 20 = 1
+
+	result := EnemyTurn(cs, player, &MockRoller{NextRoll: 9})
+
+	if !result.Rested || result.Attack != nil || result.FreeAttack == nil {
+		t.Fatalf("EnemyTurn() = %+v, want a rest with a free attack", result)
+	}
+	// (9×5) + (6×5) + 10 = 85, doubled to 170
+	if result.FreeAttack.OrbBonus != 85 || result.FreeAttack.FinalDamage != 170 {
+		t.Errorf("free attack bonus = %d, damage = %d; want 85 and 170", result.FreeAttack.OrbBonus, result.FreeAttack.FinalDamage)
+	}
+	if demon.CurrentLP != 130 {
+		t.Errorf("Demon LP = %d, want 130", demon.CurrentLP)
+	}
+	if cs.Opponents[0].RoundsSinceLastRest != 0 {
+		t.Error("resting enemy should recover its endurance")
+	}
+}
+
+// TestRest verifies every living enemy attacks while the player rests.
+func TestRest(t *testing.T) {
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	cs := NewEncounterState(encounterEnemies(), 2)
+	cs.RoundsSinceLastRest = 2
+	cs.Opponents[2].Enemy.CurrentLP = 0
+
+	if !cs.PlayerMustRest() {
+		t.Fatal("PlayerMustRest() should be true when endurance is depleted")
+	}
+
+	result := Rest(cs, player, &MockRoller{NextRoll: 12})
+
+	if len(result.Attacks) != 2 {
+		t.Fatalf("Rest() gave %d free attacks, want 2 (the Bat is dead)", len(result.Attacks))
+	}
+	if result.Attacks[0].Enemy.Name != "Orc" || !result.Attacks[0].Hit {
+		t.Errorf("first free attack = %+v, want a hit by the Orc", result.Attacks[0])
+	}
+	if cs.RoundsSinceLastRest != 0 {
+		t.Errorf("RoundsSinceLastRest = %d, want 0", cs.RoundsSinceLastRest)
+	}
+}
+
+// TestUseHealingStone verifies healing, charges and refusals.
+func TestUseHealingStone(t *testing.T) {
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+
+	if _, err := UseHealingStone(player, rules.Default(), &MockRoller{}); err == nil {
+		t.Error("UseHealingStone() expected error without charges")
+	}
+
+	player.AcquireHealingStone()
+	if _, err := UseHealingStone(player, rules.Default(), &MockRoller{}); err == nil {
+		t.Error("UseHealingStone() expected error at full health")
+	}
+
+	player.SetLP(300)
+	result, err := UseHealingStone(player, rules.Default(), &MockRoller{}) // Roll1D6 defaults to 3
+	if err != nil {
+		t.Fatalf("UseHealingStone() unexpected error: %v", err)
+	}
+	if result.Roll != 3 || result.Healed != 30 || result.ChargesLeft != 20 {
+		t.Errorf("UseHealingStone() = %+v, want roll 3, 30 healed, 20 charges left", result)
+	}
+	if player.CurrentLP != 330 {
+		t.Errorf("player LP = %d, want 330", player.CurrentLP)
+	}
+}
+
+// TestThrowOrb verifies the Orb against Demonspawn and other creatures.
+func TestThrowOrb(t *testing.T) {
+	tests := []struct {
+		name            string
+		demonspawn      bool
+		roll            int
+		wantAnnihilated bool
+		wantDamage      int
+	}{
+		{"Hit annihilates Demonspawn", true, 4, true, 300},
+		{"Miss still sears Demonspawn", true, 3, false, 200},
+		{"No effect on other creatures", false, 12, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+			player.AcquireOrb()
+			enemy, _ := NewEnemy("Foe", 40, 35, 30, 25, 20, 0, 300, 300, 5, 0, tt.demonspawn)
+			cs := NewCombatState(enemy, 3)
+
+			result, err := ThrowOrb(cs, player, &MockRoller{NextRoll: tt.roll})
+			if err != nil {
+				t.Fatalf("ThrowOrb() unexpected error: %v", err)
+			}
+			if result.Annihilated != tt.wantAnnihilated || result.Damage != tt.wantDamage {
+				t.Errorf("ThrowOrb() = %+v, want annihilated %v, damage %d", result, tt.wantAnnihilated, tt.wantDamage)
+			}
+			if !player.OrbDestroyed {
+				t.Error("The Orb should be destroyed after the throw")
+			}
+			if _, err := ThrowOrb(cs, player, &MockRoller{}); err == nil {
+				t.Error("ThrowOrb() expected error once the Orb is destroyed")
+			}
+		})
+	}
+
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	player.AcquireOrb()
+	player.OrbEquipped = true
+	enemy, _ := NewEnemy("Foe", 40, 35, 30, 25, 20, 0, 300, 300, 5, 0, true)
+	if _, err := ThrowOrb(NewCombatState(enemy, 3), player, &MockRoller{}); err == nil {
+		t.Error("ThrowOrb() expected error while the Orb is held")
+	}
+}
+
+// TestApplySpellDamage verifies a spell that slays one of two enemies
+// moves the target on, and slaying the last one wins the fight.
+func TestApplySpellDamage(t *testing.T) {
+	goblin, _ := NewEnemy("Goblin", 40, 35, 30, 25, 20, 0, 40, 40, 5, 0, false)
+	troll, _ := NewEnemy("Troll", 40, 35, 30, 25, 20, 0, 90, 90, 5, 0, false)
+	cs := NewEncounterState([]*Enemy{goblin, troll}, 3)
+
+	if cs.ApplySpellDamage("FIREBALL", 50) {
+		t.Fatal("ApplySpellDamage() = true with the Troll still alive")
+	}
+	if goblin.CurrentLP != 0 || cs.Target != 1 || cs.Enemy != troll {
+		t.Errorf("Goblin at %d LP, target %d; want the Goblin dead and the Troll targeted", goblin.CurrentLP, cs.Target)
+	}
+	if event := cs.Events[len(cs.Events)-1]; event.Type != EventDamageDealt || event.Target != "Goblin" || event.LP != 0 {
+		t.Errorf("last event = %+v, want the FIREBALL damage to the Goblin", event)
+	}
+
+	if !cs.ApplySpellDamage("POISON NEEDLE", troll.CurrentLP) {
+		t.Error("ApplySpellDamage() = false with every enemy dead")
+	}
+}
+
+// TestFlee verifies fleeing ends the fight.
+func TestFlee(t *testing.T) {
+	enemy, _ := NewEnemy("Goblin", 40, 35, 30, 25, 20, 0, 150, 150, 5, 0, false)
+	cs := NewCombatState(enemy, 3)
+
+	if err := Flee(cs); err != nil || cs.IsActive {
+		t.Errorf("Flee() = %v, active = %v; want nil, false", err, cs.IsActive)
+	}
+	if err := Flee(cs); err == nil {
+		t.Error("Flee() expected error once combat is over")
+	}
+}
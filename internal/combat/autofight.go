@@ -0,0 +1,121 @@
+package combat
+
+import (
+	"fmt"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/internal/dice"
+)
+
+// FightOptions controls an automatically resolved fight.
+type FightOptions struct {
+	MaxTurns  int  // Turns after which the fight is left unfinished (default 1000)
+	HealBelow int  // Use the Healing Stone when LP falls below this % of maximum (0 never)
+	Narrate   bool // Whether to write the fight to the combat log
+}
+
+// FightOutcome summarises an automatically resolved fight.
+type FightOutcome struct {
+	Victory           bool `json:"victory"`             // Every enemy died
+	Unfinished        bool `json:"unfinished"`          // Stopped after MaxTurns
+	Rounds            int  `json:"rounds"`              // Rounds fought, including those before a death save
+	DeathSaveRolled   bool `json:"death_save_rolled"`   // Whether the death save was needed
+	DeathSaveSurvived bool `json:"death_save_survived"` // Whether it succeeded
+	HealingStoneUses  int  `json:"healing_stone_uses"`  // Times the Healing Stone was invoked
+}
+
+// AutoResolve plays a started fight to its end, following the same turn
+// sequence as the combat screen. Fire*Wolf attacks the current target,
+// rests when out of endurance and, if asked, uses the Healing Stone when
+// badly hurt. Victory is not applied to the character; see
+// ResolveEncounterVictory.
+func AutoResolve(cs *CombatState, player *character.Character, roller dice.Roller, opts FightOptions) FightOutcome {
+	if opts.MaxTurns <= 0 {
+		opts.MaxTurns = 1000
+	}
+
+	outcome := FightOutcome{}
+	rounds := 0
+	finish := func() FightOutcome {
+		outcome.Rounds = rounds + cs.CurrentRound
+		cs.IsActive = false
+		return outcome
+	}
+
+	for turn := 0; turn < opts.MaxTurns; turn++ {
+		if cs.PlayerTurn {
+			switch {
+			case cs.PlayerMustRest():
+				if opts.Narrate {
+					cs.AddLogEntry(fmt.Sprintf("[Round %d] Endurance depleted! Must rest.", cs.CurrentRound))
+				}
+				result := Rest(cs, player, roller)
+				if opts.Narrate {
+					cs.LogRest(result)
+				}
+			case shouldHeal(player, opts.HealBelow):
+				if result, err := UseHealingStone(player, cs.Ruleset(), roller); err == nil {
+					outcome.HealingStoneUses++
+					if opts.Narrate {
+						cs.LogHealingStone(player, result)
+					}
+				}
+			default:
+				result := Attack(cs, player, roller)
+				if opts.Narrate {
+					cs.LogPlayerAttack(player, result)
+				}
+				if result.Drained {
+					// Doombringer's blood price allows no death save
+					return finish()
+				}
+			}
+		} else {
+			result := EnemyTurn(cs, player, roller)
+			if opts.Narrate {
+				cs.LogEnemyTurn(player, result)
+			}
+			if result.Rested && result.FreeAttack.Drained {
+				return finish()
+			}
+		}
+
+		if CheckVictory(cs) {
+			outcome.Victory = true
+			return finish()
+		}
+
+		if CheckDefeat(player, cs) {
+			if cs.DeathSaveUsed {
+				if opts.Narrate {
+					cs.LogDefeat()
+				}
+				return finish()
+			}
+			outcome.DeathSaveRolled = true
+			if opts.Narrate {
+				cs.AddLogEntry(fmt.Sprintf("[Critical] Your LP dropped to %d!", player.CurrentLP))
+			}
+			roundsBefore := cs.CurrentRound
+			roll, survived := AttemptDeathSave(player, cs, roller)
+			if opts.Narrate {
+				cs.LogDeathSave(player, roll, survived)
+			}
+			if !survived {
+				if opts.Narrate {
+					cs.LogDefeat()
+				}
+				return finish()
+			}
+			// The fight restarts at round 1
+			rounds += roundsBefore
+			outcome.DeathSaveSurvived = true
+			continue
+		}
+
+		NextTurn(cs)
+	}
+
+	outcome.Unfinished = true
+	return finish()
+}
@@ -0,0 +1,234 @@
+package combat
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/benoit/saga-demonspawn/internal/safefile"
+)
+
+// BestiaryFileName is the name of the bestiary file in the save directory.
+const BestiaryFileName = "bestiary.json"
+
+// BestiaryEntry is a named enemy template, tagged with the gamebook
+// sections where the enemy appears.
+type BestiaryEntry struct {
+	Enemy    Enemy  `json:"enemy"`              // Enemy statistics at full strength
+	Sections []int  `json:"sections,omitempty"` // Gamebook sections the enemy appears in
+	Notes    string `json:"notes,omitempty"`    // Free-form notes
+}
+
+// Bestiary is a library of enemy templates, kept sorted by name.
+// Names are unique, ignoring case.
+type Bestiary struct {
+	Entries []BestiaryEntry `json:"entries"`
+}
+
+// NewBestiary creates an empty bestiary.
+func NewBestiary() *Bestiary {
+	return &Bestiary{Entries: []BestiaryEntry{}}
+}
+
+// BestiaryPath returns the bestiary file location in the save directory.
+func BestiaryPath(directory string) string {
+	return filepath.Join(directory, BestiaryFileName)
+}
+
+// LoadBestiary reads a bestiary file. A missing file gives an empty bestiary.
+func LoadBestiary(path string) (*Bestiary, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewBestiary(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bestiary: %w", err)
+	}
+
+	var file Bestiary
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse bestiary: %w", err)
+	}
+
+	// Re-add every entry so names are validated, merged and sorted
+	b := NewBestiary()
+	for _, entry := range file.Entries {
+		if err := b.Add(entry); err != nil {
+			return nil, fmt.Errorf("invalid bestiary entry: %w", err)
+		}
+	}
+	return b, nil
+}
+
+// Save writes the bestiary as JSON, creating the directory if needed.
+func (b *Bestiary) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create bestiary directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal bestiary: %w", err)
+	}
+
+	if err := safefile.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write bestiary: %w", err)
+	}
+	return nil
+}
+
+// Add stores an enemy template. An existing entry with the same name is
+// replaced, keeping the sections it was already tagged with. An enemy
+// without current LP is stored at full strength.
+func (b *Bestiary) Add(entry BestiaryEntry) error {
+	if entry.Enemy.CurrentLP == 0 {
+		entry.Enemy.CurrentLP = entry.Enemy.MaximumLP
+	}
+	e := entry.Enemy
+	if _, err := NewEnemy(strings.TrimSpace(e.Name), e.Strength, e.Speed, e.Stamina, e.Courage, e.Luck, e.Skill,
+		e.CurrentLP, e.MaximumLP, e.WeaponBonus, e.ArmorProtection, e.IsDemonspawn); err != nil {
+		return err
+	}
+	entry.Enemy.Name = strings.TrimSpace(e.Name)
+
+	if existing := b.Find(entry.Enemy.Name); existing != nil {
+		entry.Sections = mergeSections(existing.Sections, entry.Sections)
+		*existing = entry
+		return nil
+	}
+
+	entry.Sections = mergeSections(nil, entry.Sections)
+	b.Entries = append(b.Entries, entry)
+	sort.Slice(b.Entries, func(i, j int) bool {
+		return strings.ToLower(b.Entries[i].Enemy.Name) < strings.ToLower(b.Entries[j].Enemy.Name)
+	})
+	return nil
+}
+
+// Find returns the entry with the given name, ignoring case, or nil.
+func (b *Bestiary) Find(name string) *BestiaryEntry {
+	for i := range b.Entries {
+		if strings.EqualFold(b.Entries[i].Enemy.Name, strings.TrimSpace(name)) {
+			return &b.Entries[i]
+		}
+	}
+	return nil
+}
+
+// Remove deletes the entry with the given name. Returns false if not found.
+func (b *Bestiary) Remove(name string) bool {
+	for i := range b.Entries {
+		if strings.EqualFold(b.Entries[i].Enemy.Name, strings.TrimSpace(name)) {
+			b.Entries = append(b.Entries[:i], b.Entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Search returns the entries whose name contains the query, ignoring case.
+// A numeric query also matches entries tagged with that section.
+// An empty query returns every entry.
+func (b *Bestiary) Search(query string) []BestiaryEntry {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return append([]BestiaryEntry{}, b.Entries...)
+	}
+
+	section, numErr := strconv.Atoi(query)
+	results := []BestiaryEntry{}
+	for _, entry := range b.Entries {
+		if strings.Contains(strings.ToLower(entry.Enemy.Name), query) {
+			results = append(results, entry)
+			continue
+		}
+		if numErr == nil && entry.AppearsIn(section) {
+			results = append(results, entry)
+		}
+	}
+	return results
+}
+
+// Import merges the entries of another bestiary into this one.
+// Returns how many entries were new and how many replaced existing ones.
+func (b *Bestiary) Import(other *Bestiary) (added, updated int, err error) {
+	for _, entry := range other.Entries {
+		exists := b.Find(entry.Enemy.Name) != nil
+		if err := b.Add(entry); err != nil {
+			return added, updated, err
+		}
+		if exists {
+			updated++
+		} else {
+			added++
+		}
+	}
+	return added, updated, nil
+}
+
+// AppearsIn returns true if the entry is tagged with the given section.
+func (e BestiaryEntry) AppearsIn(section int) bool {
+	for _, s := range e.Sections {
+		if s == section {
+			return true
+		}
+	}
+	return false
+}
+
+// NewEnemy returns a fresh enemy from the template, ready for combat.
+func (e BestiaryEntry) NewEnemy() *Enemy {
+	enemy := e.Enemy
+	return &enemy
+}
+
+// TemplateFromEnemy builds a bestiary entry from an enemy met in combat.
+// The template is stored at full LP.
+func TemplateFromEnemy(enemy *Enemy, section int) BestiaryEntry {
+	template := *enemy
+	template.CurrentLP = template.MaximumLP
+
+	entry := BestiaryEntry{Enemy: template}
+	if section > 0 {
+		entry.Sections = []int{section}
+	}
+	return entry
+}
+
+// LoadEnemy reads a single enemy from a JSON file. An enemy without
+// current LP starts the fight at full strength.
+func LoadEnemy(path string) (*Enemy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read enemy: %w", err)
+	}
+
+	var e Enemy
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("failed to parse enemy: %w", err)
+	}
+	if e.CurrentLP == 0 {
+		e.CurrentLP = e.MaximumLP
+	}
+
+	return NewEnemy(strings.TrimSpace(e.Name), e.Strength, e.Speed, e.Stamina, e.Courage, e.Luck, e.Skill,
+		e.CurrentLP, e.MaximumLP, e.WeaponBonus, e.ArmorProtection, e.IsDemonspawn)
+}
+
+// mergeSections combines two section lists into a sorted list without duplicates.
+func mergeSections(a, b []int) []int {
+	seen := make(map[int]bool)
+	merged := []int{}
+	for _, section := range append(append([]int{}, a...), b...) {
+		if section > 0 && !seen[section] {
+			seen[section] = true
+			merged = append(merged, section)
+		}
+	}
+	sort.Ints(merged)
+	return merged
+}
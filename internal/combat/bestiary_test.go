@@ -0,0 +1,171 @@
+package combat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testEntry builds a bestiary entry for tests.
+func testEntry(name string, maxLP int, sections ...int) BestiaryEntry {
+	enemy, _ := NewEnemy(name, 40, 35, 30, 25, 20, 0, maxLP, maxLP, 5, 0, false)
+	return BestiaryEntry{Enemy: *enemy, Sections: sections}
+}
+
+// TestBestiaryAdd verifies sorting, validation and replacement by name.
+func TestBestiaryAdd(t *testing.T) {
+	b := NewBestiary()
+
+	if err := b.Add(testEntry("Troll", 200, 12)); err != nil {
+		t.Fatalf("Add() unexpected error: %v", err)
+	}
+	b.Add(testEntry("Goblin", 100, 3))
+	if err := b.Add(testEntry("goblin", 120, 7, 3)); err != nil {
+		t.Fatalf("Add() replacement unexpected error: %v", err)
+	}
+
+	if len(b.Entries) != 2 {
+		t.Fatalf("len(Entries) = %d, want 2", len(b.Entries))
+	}
+	if b.Entries[0].Enemy.Name != "goblin" || b.Entries[1].Enemy.Name != "Troll" {
+		t.Errorf("Entries not sorted by name: %s, %s", b.Entries[0].Enemy.Name, b.Entries[1].Enemy.Name)
+	}
+	goblin := b.Find("GOBLIN")
+	if goblin == nil || goblin.Enemy.MaximumLP != 120 {
+		t.Fatalf("Find(GOBLIN) = %+v, want replaced entry with 120 LP", goblin)
+	}
+	if len(goblin.Sections) != 2 || goblin.Sections[0] != 3 || goblin.Sections[1] != 7 {
+		t.Errorf("Sections = %v, want [3 7]", goblin.Sections)
+	}
+
+	invalid := testEntry("Ghost", 100)
+	invalid.Enemy.Name = "  "
+	if err := b.Add(invalid); err == nil {
+		t.Error("Add() expected error for an unnamed enemy")
+	}
+}
+
+// TestBestiarySearch verifies name and section search.
+func TestBestiarySearch(t *testing.T) {
+	b := NewBestiary()
+	b.Add(testEntry("Cave Troll", 200, 12))
+	b.Add(testEntry("Goblin", 100, 3))
+	b.Add(testEntry("Goblin Chief", 150, 120))
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"", 3},
+		{"gob", 2},
+		{"TROLL", 1},
+		{"12", 1},
+		{"120", 1},
+		{"dragon", 0},
+	}
+
+	for _, tt := range tests {
+		if got := len(b.Search(tt.query)); got != tt.want {
+			t.Errorf("Search(%q) returned %d entries, want %d", tt.query, got, tt.want)
+		}
+	}
+}
+
+// TestBestiaryPersistence verifies save, load and import.
+func TestBestiaryPersistence(t *testing.T) {
+	path := BestiaryPath(t.TempDir())
+
+	empty, err := LoadBestiary(path)
+	if err != nil || len(empty.Entries) != 0 {
+		t.Fatalf("LoadBestiary() of missing file = %v, %v; want empty bestiary", empty, err)
+	}
+
+	b := NewBestiary()
+	b.Add(testEntry("Goblin", 100, 3))
+	if err := b.Save(path); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+
+	loaded, err := LoadBestiary(path)
+	if err != nil {
+		t.Fatalf("LoadBestiary() unexpected error: %v", err)
+	}
+	if loaded.Find("Goblin") == nil {
+		t.Fatal("loaded bestiary should contain Goblin")
+	}
+
+	shared := NewBestiary()
+	shared.Add(testEntry("Goblin", 110, 9))
+	shared.Add(testEntry("Wraith", 90, 44))
+	sharedPath := filepath.Join(t.TempDir(), "shared.json")
+	shared.Save(sharedPath)
+
+	other, _ := LoadBestiary(sharedPath)
+	added, updated, err := loaded.Import(other)
+	if err != nil {
+		t.Fatalf("Import() unexpected error: %v", err)
+	}
+	if added != 1 || updated != 1 {
+		t.Errorf("Import() = %d added, %d updated; want 1 and 1", added, updated)
+	}
+	if !loaded.Find("Goblin").AppearsIn(3) || !loaded.Find("Goblin").AppearsIn(9) {
+		t.Error("imported Goblin should keep both section tags")
+	}
+}
+
+// TestBestiaryImportWithoutCurrentLP verifies a shared bestiary written
+// without current LP gives enemies at full strength.
+func TestBestiaryImportWithoutCurrentLP(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shared.json")
+	os.WriteFile(path, []byte(`{"entries": [{"enemy": {"name": "Goblin", "strength": 40, "maximum_lp": 100}, "sections": [3]}]}`), 0644)
+
+	shared, err := LoadBestiary(path)
+	if err != nil {
+		t.Fatalf("LoadBestiary() unexpected error: %v", err)
+	}
+	b := NewBestiary()
+	if _, _, err := b.Import(shared); err != nil {
+		t.Fatalf("Import() unexpected error: %v", err)
+	}
+	if enemy := b.Find("Goblin").NewEnemy(); enemy.CurrentLP != 100 {
+		t.Errorf("NewEnemy() CurrentLP = %d, want 100", enemy.CurrentLP)
+	}
+}
+
+// TestTemplateFromEnemy verifies templates are stored at full LP.
+func TestTemplateFromEnemy(t *testing.T) {
+	enemy, _ := NewEnemy("Goblin", 40, 35, 30, 25, 20, 0, 0, 100, 5, 0, false)
+
+	entry := TemplateFromEnemy(enemy, 42)
+	if entry.Enemy.CurrentLP != 100 {
+		t.Errorf("template CurrentLP = %d, want 100", entry.Enemy.CurrentLP)
+	}
+	if !entry.AppearsIn(42) {
+		t.Error("template should be tagged with section 42")
+	}
+
+	fresh := entry.NewEnemy()
+	fresh.CurrentLP = 10
+	if entry.Enemy.CurrentLP != 100 {
+		t.Error("NewEnemy() should return an independent copy")
+	}
+}
+
+// TestLoadEnemy verifies reading a single enemy file.
+func TestLoadEnemy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goblin.json")
+	os.WriteFile(path, []byte(`{"name": "Goblin", "strength": 40, "maximum_lp": 100, "weapon_bonus": 5}`), 0644)
+
+	enemy, err := LoadEnemy(path)
+	if err != nil {
+		t.Fatalf("LoadEnemy() unexpected error: %v", err)
+	}
+	if enemy.Name != "Goblin" || enemy.CurrentLP != 100 {
+		t.Errorf("LoadEnemy() = %+v, want Goblin at 100 LP", enemy)
+	}
+
+	os.WriteFile(path, []byte(`{"name": "Ghost"}`), 0644)
+	if _, err := LoadEnemy(path); err == nil {
+		t.Error("LoadEnemy() expected error for an enemy without LP")
+	}
+}
@@ -3,9 +3,11 @@ package combat
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/benoit/saga-demonspawn/internal/character"
 	"github.com/benoit/saga-demonspawn/internal/dice"
+	"github.com/benoit/saga-demonspawn/internal/rules"
 )
 
 // Enemy represents an opponent in combat.
@@ -76,29 +78,68 @@ func NewEnemy(name string, str, spd, sta, crg, lck, skill, currentLP, maxLP, wea
 	}, nil
 }
 
+// PlayerActor marks the player's place in the turn order.
+const PlayerActor = -1
+
+// Opponent tracks one enemy's part in an encounter.
+type Opponent struct {
+	Enemy               *Enemy `json:"enemy"`                  // Enemy statistics and current LP
+	Initiative          int    `json:"initiative"`             // Initiative roll result
+	EnduranceLimit      int    `json:"endurance_limit"`        // Max rounds before resting
+	RoundsSinceLastRest int    `json:"rounds_since_last_rest"` // Endurance tracking
+}
+
+// IsAlive returns true if the opponent can still fight.
+func (o *Opponent) IsAlive() bool {
+	return o.Enemy.CurrentLP > 0
+}
+
 // CombatState encapsulates the complete state of an active combat encounter.
+// An encounter has one or more opponents; each acts in initiative order.
 type CombatState struct {
-	IsActive            bool     `json:"is_active"`              // Whether combat is currently ongoing
-	CurrentRound        int      `json:"current_round"`          // Round counter (starts at 1)
-	PlayerTurn          bool     `json:"player_turn"`            // True if player turn, false if enemy turn
-	PlayerFirstStrike   bool     `json:"player_first_strike"`    // Tracks who won initiative
-	DeathSaveUsed       bool     `json:"death_save_used"`        // Prevents multiple death saves
-	EnduranceLimit      int      `json:"endurance_limit"`        // Calculated max rounds (STA ÷ 20)
-	RoundsSinceLastRest int      `json:"rounds_since_last_rest"` // Endurance tracking
-	EnemyEnduranceLimit      int      `json:"enemy_endurance_limit"`        // Enemy's max rounds (STA ÷ 20)
-	EnemyRoundsSinceLastRest int      `json:"enemy_rounds_since_last_rest"` // Enemy endurance tracking
-	Enemy               *Enemy   `json:"enemy"`                  // Complete enemy data
-	CombatLog           []string `json:"combat_log"`             // Historical combat messages
-	PlayerInitiative    int      `json:"player_initiative"`      // Player's initiative roll result
-	EnemyInitiative     int      `json:"enemy_initiative"`       // Enemy's initiative roll result
+	IsActive            bool          `json:"is_active"`              // Whether combat is currently ongoing
+	CurrentRound        int           `json:"current_round"`          // Round counter (starts at 1)
+	PlayerTurn          bool          `json:"player_turn"`            // True if player turn, false if an enemy's turn
+	PlayerFirstStrike   bool          `json:"player_first_strike"`    // Whether the player acts first each round
+	DeathSaveUsed       bool          `json:"death_save_used"`        // Prevents multiple death saves
+	EnduranceLimit      int           `json:"endurance_limit"`        // Calculated max rounds (STA ÷ endurance divisor)
+	RoundsSinceLastRest int           `json:"rounds_since_last_rest"` // Endurance tracking
+	Opponents           []*Opponent   `json:"opponents"`              // Every enemy in the encounter, in setup order
+	Target              int           `json:"target"`                 // Index of the opponent the player attacks
+	Enemy               *Enemy        `json:"-"`                      // The player's current target (Opponents[Target].Enemy)
+	TurnOrder           []int         `json:"turn_order"`             // Acting order: PlayerActor or an opponent index
+	TurnIndex           int           `json:"turn_index"`             // Position of the current actor in TurnOrder
+	Events              []Event       `json:"events"`                 // Everything that happened, in order
+	Tallied             int           `json:"tallied"`                // Events already added to the player's statistics
+	PlayerInitiative    int           `json:"player_initiative"`      // Player's initiative roll result
+	Rules               rules.Ruleset `json:"rules"`                  // Rules the fight is played by
 }
 
 // NewCombatState creates a new combat state with the given enemy.
 func NewCombatState(enemy *Enemy, enduranceLimit int) *CombatState {
-	// Calculate enemy endurance limit
-	enemyEnduranceLimit := enemy.Stamina / 10
-	
-	return &CombatState{
+	return NewEncounterState([]*Enemy{enemy}, enduranceLimit)
+}
+
+// NewEncounterState creates a new combat state against several enemies,
+// played by the default rules.
+// Until initiative is rolled the player acts first, then each enemy in order.
+func NewEncounterState(enemies []*Enemy, enduranceLimit int) *CombatState {
+	return newEncounterState(enemies, enduranceLimit, rules.Default())
+}
+
+// newEncounterState creates a new combat state played by the given rules.
+func newEncounterState(enemies []*Enemy, enduranceLimit int, ruleset rules.Ruleset) *CombatState {
+	opponents := make([]*Opponent, len(enemies))
+	turnOrder := []int{PlayerActor}
+	for i, enemy := range enemies {
+		opponents[i] = &Opponent{
+			Enemy:          enemy,
+			EnduranceLimit: ruleset.EnduranceLimit(enemy.Stamina),
+		}
+		turnOrder = append(turnOrder, i)
+	}
+
+	cs := &CombatState{
 		IsActive:            true,
 		CurrentRound:        1,
 		PlayerTurn:          false, // Will be set by initiative
@@ -106,24 +147,154 @@ func NewCombatState(enemy *Enemy, enduranceLimit int) *CombatState {
 		DeathSaveUsed:       false,
 		EnduranceLimit:      enduranceLimit,
 		RoundsSinceLastRest: 0,
-		EnemyEnduranceLimit:      enemyEnduranceLimit,
-		EnemyRoundsSinceLastRest: 0,
-		Enemy:               enemy,
-		CombatLog:           make([]string, 0),
+		Opponents:           opponents,
+		TurnOrder:           turnOrder,
+		TurnIndex:           0,
+		Events:              make([]Event, 0),
 		PlayerInitiative:    0,
-		EnemyInitiative:     0,
+		Rules:               ruleset,
 	}
+	cs.Target = -1
+	cs.retarget()
+	return cs
+}
+
+// Ruleset returns the rules the fight is played by. A fight saved before
+// rulesets existed is played by the default rules.
+func (cs *CombatState) Ruleset() rules.Ruleset {
+	if cs.Rules.IsZero() {
+		return rules.Default()
+	}
+	return cs.Rules
 }
 
-// AddLogEntry appends a message to the combat log.
+// AddLogEntry appends a message with no game effect to the combat log.
 func (cs *CombatState) AddLogEntry(message string) {
-	cs.CombatLog = append(cs.CombatLog, message)
+	cs.Record(Event{Type: EventNote, Message: message})
+}
+
+// Clone returns a deep copy of the combat state, including the enemies and
+// events. Recorded events are never changed, so they are shared.
+func (cs *CombatState) Clone() *CombatState {
+	clone := *cs
+	clone.Opponents = make([]*Opponent, len(cs.Opponents))
+	for i, opponent := range cs.Opponents {
+		o := *opponent
+		enemy := *opponent.Enemy
+		o.Enemy = &enemy
+		clone.Opponents[i] = &o
+	}
+	clone.TurnOrder = append([]int{}, cs.TurnOrder...)
+	clone.Events = append([]Event{}, cs.Events...)
+	clone.Enemy = nil
+	if cs.Target >= 0 && cs.Target < len(clone.Opponents) {
+		clone.Enemy = clone.Opponents[cs.Target].Enemy
+	}
+	return &clone
+}
+
+// LivingOpponents returns the opponents still able to fight.
+func (cs *CombatState) LivingOpponents() []*Opponent {
+	living := []*Opponent{}
+	for _, opponent := range cs.Opponents {
+		if opponent.IsAlive() {
+			living = append(living, opponent)
+		}
+	}
+	return living
+}
+
+// SetTarget chooses which opponent the player attacks.
+func (cs *CombatState) SetTarget(index int) error {
+	if index < 0 || index >= len(cs.Opponents) {
+		return fmt.Errorf("no opponent %d", index+1)
+	}
+	if !cs.Opponents[index].IsAlive() {
+		return fmt.Errorf("%s is already dead", cs.Opponents[index].Enemy.Name)
+	}
+	cs.Target = index
+	cs.Enemy = cs.Opponents[index].Enemy
+	return nil
+}
+
+// CycleTarget moves the player's target to the next (delta > 0) or
+// previous (delta < 0) living opponent.
+func (cs *CombatState) CycleTarget(delta int) {
+	n := len(cs.Opponents)
+	for step := 1; step < n; step++ {
+		index := ((cs.Target+delta*step)%n + n) % n
+		if cs.Opponents[index].IsAlive() {
+			cs.SetTarget(index)
+			return
+		}
+	}
+}
+
+// retarget moves the player's target to the first living opponent when
+// the current one has died. A finished encounter keeps its last target.
+func (cs *CombatState) retarget() {
+	if cs.Target >= 0 && cs.Target < len(cs.Opponents) && cs.Opponents[cs.Target].IsAlive() {
+		cs.Enemy = cs.Opponents[cs.Target].Enemy
+		return
+	}
+	for i, opponent := range cs.Opponents {
+		if opponent.IsAlive() {
+			cs.SetTarget(i)
+			return
+		}
+	}
+	if cs.Target < 0 && len(cs.Opponents) > 0 {
+		cs.Target = 0
+	}
+	if cs.Target >= 0 && cs.Target < len(cs.Opponents) {
+		cs.Enemy = cs.Opponents[cs.Target].Enemy
+	}
+}
+
+// ActingOpponent returns the opponent whose turn it is.
+// During the player's turn it returns the player's target.
+func (cs *CombatState) ActingOpponent() *Opponent {
+	if !cs.PlayerTurn && cs.TurnIndex < len(cs.TurnOrder) {
+		if actor := cs.TurnOrder[cs.TurnIndex]; actor != PlayerActor {
+			return cs.Opponents[actor]
+		}
+	}
+	if cs.Target >= 0 && cs.Target < len(cs.Opponents) {
+		return cs.Opponents[cs.Target]
+	}
+	return nil
+}
+
+// ActingEnemy returns the enemy whose turn it is, or the player's target
+// during the player's turn.
+func (cs *CombatState) ActingEnemy() *Enemy {
+	if opponent := cs.ActingOpponent(); opponent != nil {
+		return opponent.Enemy
+	}
+	return cs.Enemy
+}
+
+// actorCanAct returns true if the actor at the given turn position is alive.
+func (cs *CombatState) actorCanAct(position int) bool {
+	actor := cs.TurnOrder[position]
+	return actor == PlayerActor || cs.Opponents[actor].IsAlive()
+}
+
+// startRound puts the first living actor of the turn order in play.
+func (cs *CombatState) startRound() {
+	cs.TurnIndex = 0
+	for cs.TurnIndex < len(cs.TurnOrder)-1 && !cs.actorCanAct(cs.TurnIndex) {
+		cs.TurnIndex++
+	}
+	cs.PlayerTurn = cs.TurnOrder[cs.TurnIndex] == PlayerActor
 }
 
 // CalculateInitiative determines who strikes first in combat.
 // Returns the player's initiative score, enemy's initiative score, and whether player goes first.
 func CalculateInitiative(player *character.Character, enemy *Enemy, roller dice.Roller) (int, int, bool) {
+	dice.Label(roller, dice.LabelInitiative+": Fire*Wolf")
 	playerRoll := roller.Roll2D6()
+	dice.Label(roller, dice.LabelInitiative+": "+enemy.Name)
 	enemyRoll := roller.Roll2D6()
 
 	playerInitiative := playerRoll + player.Speed + player.Courage + player.Luck
@@ -132,35 +303,51 @@ func CalculateInitiative(player *character.Character, enemy *Enemy, roller dice.
 	return playerInitiative, enemyInitiative, playerInitiative > enemyInitiative
 }
 
-// CalculateToHitRequirement determines the number needed on 2d6 to hit.
-// Base requirement is 7, reduced by skill (1 per 10 points) and luck (1 if >= 72).
-// Minimum requirement is always 2.
-func CalculateToHitRequirement(skill, luck int) int {
-	requirement := 7
-
-	// Skill modifier: -1 per 10 full points
-	requirement -= skill / 10
+// RollInitiative rolls initiative for the player and every opponent and
+// sorts the turn order, highest first. The player rolls once; an enemy
+// beats the player on a tie, and tied enemies keep their setup order.
+func RollInitiative(cs *CombatState, player *character.Character, roller dice.Roller) {
+	dice.Label(roller, dice.LabelInitiative+": Fire*Wolf")
+	cs.PlayerInitiative = roller.Roll2D6() + player.Speed + player.Courage + player.Luck
+	for _, opponent := range cs.Opponents {
+		enemy := opponent.Enemy
+		dice.Label(roller, dice.LabelInitiative+": "+enemy.Name)
+		opponent.Initiative = roller.Roll2D6() + enemy.Speed + enemy.Courage + enemy.Luck
+	}
 
-	// Luck modifier: -1 if luck >= 72
-	if luck >= 72 {
-		requirement--
+	initiative := func(actor int) int {
+		if actor == PlayerActor {
+			return cs.PlayerInitiative
+		}
+		return cs.Opponents[actor].Initiative
 	}
 
-	// Minimum requirement is always 2
-	if requirement < 2 {
-		requirement = 2
+	order := []int{}
+	for i := range cs.Opponents {
+		order = append(order, i)
 	}
+	order = append(order, PlayerActor)
+	sort.SliceStable(order, func(i, j int) bool {
+		return initiative(order[i]) > initiative(order[j])
+	})
 
-	return requirement
+	cs.TurnOrder = order
+	cs.PlayerFirstStrike = order[0] == PlayerActor
+	cs.startRound()
 }
 
-// CalculateDamage computes the total damage before armor reduction.
-// Formula: (roll × 5) + (STR ÷ 10 × 5) + weapon bonus
+// CalculateToHitRequirement determines the number needed on 2d6 to hit
+// under the default rules: 7, reduced by skill (1 per 10 points) and luck
+// (1 if >= 72), never below 2. See rules.Ruleset.ToHitRequirement.
+func CalculateToHitRequirement(skill, luck int) int {
+	return rules.Default().ToHitRequirement(skill, luck)
+}
+
+// CalculateDamage computes the total damage before armor reduction under
+// the default rules: (roll × 5) + (STR ÷ 10 × 5) + weapon bonus.
+// See rules.Ruleset.Damage.
 func CalculateDamage(rollResult, strength, weaponBonus int) int {
-	baseDamage := rollResult * 5
-	strengthBonus := (strength / 10) * 5
-	totalDamage := baseDamage + strengthBonus + weaponBonus
-	return totalDamage
+	return rules.Default().Damage(rollResult, strength, weaponBonus)
 }
 
 // ApplyArmorReduction subtracts armor protection from damage.
@@ -178,10 +365,11 @@ func CheckEndurance(roundsSinceLastRest, enduranceLimit int) bool {
 	return roundsSinceLastRest >= enduranceLimit && enduranceLimit > 0
 }
 
-// ExecuteDeathSave performs a death save roll.
-// Returns true if successful (result <= luck), false otherwise.
-func ExecuteDeathSave(luck int, roller dice.Roller) (int, bool) {
-	roll := roller.Roll2D6() * 10
+// ExecuteDeathSave performs a death save roll: 2d6 times the ruleset's
+// death save multiplier. Returns true if successful (result <= luck).
+func ExecuteDeathSave(luck int, ruleset rules.Ruleset, roller dice.Roller) (int, bool) {
+	dice.Label(roller, dice.LabelDeathSave)
+	roll := ruleset.DeathSaveScore(roller.Roll2D6())
 	return roll, roll <= luck
 }
 
@@ -193,14 +381,22 @@ type AttackResult struct {
 	DamageBeforeArmor int  // Damage before armor reduction
 	FinalDamage     int    // Damage after armor reduction
 	TargetLP        int    // Target's LP after damage
+	Modifiers       []Term // Terms adding up to FinalDamage, starting with the roll's
 }
 
-// ExecutePlayerAttack performs a player attack and updates combat state.
+// ExecutePlayerAttack performs a player attack on the current target and
+// updates combat state. A slain target stays selected until the next turn.
 func ExecutePlayerAttack(cs *CombatState, player *character.Character, roller dice.Roller) AttackResult {
+	return ExecuteAttackOn(cs.Enemy, player, cs.Ruleset(), roller)
+}
+
+// ExecuteAttackOn performs a player attack on the given enemy.
+func ExecuteAttackOn(target *Enemy, player *character.Character, ruleset rules.Ruleset, roller dice.Roller) AttackResult {
 	// Calculate to-hit requirement
-	requirement := CalculateToHitRequirement(player.Skill, player.Luck)
+	requirement := ruleset.ToHitRequirement(player.Skill, player.Luck)
 	
 	// Roll to hit
+	dice.Label(roller, dice.LabelToHit+": "+target.Name)
 	roll := roller.Roll2D6()
 	hit := roll >= requirement
 	
@@ -217,29 +413,38 @@ func ExecutePlayerAttack(cs *CombatState, player *character.Character, roller di
 			weaponBonus = player.EquippedWeapon.DamageBonus
 		}
 		
-		damageBeforeArmor := CalculateDamage(roll, player.Strength, weaponBonus)
-		finalDamage := ApplyArmorReduction(damageBeforeArmor, cs.Enemy.ArmorProtection)
+		damageBeforeArmor := ruleset.Damage(roll, player.Strength, weaponBonus)
+		finalDamage := ApplyArmorReduction(damageBeforeArmor, target.ArmorProtection)
+		result.Modifiers = damageTerms(roll, ruleset, player.Strength, weaponBonus)
+		result.Modifiers = appendTerm(result.Modifiers, "Armor", finalDamage-damageBeforeArmor)
 		
 		// Apply damage
-		cs.Enemy.CurrentLP -= finalDamage
-		if cs.Enemy.CurrentLP < 0 {
-			cs.Enemy.CurrentLP = 0
+		target.CurrentLP -= finalDamage
+		if target.CurrentLP < 0 {
+			target.CurrentLP = 0
 		}
 		
 		result.DamageBeforeArmor = damageBeforeArmor
 		result.FinalDamage = finalDamage
-		result.TargetLP = cs.Enemy.CurrentLP
+		result.TargetLP = target.CurrentLP
 	}
 	
 	return result
 }
 
-// ExecuteEnemyAttack performs an enemy attack and updates combat state.
+// ExecuteEnemyAttack performs an attack by the enemy whose turn it is and
+// updates combat state.
 func ExecuteEnemyAttack(cs *CombatState, player *character.Character, roller dice.Roller) AttackResult {
+	return ExecuteOpponentAttack(cs.ActingEnemy(), player, cs.Ruleset(), roller)
+}
+
+// ExecuteOpponentAttack performs an attack on the player by the given enemy.
+func ExecuteOpponentAttack(enemy *Enemy, player *character.Character, ruleset rules.Ruleset, roller dice.Roller) AttackResult {
 	// Calculate to-hit requirement
-	requirement := CalculateToHitRequirement(cs.Enemy.Skill, cs.Enemy.Luck)
+	requirement := ruleset.ToHitRequirement(enemy.Skill, enemy.Luck)
 	
 	// Roll to hit
+	dice.Label(roller, dice.LabelEnemyToHit+": "+enemy.Name)
 	roll := roller.Roll2D6()
 	hit := roll >= requirement
 	
@@ -251,15 +456,18 @@ func ExecuteEnemyAttack(cs *CombatState, player *character.Character, roller dic
 	
 	if hit {
 		// Calculate damage
-		damageBeforeArmor := CalculateDamage(roll, cs.Enemy.Strength, cs.Enemy.WeaponBonus)
+		damageBeforeArmor := ruleset.Damage(roll, enemy.Strength, enemy.WeaponBonus)
+		result.Modifiers = damageTerms(roll, ruleset, enemy.Strength, enemy.WeaponBonus)
 		
 		// Apply XENOPHOBIA effect if active
 		if player.HasSpellEffect("XENOPHOBIA") {
 			xenophobiaReduction := player.GetSpellEffect("XENOPHOBIA")
+			before := damageBeforeArmor
 			damageBeforeArmor -= xenophobiaReduction
 			if damageBeforeArmor < 0 {
 				damageBeforeArmor = 0
 			}
+			result.Modifiers = appendTerm(result.Modifiers, "XENOPHOBIA", damageBeforeArmor-before)
 		}
 		
 		// Calculate player armor protection
@@ -282,6 +490,7 @@ func ExecuteEnemyAttack(cs *CombatState, player *character.Character, roller dic
 		}
 		
 		finalDamage := ApplyArmorReduction(damageBeforeArmor, armorProtection)
+		result.Modifiers = appendTerm(result.Modifiers, "Armor", finalDamage-damageBeforeArmor)
 		
 		// Apply damage
 		player.ModifyLP(-finalDamage)
@@ -294,27 +503,65 @@ func ExecuteEnemyAttack(cs *CombatState, player *character.Character, roller dic
 	return result
 }
 
+// damageTerms breaks the damage of a hit before armor into its terms:
+// the roll times the damage multiplier, strength and weapon.
+func damageTerms(roll int, ruleset rules.Ruleset, strength, weaponBonus int) []Term {
+	terms := []Term{{Name: fmt.Sprintf("%d×%d", roll, ruleset.DamageMultiplier), Value: roll * ruleset.DamageMultiplier}}
+	terms = appendTerm(terms, "STR", ruleset.StrengthBonus(strength))
+	return appendTerm(terms, "Weapon", weaponBonus)
+}
+
+// appendTerm adds a term that changed the damage; zero terms are left out.
+func appendTerm(terms []Term, name string, value int) []Term {
+	if value == 0 {
+		return terms
+	}
+	return append(terms, Term{Name: name, Value: value})
+}
+
 // StartCombat initializes combat with initiative roll.
 func StartCombat(player *character.Character, enemy *Enemy, roller dice.Roller) *CombatState {
-	// Calculate endurance limit
-	enduranceLimit := player.Stamina / 10
-	
-	// Create combat state
-	cs := NewCombatState(enemy, enduranceLimit)
-	
-	// Roll initiative
-	playerInit, enemyInit, playerFirst := CalculateInitiative(player, enemy, roller)
-	cs.PlayerInitiative = playerInit
-	cs.EnemyInitiative = enemyInit
-	cs.PlayerFirstStrike = playerFirst
-	cs.PlayerTurn = playerFirst
-	
+	return StartEncounter(player, []*Enemy{enemy}, roller)
+}
+
+// StartEncounter initializes combat against one or more enemies and rolls
+// initiative for everyone, played by the default rules.
+func StartEncounter(player *character.Character, enemies []*Enemy, roller dice.Roller) *CombatState {
+	return StartEncounterWithRules(player, enemies, rules.Default(), roller)
+}
+
+// StartEncounterWithRules initializes combat against one or more enemies
+// played by the given rules, and rolls initiative for everyone.
+func StartEncounterWithRules(player *character.Character, enemies []*Enemy, ruleset rules.Ruleset, roller dice.Roller) *CombatState {
+	cs := newEncounterState(enemies, ruleset.EnduranceLimit(player.Stamina), ruleset)
+	RollInitiative(cs, player, roller)
+
 	return cs
 }
 
-// CheckVictory returns true if the enemy is defeated.
+// ResumeCombat puts a lost fight back in progress after a RESURRECTION.
+// The enemies keep their wounds; the player's endurance is recalculated from
+// the rerolled Stamina and the death save becomes available again.
+func ResumeCombat(player *character.Character, cs *CombatState) {
+	cs.IsActive = true
+	cs.DeathSaveUsed = false
+	cs.EnduranceLimit = cs.Ruleset().EnduranceLimit(player.Stamina)
+	cs.RoundsSinceLastRest = 0
+	for _, opponent := range cs.Opponents {
+		opponent.RoundsSinceLastRest = 0
+	}
+	cs.retarget()
+	cs.startRound()
+}
+
+// CheckVictory returns true if every enemy is defeated.
 func CheckVictory(cs *CombatState) bool {
-	return cs.Enemy.CurrentLP <= 0
+	for _, opponent := range cs.Opponents {
+		if opponent.IsAlive() {
+			return false
+		}
+	}
+	return true
 }
 
 // CheckDefeat returns true if the player is defeated (LP <= 0 and no death save available).
@@ -322,17 +569,27 @@ func CheckDefeat(player *character.Character, cs *CombatState) bool {
 	return player.CurrentLP <= 0
 }
 
-// NextTurn advances combat to the next turn, handling turn alternation and round progression.
+// NextTurn advances combat to the next living combatant in the turn order.
+// When the order wraps around a new round begins and every endurance
+// tracker advances.
 func NextTurn(cs *CombatState) {
-	// Toggle turn
-	cs.PlayerTurn = !cs.PlayerTurn
-	
-	// If we're back to the first striker, increment round and endurance trackers
-	if cs.PlayerTurn == cs.PlayerFirstStrike {
-		cs.CurrentRound++
-		cs.RoundsSinceLastRest++
-		cs.EnemyRoundsSinceLastRest++
+	cs.retarget()
+	for {
+		cs.TurnIndex++
+		if cs.TurnIndex >= len(cs.TurnOrder) {
+			cs.CurrentRound++
+			cs.RoundsSinceLastRest++
+			for _, opponent := range cs.Opponents {
+				opponent.RoundsSinceLastRest++
+			}
+			cs.startRound()
+			return
+		}
+		if cs.actorCanAct(cs.TurnIndex) {
+			break
+		}
 	}
+	cs.PlayerTurn = cs.TurnOrder[cs.TurnIndex] == PlayerActor
 }
 
 // ProcessRest handles the rest mechanic when endurance is depleted.
@@ -340,9 +597,12 @@ func ProcessRest(cs *CombatState) {
 	cs.RoundsSinceLastRest = 0
 }
 
-// ProcessEnemyRest handles the rest mechanic when enemy endurance is depleted.
+// ProcessEnemyRest handles the rest mechanic when the acting enemy's
+// endurance is depleted.
 func ProcessEnemyRest(cs *CombatState) {
-	cs.EnemyRoundsSinceLastRest = 0
+	if opponent := cs.ActingOpponent(); opponent != nil {
+		opponent.RoundsSinceLastRest = 0
+	}
 }
 
 // ResolveCombatVictory updates player stats after winning combat.
@@ -351,32 +611,40 @@ func ResolveCombatVictory(player *character.Character) {
 	player.ModifySkill(1)
 }
 
+// ResolveEncounterVictory updates player stats for every enemy slain in
+// the encounter.
+func ResolveEncounterVictory(player *character.Character, cs *CombatState) {
+	for _, opponent := range cs.Opponents {
+		if !opponent.IsAlive() {
+			ResolveCombatVictory(player)
+		}
+	}
+}
+
 // AttemptDeathSave performs a death save and restores player if successful.
 // Returns the roll result and whether the save was successful.
 func AttemptDeathSave(player *character.Character, cs *CombatState, roller dice.Roller) (int, bool) {
 	if cs.DeathSaveUsed {
 		return 0, false
 	}
-	
-	roll, success := ExecuteDeathSave(player.Luck, roller)
+
+	roll, success := ExecuteDeathSave(player.Luck, cs.Ruleset(), roller)
 	cs.DeathSaveUsed = true
-	
+
 	if success {
 		// Restore player to max LP
 		player.SetLP(player.MaximumLP)
-		
-		// Reset combat to beginning (but enemy keeps current LP)
+
+		// Reset combat to beginning (but enemies keep current LP)
 		cs.CurrentRound = 1
 		cs.RoundsSinceLastRest = 0
-		cs.EnemyRoundsSinceLastRest = 0
-		
+		for _, opponent := range cs.Opponents {
+			opponent.RoundsSinceLastRest = 0
+		}
+
 		// Re-roll initiative
-		playerInit, enemyInit, playerFirst := CalculateInitiative(player, cs.Enemy, roller)
-		cs.PlayerInitiative = playerInit
-		cs.EnemyInitiative = enemyInit
-		cs.PlayerFirstStrike = playerFirst
-		cs.PlayerTurn = playerFirst
+		RollInitiative(cs, player, roller)
 	}
-	
+
 	return roll, success
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/benoit/saga-demonspawn/internal/character"
 	"github.com/benoit/saga-demonspawn/internal/items"
+	"github.com/benoit/saga-demonspawn/internal/rules"
 )
 
 // MockRoller implements dice.Roller with fixed results for testing.
@@ -395,7 +396,7 @@ func TestExecuteDeathSave(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			roller := &MockRoller{NextRoll: tt.roll}
-			rollResult, success := ExecuteDeathSave(tt.luck, roller)
+			rollResult, success := ExecuteDeathSave(tt.luck, rules.Default(), roller)
 
 			if success != tt.wantSuccess {
 				t.Errorf("ExecuteDeathSave(%d) success = %v, want %v", tt.luck, success, tt.wantSuccess)
@@ -517,12 +518,93 @@ func TestStartCombat(t *testing.T) {
 		t.Error("StartCombat() should be player's turn")
 	}
 
-	expectedEndurance := player.Stamina / 10
+	expectedEndurance := player.Stamina / 20 // STA ÷ 20 under the default rules
 	if cs.EnduranceLimit != expectedEndurance {
 		t.Errorf("StartCombat() endurance = %d, want %d", cs.EnduranceLimit, expectedEndurance)
 	}
 }
 
+// TestStartEncounterWithRules verifies a fight is played by its ruleset.
+func TestStartEncounterWithRules(t *testing.T) {
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	player.EquipWeapon(&items.WeaponSword)
+	enemy, _ := NewEnemy("Goblin", 40, 35, 40, 25, 20, 0, 300, 300, 5, 0, false)
+
+	original := rules.Original()
+	original.EnduranceDivisor = 10
+	cs := StartEncounterWithRules(player, []*Enemy{enemy}, original, &MockRoller{NextRoll: 8})
+
+	if cs.EnduranceLimit != 7 || cs.Opponents[0].EnduranceLimit != 4 {
+		t.Errorf("endurance = %d and %d, want 7 and 4 (STA ÷ 10)", cs.EnduranceLimit, cs.Opponents[0].EnduranceLimit)
+	}
+
+	// (8×10) + (6×5) + 10 = 120
+	result := Attack(cs, player, &MockRoller{NextRoll: 8})
+	if result.FinalDamage != 120 {
+		t.Errorf("Attack() damage = %d, want 120 under the original rules", result.FinalDamage)
+	}
+
+	// A fight saved before rulesets existed plays by the default rules
+	cs.Rules = rules.Ruleset{}
+	if cs.Ruleset() != rules.Default() {
+		t.Errorf("Ruleset() = %+v, want the default rules", cs.Ruleset())
+	}
+}
+
+// TestCombatStateClone verifies that a cloned combat shares no mutable state.
+func TestCombatStateClone(t *testing.T) {
+	enemy, _ := NewEnemy("Goblin", 40, 35, 30, 25, 20, 0, 150, 150, 5, 0, false)
+	cs := NewCombatState(enemy, 3)
+	cs.AddLogEntry("Combat begins")
+
+	clone := cs.Clone()
+	clone.Enemy.CurrentLP = 20
+	clone.AddLogEntry("Goblin is wounded")
+	clone.CurrentRound = 4
+
+	if cs.Enemy.CurrentLP != 150 {
+		t.Errorf("original enemy LP = %d, want 150", cs.Enemy.CurrentLP)
+	}
+	if len(cs.Events) != 1 {
+		t.Errorf("original log has %d events, want 1", len(cs.Events))
+	}
+	if cs.CurrentRound != 1 {
+		t.Errorf("original round = %d, want 1", cs.CurrentRound)
+	}
+}
+
+// TestResumeCombat verifies a lost fight restarts with the enemy's wounds.
+func TestResumeCombat(t *testing.T) {
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	enemy, _ := NewEnemy("Goblin", 40, 35, 30, 25, 20, 0, 150, 150, 5, 0, false)
+	cs := NewCombatState(enemy, 1)
+	cs.TurnOrder = []int{0, PlayerActor}
+	cs.PlayerFirstStrike = false
+	cs.PlayerTurn = true
+	cs.DeathSaveUsed = true
+	cs.IsActive = false
+	cs.RoundsSinceLastRest = 3
+	enemy.CurrentLP = 35
+
+	ResumeCombat(player, cs)
+
+	if !cs.IsActive || cs.DeathSaveUsed {
+		t.Errorf("ResumeCombat() active = %v, death save used = %v; want true, false", cs.IsActive, cs.DeathSaveUsed)
+	}
+	if cs.EnduranceLimit != player.Stamina/20 {
+		t.Errorf("ResumeCombat() endurance = %d, want %d", cs.EnduranceLimit, player.Stamina/20)
+	}
+	if cs.RoundsSinceLastRest != 0 {
+		t.Errorf("ResumeCombat() rounds since rest = %d, want 0", cs.RoundsSinceLastRest)
+	}
+	if cs.PlayerTurn {
+		t.Error("ResumeCombat() should give the first turn to the initiative winner")
+	}
+	if cs.Enemy.CurrentLP != 35 {
+		t.Errorf("ResumeCombat() enemy LP = %d, want 35", cs.Enemy.CurrentLP)
+	}
+}
+
 func TestNextTurn(t *testing.T) {
 	enemy, _ := NewEnemy("Goblin", 40, 35, 30, 25, 20, 0, 150, 150, 5, 0, false)
 	cs := NewCombatState(enemy, 3)
@@ -644,3 +726,113 @@ func TestResolveCombatVictory(t *testing.T) {
 		t.Errorf("ResolveCombatVictory() skill = %d, want %d", player.Skill, initialSkill+1)
 	}
 }
+
+// encounterEnemies builds three enemies with the given initiative bonuses.
+func encounterEnemies() []*Enemy {
+	orc, _ := NewEnemy("Orc", 50, 10, 40, 10, 10, 0, 100, 100, 5, 0, false)
+	wolf, _ := NewEnemy("Wolf", 30, 40, 30, 30, 30, 0, 60, 60, 0, 0, false)
+	bat, _ := NewEnemy("Bat", 10, 20, 20, 20, 20, 0, 20, 20, 0, 0, false)
+	return []*Enemy{orc, wolf, bat}
+}
+
+// TestStartEncounter verifies that every combatant acts in initiative order.
+func TestStartEncounter(t *testing.T) {
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56) // SPD+CRG+LCK = 184
+	// Player 7 → 191; Orc 7 → 37; Wolf 12 → 112; Bat 2 → 62
+	roller := &MockRoller{Rolls: []int{7, 7, 12, 2}}
+
+	cs := StartEncounter(player, encounterEnemies(), roller)
+
+	want := []int{PlayerActor, 1, 2, 0}
+	if len(cs.TurnOrder) != len(want) {
+		t.Fatalf("TurnOrder = %v, want %v", cs.TurnOrder, want)
+	}
+	for i := range want {
+		if cs.TurnOrder[i] != want[i] {
+			t.Fatalf("TurnOrder = %v, want %v", cs.TurnOrder, want)
+		}
+	}
+	if !cs.PlayerTurn || !cs.PlayerFirstStrike {
+		t.Error("player with the highest initiative should act first")
+	}
+	if cs.Opponents[1].Initiative != 112 {
+		t.Errorf("Wolf initiative = %d, want 112", cs.Opponents[1].Initiative)
+	}
+	if cs.Target != 0 || cs.Enemy.Name != "Orc" {
+		t.Errorf("initial target = %d (%s), want the first enemy", cs.Target, cs.Enemy.Name)
+	}
+}
+
+// TestEncounterTurns verifies turn order, skipping dead enemies and round progression.
+func TestEncounterTurns(t *testing.T) {
+	cs := NewEncounterState(encounterEnemies(), 5)
+	cs.TurnOrder = []int{1, PlayerActor, 0, 2}
+	cs.startRound()
+
+	if cs.PlayerTurn || cs.ActingEnemy().Name != "Wolf" {
+		t.Fatalf("first actor should be the Wolf, got player turn = %v", cs.PlayerTurn)
+	}
+
+	NextTurn(cs)
+	if !cs.PlayerTurn {
+		t.Fatal("player should act second")
+	}
+
+	cs.Opponents[0].Enemy.CurrentLP = 0 // Orc slain during the player's turn
+	NextTurn(cs)
+	if cs.PlayerTurn || cs.ActingEnemy().Name != "Bat" {
+		t.Errorf("dead Orc should be skipped, acting enemy = %s", cs.ActingEnemy().Name)
+	}
+	if cs.Enemy.Name == "Orc" {
+		t.Error("target should move off a dead enemy")
+	}
+
+	NextTurn(cs)
+	if cs.CurrentRound != 2 || cs.ActingEnemy().Name != "Wolf" {
+		t.Errorf("round %d acting %s, want round 2 starting with Wolf", cs.CurrentRound, cs.ActingEnemy().Name)
+	}
+	if cs.RoundsSinceLastRest != 1 || cs.Opponents[1].RoundsSinceLastRest != 1 {
+		t.Error("endurance trackers should advance once per round")
+	}
+
+	ProcessEnemyRest(cs)
+	if cs.Opponents[1].RoundsSinceLastRest != 0 || cs.Opponents[2].RoundsSinceLastRest != 1 {
+		t.Error("ProcessEnemyRest() should only rest the acting enemy")
+	}
+}
+
+// TestEncounterTargeting verifies target selection and victory over all enemies.
+func TestEncounterTargeting(t *testing.T) {
+	cs := NewEncounterState(encounterEnemies(), 5)
+
+	if err := cs.SetTarget(2); err != nil || cs.Enemy.Name != "Bat" {
+		t.Fatalf("SetTarget(2) = %v, target %s; want Bat", err, cs.Enemy.Name)
+	}
+	if err := cs.SetTarget(3); err == nil {
+		t.Error("SetTarget() expected error for a missing opponent")
+	}
+
+	cs.Opponents[0].Enemy.CurrentLP = 0
+	if err := cs.SetTarget(0); err == nil {
+		t.Error("SetTarget() expected error for a dead opponent")
+	}
+	cs.CycleTarget(1)
+	if cs.Enemy.Name != "Wolf" {
+		t.Errorf("CycleTarget(1) from Bat = %s, want Wolf (Orc is dead)", cs.Enemy.Name)
+	}
+
+	cs.Opponents[1].Enemy.CurrentLP = 0
+	if CheckVictory(cs) {
+		t.Error("CheckVictory() should be false while the Bat lives")
+	}
+	cs.Opponents[2].Enemy.CurrentLP = 0
+	if !CheckVictory(cs) {
+		t.Error("CheckVictory() should be true once every enemy is dead")
+	}
+
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	ResolveEncounterVictory(player, cs)
+	if player.EnemiesDefeated != 3 || player.Skill != 3 {
+		t.Errorf("after encounter: defeated = %d, skill = %d; want 3 and 3", player.EnemiesDefeated, player.Skill)
+	}
+}
@@ -0,0 +1,251 @@
+package combat
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PlayerName is the actor of the events the player causes.
+const PlayerName = "Fire*Wolf"
+
+// EventType identifies what happened in a fight.
+type EventType string
+
+// Combat event types.
+const (
+	EventCombatStarted   EventType = "combat_started"    // Initiative was rolled and the fight began
+	EventAttackRolled    EventType = "attack_rolled"     // A to-hit roll was made
+	EventDamageDealt     EventType = "damage_dealt"      // A hit or spell took LP from its target
+	EventItemUsed        EventType = "item_used"         // A special item took effect
+	EventRestTaken       EventType = "rest_taken"        // A combatant recovered its endurance
+	EventDeathSaveRolled EventType = "death_save_rolled" // The player rolled the death save
+	EventSpellApplied    EventType = "spell_applied"     // The player cast a spell
+	EventCombatEnded     EventType = "combat_ended"      // The fight was won, lost or left
+	EventNote            EventType = "note"              // A message with no game effect
+)
+
+// How a fight ended, for EventCombatEnded.
+const (
+	OutcomeVictory = "victory" // Every enemy died
+	OutcomeDefeat  = "defeat"  // The player died
+	OutcomeDrained = "drained" // Doombringer's blood price took the player's last LP
+	OutcomeFled    = "fled"    // The player ran away
+	OutcomeEscaped = "escaped" // A spell took the player out of the fight
+)
+
+// What a special item did, for EventItemUsed.
+const (
+	EffectBloodPrice  = "blood_price"  // Doombringer took LP before an attack
+	EffectSoulThirst  = "soul_thirst"  // Doombringer healed the damage dealt
+	EffectDamageBonus = "damage_bonus" // The Orb doubled damage against a Demonspawn
+	EffectHeal        = "heal"         // The Healing Stone restored LP
+	EffectThrow       = "throw"        // The Orb was thrown
+)
+
+// Term is a named number: a damage modifier, an initiative score or a
+// combatant's LP.
+type Term struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+}
+
+// Event is one thing that happened in a fight. Which fields are set
+// depends on the type; the combat log is rendered from the events.
+type Event struct {
+	Type        EventType `json:"type"`
+	Round       int       `json:"round"`                 // Round the event happened in
+	Actor       string    `json:"actor,omitempty"`       // PlayerName or the acting enemy's name
+	Target      string    `json:"target,omitempty"`      // Who the action was aimed at
+	Opponent    int       `json:"opponent,omitempty"`    // Enemy acting or aimed at, numbered from 1 in setup order
+	Rolls       []int     `json:"rolls,omitempty"`       // Dice totals rolled
+	Requirement int       `json:"requirement,omitempty"` // Score the roll needed
+	Success     bool      `json:"success,omitempty"`     // Hit, save survived, spell worked
+	Amount      int       `json:"amount,omitempty"`      // Damage dealt, LP healed or lost, POW spent
+	Modifiers   []Term    `json:"modifiers,omitempty"`   // Terms adding up to the damage dealt
+	LP          int       `json:"lp,omitempty"`          // LP left to whoever the event affected
+	MaximumLP   int       `json:"maximum_lp,omitempty"`  // Their maximum LP, when it is shown
+	Item        string    `json:"item,omitempty"`        // Item used
+	Effect      string    `json:"effect,omitempty"`      // What the item did
+	Charges     int       `json:"charges,omitempty"`     // Charges left in the item
+	Spell       string    `json:"spell,omitempty"`       // Spell cast, or that dealt the damage or ended the fight
+	Outcome     string    `json:"outcome,omitempty"`     // How the fight ended
+	Initiative  []Term    `json:"initiative,omitempty"`  // Everyone's initiative, in turn order
+	StartingLP  []Term    `json:"starting_lp,omitempty"` // Everyone's LP when the fight began
+	Message     string    `json:"message,omitempty"`     // Text of a note
+}
+
+// Record adds an event to the fight, in the current round.
+func (cs *CombatState) Record(event Event) {
+	event.Round = cs.CurrentRound
+	cs.Events = append(cs.Events, event)
+}
+
+// Log renders the events of the fight as the human-readable combat log.
+func (cs *CombatState) Log() []string {
+	lines := []string{}
+	for _, event := range cs.Events {
+		lines = append(lines, event.Lines()...)
+	}
+	return lines
+}
+
+// Lines renders the event as combat log lines.
+func (e Event) Lines() []string {
+	r := e.Round
+	switch e.Type {
+	case EventCombatStarted:
+		lines := []string{fmt.Sprintf("Combat begins against %s!", e.Target), initiativeLine(e.Initiative)}
+		if e.Actor == PlayerName {
+			return append(lines, "You strike first!")
+		}
+		return append(lines, fmt.Sprintf("%s strikes first!", e.Actor))
+
+	case EventAttackRolled:
+		who, verdict := "You", "MISS!"
+		if e.Actor != PlayerName {
+			who = e.Actor
+		}
+		if e.Success {
+			verdict = "HIT!"
+		}
+		return []string{fmt.Sprintf("[R%d] %s rolled %d (need %d+) - %s", r, who, e.roll(), e.Requirement, verdict)}
+
+	case EventDamageDealt:
+		switch {
+		case e.Spell != "":
+			lines := []string{fmt.Sprintf("[R%d] %s deals %d damage to %s (%d LP remaining)", r, e.Spell, e.Amount, e.Target, e.LP)}
+			if e.LP <= 0 {
+				lines = append(lines, fmt.Sprintf("[R%d] %s is defeated!", r, e.Target))
+			}
+			return lines
+		case e.Actor == PlayerName:
+			return []string{
+				fmt.Sprintf("[R%d] Damage: %s = %d", r, sumLine(e.Modifiers), e.Amount),
+				fmt.Sprintf("[R%d] %s takes %d damage (%d LP remaining)", r, e.Target, e.Amount, e.LP),
+			}
+		default:
+			return []string{fmt.Sprintf("[R%d] %s deals %d damage (%d LP remaining)", r, e.Actor, e.Amount, e.LP)}
+		}
+
+	case EventItemUsed:
+		return e.itemLines()
+
+	case EventRestTaken:
+		if e.Actor == PlayerName {
+			return []string{fmt.Sprintf("[R%d] Rested! Endurance restored.", r)}
+		}
+		return []string{fmt.Sprintf("[R%d] %s rested! Endurance restored.", r, e.Actor)}
+
+	case EventDeathSaveRolled:
+		if !e.Success {
+			return []string{fmt.Sprintf("[Death Save] Rolled %d vs Luck %d - FAILED!", e.Amount, e.Requirement)}
+		}
+		return []string{
+			fmt.Sprintf("[Death Save] Rolled %d vs Luck %d - SUCCESS!", e.Amount, e.Requirement),
+			fmt.Sprintf("[Death Save] Restored to %d LP. Combat restarted!", e.LP),
+			initiativeLine(e.Initiative),
+		}
+
+	case EventSpellApplied:
+		if !e.Success {
+			return []string{fmt.Sprintf("[R%d] %s fizzles! (rolled %d, needed %d+) -%d POW", r, e.Spell, e.roll(), e.Requirement, e.Amount)}
+		}
+		return []string{fmt.Sprintf("[R%d] You cast %s! (rolled %d, needed %d+) -%d POW", r, e.Spell, e.roll(), e.Requirement, e.Amount)}
+
+	case EventCombatEnded:
+		switch {
+		case e.Spell != "":
+			return []string{fmt.Sprintf("Combat ended via %s (%s)!", e.Spell, e.Outcome)}
+		case e.Outcome == OutcomeVictory:
+			return []string{fmt.Sprintf("[Victory] %s defeated!", e.Target)}
+		case e.Outcome == OutcomeDrained:
+			return []string{"[Defeat] Doombringer has drained your life!"}
+		case e.Outcome == OutcomeFled:
+			return []string{"[Fled] You fled from combat!"}
+		default:
+			return []string{"[Defeat] You have been defeated!"}
+		}
+	}
+	return []string{e.Message}
+}
+
+// itemLines renders an EventItemUsed.
+func (e Event) itemLines() []string {
+	r := e.Round
+	currentLP := fmt.Sprintf("[R%d] Current LP: %d/%d", r, e.LP, e.MaximumLP)
+	switch e.Effect {
+	case EffectBloodPrice:
+		return []string{fmt.Sprintf("[R%d] Doombringer thirsts for blood... -%d LP", r, e.Amount), currentLP}
+	case EffectSoulThirst:
+		switch {
+		case !e.Success:
+			return []string{fmt.Sprintf("[R%d] No healing from Doombringer on miss", r)}
+		case e.Amount > 0:
+			return []string{fmt.Sprintf("[R%d] Doombringer feeds on pain... +%d LP healed!", r, e.Amount), currentLP}
+		default:
+			return []string{fmt.Sprintf("[R%d] Doombringer feeds on pain... (already at maximum LP)", r)}
+		}
+	case EffectDamageBonus:
+		return []string{fmt.Sprintf("[R%d] The Orb pulses with power! Damage doubled: %d → %d", r, e.Amount, e.Amount*2)}
+	case EffectHeal:
+		return []string{
+			fmt.Sprintf("[R%d] You invoke the Healing Stone... (rolled %d)", r, e.roll()),
+			fmt.Sprintf("[R%d] +%d LP restored! (Charges: %d/50)", r, e.Amount, e.Charges),
+			currentLP,
+		}
+	case EffectThrow:
+		lines := []string{
+			fmt.Sprintf("[R%d] You hurl The Orb at %s!", r, e.Target),
+			fmt.Sprintf("[R%d] Rolled %d (need %d+)", r, e.roll(), e.Requirement),
+		}
+		switch {
+		case e.Amount == 0:
+			lines = append(lines, "[The Orb] The Orb has no effect on this creature!")
+		case e.Success:
+			lines = append(lines, "[The Orb] The Orb strikes true! The Demonspawn is annihilated in brilliant light!")
+		default:
+			lines = append(lines, fmt.Sprintf("[The Orb] The Orb's light sears the Demonspawn! %d damage dealt! (%d LP remaining)", e.Amount, e.LP))
+		}
+		return append(lines, "[The Orb] The Orb explodes and is destroyed!")
+	}
+	return []string{fmt.Sprintf("[R%d] %s used", r, e.Item)}
+}
+
+// roll returns the first dice total of the event.
+func (e Event) roll() int {
+	if len(e.Rolls) == 0 {
+		return 0
+	}
+	return e.Rolls[0]
+}
+
+// initiativeLine renders everyone's initiative, e.g.
+// "[Initiative] Player: 191, Rat: 37".
+func initiativeLine(scores []Term) string {
+	parts := make([]string, len(scores))
+	for i, score := range scores {
+		name := score.Name
+		if name == PlayerName {
+			name = "Player"
+		}
+		parts[i] = fmt.Sprintf("%s: %d", name, score.Value)
+	}
+	return "[Initiative] " + strings.Join(parts, ", ")
+}
+
+// sumLine renders damage terms as a sum, e.g.
+// "45 (9×5) + 30 (STR) - 5 (Armor)".
+func sumLine(terms []Term) string {
+	var s strings.Builder
+	for i, term := range terms {
+		switch {
+		case i == 0:
+			fmt.Fprintf(&s, "%d (%s)", term.Value, term.Name)
+		case term.Value < 0:
+			fmt.Fprintf(&s, " - %d (%s)", -term.Value, term.Name)
+		default:
+			fmt.Fprintf(&s, " + %d (%s)", term.Value, term.Name)
+		}
+	}
+	return s.String()
+}
@@ -0,0 +1,82 @@
+package combat
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/internal/items"
+)
+
+// TestAttackEvents verifies the events recorded for a Doombringer hit and
+// the log rendered from them.
+func TestAttackEvents(t *testing.T) {
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	player.EquipWeapon(&items.WeaponDoombringer)
+	player.SetLP(300)
+	enemy, _ := NewEnemy("Goblin", 40, 35, 30, 25, 20, 0, 150, 150, 5, 5, false)
+	cs := NewCombatState(enemy, 3)
+
+	cs.LogPlayerAttack(player, Attack(cs, player, &MockRoller{NextRoll: 9}))
+
+	types := []EventType{}
+	for _, event := range cs.Events {
+		types = append(types, event.Type)
+	}
+	wantTypes := []EventType{EventItemUsed, EventAttackRolled, EventDamageDealt, EventItemUsed}
+	if !reflect.DeepEqual(types, wantTypes) {
+		t.Fatalf("event types = %v, want %v", types, wantTypes)
+	}
+
+	damage := cs.Events[2]
+	wantTerms := []Term{{"9×5", 45}, {"STR", 30}, {"Weapon", 20}, {"Armor", -5}}
+	if !reflect.DeepEqual(damage.Modifiers, wantTerms) || damage.Amount != 90 || damage.LP != 60 {
+		t.Errorf("damage event = %+v, want 90 damage from %v leaving 60 LP", damage, wantTerms)
+	}
+	if attack := cs.Events[1]; attack.Round != 1 || attack.Actor != PlayerName || attack.Requirement != 6 || !attack.Success {
+		t.Errorf("attack event = %+v, want a round 1 hit by the player needing 6", attack)
+	}
+
+	wantLog := []string{
+		"[R1] Doombringer thirsts for blood... -10 LP",
+		"[R1] Current LP: 290/416",
+		"[R1] You rolled 9 (need 6+) - HIT!",
+		"[R1] Damage: 45 (9×5) + 30 (STR) + 20 (Weapon) - 5 (Armor) = 90",
+		"[R1] Goblin takes 90 damage (60 LP remaining)",
+		"[R1] Doombringer feeds on pain... +90 LP healed!",
+		"[R1] Current LP: 380/416",
+	}
+	if log := cs.Log(); !reflect.DeepEqual(log, wantLog) {
+		t.Errorf("Log() = %q\nwant %q", log, wantLog)
+	}
+}
+
+// TestEventsRoundTrip verifies events survive JSON and render the same.
+func TestEventsRoundTrip(t *testing.T) {
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	rat, _ := NewEnemy("Rat", 10, 10, 10, 10, 10, 0, 20, 20, 0, 0, false)
+	roller := &MockRoller{NextRoll: 9}
+	cs := StartCombat(player, rat, roller)
+	cs.LogStart(player)
+	AutoResolve(cs, player, roller, FightOptions{Narrate: true})
+	cs.LogVictory(player)
+
+	data, err := json.Marshal(cs.Events)
+	if err != nil {
+		t.Fatalf("Marshal() unexpected error: %v", err)
+	}
+	var events []Event
+	if err := json.Unmarshal(data, &events); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %v", err)
+	}
+	restored := &CombatState{Events: events}
+	if !reflect.DeepEqual(restored.Log(), cs.Log()) {
+		t.Errorf("restored log = %q, want %q", restored.Log(), cs.Log())
+	}
+
+	last := events[len(events)-2]
+	if last.Type != EventCombatEnded || last.Outcome != OutcomeVictory || last.Target != "Rat" {
+		t.Errorf("ending event = %+v, want a victory over the Rat", last)
+	}
+}
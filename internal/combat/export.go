@@ -0,0 +1,355 @@
+package combat
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/benoit/saga-demonspawn/internal/safefile"
+)
+
+// Formats a fight can be exported to.
+const (
+	FormatMarkdown = "markdown"
+	FormatCSV      = "csv"
+	FormatJSON     = "json"
+)
+
+// ExportFormats lists the export formats, in menu order.
+var ExportFormats = []string{FormatMarkdown, FormatCSV, FormatJSON}
+
+// exportExtensions maps each export format to its file extension.
+var exportExtensions = map[string]string{
+	FormatMarkdown: "md",
+	FormatCSV:      "csv",
+	FormatJSON:     "json",
+}
+
+// ParseExportFormat accepts an export format or its file extension,
+// e.g. "markdown" or "md".
+func ParseExportFormat(name string) (string, error) {
+	name = strings.ToLower(name)
+	for format, extension := range exportExtensions {
+		if name == format || name == extension {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown export format %q (want markdown, csv or json)", name)
+}
+
+// ExportDir returns where exported fights are written in the save directory.
+func ExportDir(directory string) string {
+	return filepath.Join(directory, "exports")
+}
+
+// ExportRow is one event of an exported fight, with everyone's LP after it.
+type ExportRow struct {
+	Event
+	Text     []string       `json:"text"`     // The event's combat log lines
+	Standing map[string]int `json:"lp_after"` // LP of every combatant known so far
+}
+
+// CombatExport is a fight laid out round by round for export.
+type CombatExport struct {
+	Enemies    []string    `json:"enemies"`
+	Ruleset    string      `json:"ruleset"`
+	Outcome    string      `json:"outcome"`    // Outcome of the ending event, or "unfinished"
+	Rounds     int         `json:"rounds"`     // Rounds fought, including those before a death save
+	Combatants []string    `json:"combatants"` // Fire*Wolf, then each enemy, numbered if names repeat
+	Rows       []ExportRow `json:"events"`
+}
+
+// Export lays out the fight's events for export, following every
+// combatant's LP from the start of the fight.
+func (cs *CombatState) Export() CombatExport {
+	export := CombatExport{
+		Ruleset:    cs.Ruleset().Name,
+		Outcome:    "unfinished",
+		Combatants: []string{PlayerName},
+		Rows:       []ExportRow{},
+	}
+	for _, opponent := range cs.Opponents {
+		export.Enemies = append(export.Enemies, opponent.Enemy.Name)
+	}
+	export.Combatants = append(export.Combatants, opponentLabels(cs.Opponents)...)
+
+	standing := map[string]int{}
+	for _, event := range cs.Events {
+		for i, lp := range event.StartingLP {
+			if i < len(export.Combatants) {
+				standing[export.Combatants[i]] = lp.Value
+			}
+		}
+		if name, ok := event.affected(); ok {
+			standing[cs.exportLabel(event, name, export.Combatants)] = event.LP
+		}
+		if event.Type == EventCombatEnded {
+			export.Outcome = event.Outcome
+		}
+
+		row := ExportRow{Event: event, Text: event.Lines(), Standing: map[string]int{}}
+		for name, lp := range standing {
+			row.Standing[name] = lp
+		}
+		export.Rows = append(export.Rows, row)
+	}
+	export.Rounds = cs.roundsFought()
+	return export
+}
+
+// opponentLabels names each opponent for the export columns, numbering
+// enemies that share a name, e.g. "Goblin #1" and "Goblin #2".
+func opponentLabels(opponents []*Opponent) []string {
+	count := map[string]int{}
+	for _, opponent := range opponents {
+		count[opponent.Enemy.Name]++
+	}
+	labels := make([]string, len(opponents))
+	seen := map[string]int{}
+	for i, opponent := range opponents {
+		name := opponent.Enemy.Name
+		labels[i] = name
+		if count[name] > 1 {
+			seen[name]++
+			labels[i] = fmt.Sprintf("%s #%d", name, seen[name])
+		}
+	}
+	return labels
+}
+
+// exportLabel returns the column of the combatant an event names: Fire*Wolf
+// or the opponent the event concerns. Events saved before they carried the
+// opponent's number fall back to the first enemy of that name.
+func (cs *CombatState) exportLabel(event Event, name string, combatants []string) string {
+	if name == PlayerName {
+		return PlayerName
+	}
+	if event.Opponent > 0 && event.Opponent < len(combatants) {
+		return combatants[event.Opponent]
+	}
+	for i, opponent := range cs.Opponents {
+		if opponent.Enemy.Name == name && i+1 < len(combatants) {
+			return combatants[i+1]
+		}
+	}
+	return name
+}
+
+// roundsFought returns the rounds the events cover, adding up the rounds
+// fought before each death save restarted the fight at round 1.
+func (cs *CombatState) roundsFought() int {
+	rounds, lastRound := 0, 0
+	for _, event := range cs.Events {
+		if event.Round < lastRound {
+			rounds += lastRound
+		}
+		lastRound = event.Round
+	}
+	return rounds + lastRound
+}
+
+// affected returns whose LP the event left at event.LP, if anyone's.
+func (e Event) affected() (string, bool) {
+	switch e.Type {
+	case EventDamageDealt:
+		return e.Target, true
+	case EventDeathSaveRolled:
+		return e.Actor, true
+	case EventItemUsed:
+		switch e.Effect {
+		case EffectThrow:
+			return e.Target, true
+		case EffectBloodPrice, EffectHeal:
+			return e.Actor, true
+		case EffectSoulThirst:
+			return e.Actor, e.Success && e.Amount > 0
+		}
+	}
+	return "", false
+}
+
+// Write writes the export in the given format.
+func (e CombatExport) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatMarkdown:
+		_, err := io.WriteString(w, e.markdown())
+		return err
+	case FormatCSV:
+		return e.writeCSV(w)
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(e)
+	}
+	return fmt.Errorf("unknown export format %q", format)
+}
+
+// markdown renders the export as a Markdown document with a table per round.
+func (e CombatExport) markdown() string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "# %s vs %s\n\n", PlayerName, strings.Join(e.Enemies, ", "))
+	fmt.Fprintf(&s, "- Ruleset: %s\n- Outcome: %s\n- Rounds: %d\n", e.Ruleset, e.Outcome, e.Rounds)
+
+	header := "| Event | Roll | Need | Damage |"
+	rule := "|---|---|---|---|"
+	for _, name := range e.Combatants {
+		header += " " + name + " LP |"
+		rule += "---|"
+	}
+
+	round := -1
+	for _, row := range e.Rows {
+		if row.Round != round {
+			round = row.Round
+			fmt.Fprintf(&s, "\n## Round %d\n\n%s\n%s\n", round, header, rule)
+		}
+		damage := ""
+		if row.Type == EventDamageDealt {
+			damage = strconv.Itoa(row.Amount)
+		}
+		text := make([]string, len(row.Text))
+		for i, line := range row.Text {
+			line = strings.TrimPrefix(line, fmt.Sprintf("[R%d] ", row.Round))
+			text[i] = strings.ReplaceAll(line, "|", "\\|")
+		}
+		fmt.Fprintf(&s, "| %s | %s | %s | %s |", strings.Join(text, "<br>"), joinInts(row.Rolls, " "), requirement(row.Event), damage)
+		for _, name := range e.Combatants {
+			fmt.Fprintf(&s, " %s |", standingCell(row.Standing, name))
+		}
+		s.WriteString("\n")
+	}
+	return s.String()
+}
+
+// writeCSV writes the export as a CSV table, one event per line.
+func (e CombatExport) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := []string{"round", "type", "actor", "target", "rolls", "requirement", "success",
+		"amount", "modifiers", "item", "effect", "spell", "outcome"}
+	for _, name := range e.Combatants {
+		header = append(header, name+" LP")
+	}
+	header = append(header, "log")
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range e.Rows {
+		modifiers := make([]string, len(row.Modifiers))
+		for i, term := range row.Modifiers {
+			modifiers[i] = fmt.Sprintf("%s=%d", term.Name, term.Value)
+		}
+		amount := ""
+		if row.Amount != 0 {
+			amount = strconv.Itoa(row.Amount)
+		}
+		record := []string{
+			strconv.Itoa(row.Round), string(row.Type), row.Actor, row.Target,
+			joinInts(row.Rolls, " "), requirement(row.Event), strconv.FormatBool(row.Success),
+			amount, strings.Join(modifiers, "; "), row.Item, row.Effect, row.Spell, row.Outcome,
+		}
+		for _, name := range e.Combatants {
+			record = append(record, standingCell(row.Standing, name))
+		}
+		record = append(record, strings.Join(row.Text, " / "))
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteExport writes the fight in the given format to a new file in the
+// export directory of the save directory, and returns its path.
+func WriteExport(cs *CombatState, directory, format string, now time.Time) (string, error) {
+	extension, ok := exportExtensions[format]
+	if !ok {
+		return "", fmt.Errorf("unknown export format %q", format)
+	}
+	var data strings.Builder
+	if err := cs.Export().Write(&data, format); err != nil {
+		return "", fmt.Errorf("failed to export combat: %w", err)
+	}
+
+	dir := ExportDir(directory)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+	base := fmt.Sprintf("combat_%s_%s", exportSlug(EnemyNames(cs.Opponents)), now.Format("2006-01-02_15-04-05"))
+	path := filepath.Join(dir, base+"."+extension)
+	for n := 2; ; n++ {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			break
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s_%d.%s", base, n, extension))
+	}
+
+	if err := safefile.WriteFile(path, []byte(data.String()), 0644); err != nil {
+		return "", fmt.Errorf("failed to write export: %w", err)
+	}
+	return path, nil
+}
+
+// exportSlug turns enemy names into a file name part, e.g. "orc-and-wolf".
+func exportSlug(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "-"):
+			b.WriteByte('-')
+		}
+	}
+	slug := strings.Trim(b.String(), "-")
+	if len(slug) > 40 {
+		slug = strings.Trim(slug[:40], "-")
+	}
+	if slug == "" {
+		slug = "fight"
+	}
+	return slug
+}
+
+// requirement returns the score an event's roll needed, or "" if none.
+func requirement(e Event) string {
+	if e.Requirement == 0 {
+		return ""
+	}
+	return strconv.Itoa(e.Requirement)
+}
+
+// standingCell returns a combatant's LP, or "" before it is known.
+func standingCell(standing map[string]int, name string) string {
+	lp, ok := standing[name]
+	if !ok {
+		return ""
+	}
+	return strconv.Itoa(lp)
+}
+
+// joinInts joins numbers with a separator.
+func joinInts(values []int, sep string) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, sep)
+}
+
+// contains returns true if the list holds the value.
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
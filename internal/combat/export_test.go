@@ -0,0 +1,149 @@
+package combat
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+)
+
+// exportedFight returns a narrated one-round victory over a Rat.
+func exportedFight() *CombatState {
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	rat, _ := NewEnemy("Rat", 10, 10, 10, 10, 10, 0, 20, 20, 0, 0, false)
+	roller := &MockRoller{NextRoll: 9}
+	cs := StartCombat(player, rat, roller)
+	cs.LogStart(player)
+	AutoResolve(cs, player, roller, FightOptions{Narrate: true})
+	ResolveEncounterVictory(player, cs)
+	cs.LogVictory(player)
+	return cs
+}
+
+// TestExport verifies the LP followed through the fight and its outcome.
+func TestExport(t *testing.T) {
+	export := exportedFight().Export()
+
+	if export.Outcome != OutcomeVictory || export.Rounds != 1 || export.Ruleset != "Revised" {
+		t.Errorf("export = %s in %d rounds by %s rules, want a 1-round victory by Revised rules",
+			export.Outcome, export.Rounds, export.Ruleset)
+	}
+	if len(export.Combatants) != 2 || export.Combatants[1] != "Rat" {
+		t.Errorf("Combatants = %v, want Fire*Wolf and Rat", export.Combatants)
+	}
+	first, damage := export.Rows[0], export.Rows[2]
+	if first.Standing["Rat"] != 20 || first.Standing[PlayerName] != 416 {
+		t.Errorf("starting LP = %v, want the Rat at 20 and Fire*Wolf at 416", first.Standing)
+	}
+	if damage.Type != EventDamageDealt || damage.Standing["Rat"] != 0 {
+		t.Errorf("row 3 = %+v, want the Rat's death", damage)
+	}
+}
+
+// TestExportSameNames verifies enemies sharing a name get a column each.
+func TestExportSameNames(t *testing.T) {
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	first, _ := NewEnemy("Goblin", 10, 10, 10, 10, 10, 0, 20, 20, 0, 0, false)
+	second, _ := NewEnemy("Goblin", 10, 10, 10, 10, 10, 0, 30, 30, 0, 0, false)
+	roller := &MockRoller{NextRoll: 9}
+	cs := StartEncounter(player, []*Enemy{first, second}, roller)
+	cs.LogStart(player)
+	AutoResolve(cs, player, roller, FightOptions{Narrate: true})
+
+	export := cs.Export()
+	want := []string{PlayerName, "Goblin #1", "Goblin #2"}
+	if strings.Join(export.Combatants, ",") != strings.Join(want, ",") {
+		t.Fatalf("Combatants = %v, want %v", export.Combatants, want)
+	}
+	if start := export.Rows[0].Standing; start["Goblin #1"] != 20 || start["Goblin #2"] != 30 {
+		t.Errorf("starting LP = %v, want the Goblins at 20 and 30", start)
+	}
+	for _, row := range export.Rows {
+		if row.Type == EventDamageDealt && row.Actor == PlayerName {
+			if row.Standing["Goblin #1"] != 0 || row.Standing["Goblin #2"] != 30 {
+				t.Errorf("after the first kill LP = %v, want Goblin #1 at 0 and Goblin #2 at 30", row.Standing)
+			}
+			break
+		}
+	}
+	if end := export.Rows[len(export.Rows)-1].Standing; end["Goblin #1"] != 0 || end["Goblin #2"] != 0 {
+		t.Errorf("final LP = %v, want both Goblins at 0", end)
+	}
+}
+
+// TestExportFormats verifies each format renders the whole fight.
+func TestExportFormats(t *testing.T) {
+	export := exportedFight().Export()
+
+	var md strings.Builder
+	if err := export.Write(&md, FormatMarkdown); err != nil {
+		t.Fatalf("Write(markdown) unexpected error: %v", err)
+	}
+	for _, want := range []string{"# Fire*Wolf vs Rat", "## Round 1", "| Fire*Wolf LP | Rat LP |", "Rat takes 85 damage"} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("Markdown export lacks %q:\n%s", want, md.String())
+		}
+	}
+
+	var c strings.Builder
+	if err := export.Write(&c, FormatCSV); err != nil {
+		t.Fatalf("Write(csv) unexpected error: %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(c.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("CSV export does not parse: %v", err)
+	}
+	if len(records) != len(export.Rows)+1 || records[3][7] != "85" || records[3][14] != "0" {
+		t.Errorf("CSV export = %q", records)
+	}
+
+	var j strings.Builder
+	if err := export.Write(&j, FormatJSON); err != nil {
+		t.Fatalf("Write(json) unexpected error: %v", err)
+	}
+	var decoded CombatExport
+	if err := json.Unmarshal([]byte(j.String()), &decoded); err != nil || len(decoded.Rows) != len(export.Rows) {
+		t.Errorf("JSON export decodes to %d rows, %v; want %d", len(decoded.Rows), err, len(export.Rows))
+	}
+
+	if err := export.Write(&j, "xml"); err == nil {
+		t.Error("Write(xml) expected error")
+	}
+}
+
+// TestWriteExport verifies exports go to the save directory without
+// overwriting each other.
+func TestWriteExport(t *testing.T) {
+	dir := t.TempDir()
+	cs := exportedFight()
+	now := time.Date(2026, 10, 16, 21, 5, 0, 0, time.UTC)
+
+	first, err := WriteExport(cs, dir, FormatMarkdown, now)
+	if err != nil {
+		t.Fatalf("WriteExport() unexpected error: %v", err)
+	}
+	if want := filepath.Join(ExportDir(dir), "combat_rat_2026-10-16_21-05-00.md"); first != want {
+		t.Errorf("WriteExport() path = %s, want %s", first, want)
+	}
+	second, err := WriteExport(cs, dir, FormatMarkdown, now)
+	if err != nil || second == first {
+		t.Errorf("second WriteExport() = %s, %v; want a new file", second, err)
+	}
+	if _, err := os.Stat(second); err != nil {
+		t.Errorf("second export missing: %v", err)
+	}
+
+	for _, name := range []string{"md", "CSV", "json"} {
+		if _, err := ParseExportFormat(name); err != nil {
+			t.Errorf("ParseExportFormat(%q) unexpected error: %v", name, err)
+		}
+	}
+	if _, err := ParseExportFormat("pdf"); err == nil {
+		t.Error("ParseExportFormat(pdf) expected error")
+	}
+}
@@ -0,0 +1,88 @@
+package combat
+
+import (
+	"time"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+)
+
+// Encounter returns the finished fight as an entry of the player's
+// encounter journal: the enemies, how it ended, the rounds, LP before and
+// after, the items and spells used and the combat log. It returns false if
+// the fight has not ended.
+func (cs *CombatState) Encounter(player *character.Character, now time.Time) (character.Encounter, bool) {
+	var start, end *Event
+	for i := range cs.Events {
+		switch cs.Events[i].Type {
+		case EventCombatStarted:
+			if start == nil {
+				start = &cs.Events[i]
+			}
+		case EventCombatEnded:
+			end = &cs.Events[i]
+		}
+	}
+	if end == nil {
+		return character.Encounter{}, false
+	}
+
+	// LP at the start of the fight, or now if the start was not recorded
+	startingLP := func(index int, name string, lp int) int {
+		if start != nil && index < len(start.StartingLP) && start.StartingLP[index].Name == name {
+			return start.StartingLP[index].Value
+		}
+		return lp
+	}
+
+	encounter := character.Encounter{
+		Time:     now,
+		Section:  player.CurrentSection,
+		Outcome:  encounterOutcome(*end),
+		Rounds:   cs.roundsFought(),
+		LPBefore: startingLP(0, PlayerName, player.CurrentLP),
+		LPAfter:  player.CurrentLP,
+		Log:      cs.Log(),
+	}
+	for i, opponent := range cs.Opponents {
+		enemy := opponent.Enemy
+		encounter.Enemies = append(encounter.Enemies, character.EnemySnapshot{
+			Name:            enemy.Name,
+			Strength:        enemy.Strength,
+			Speed:           enemy.Speed,
+			Stamina:         enemy.Stamina,
+			Courage:         enemy.Courage,
+			Luck:            enemy.Luck,
+			Skill:           enemy.Skill,
+			MaximumLP:       enemy.MaximumLP,
+			LPBefore:        startingLP(i+1, enemy.Name, enemy.CurrentLP),
+			LPAfter:         enemy.CurrentLP,
+			WeaponBonus:     enemy.WeaponBonus,
+			ArmorProtection: enemy.ArmorProtection,
+			IsDemonspawn:    enemy.IsDemonspawn,
+		})
+	}
+	for _, event := range cs.Events {
+		switch {
+		case event.Type == EventItemUsed && !contains(encounter.Items, event.Item):
+			encounter.Items = append(encounter.Items, event.Item)
+		case event.Type == EventSpellApplied && !contains(encounter.Spells, event.Spell):
+			encounter.Spells = append(encounter.Spells, event.Spell)
+		}
+	}
+	return encounter, true
+}
+
+// encounterOutcome returns the journal outcome of the event ending a fight.
+func encounterOutcome(end Event) string {
+	switch {
+	case end.Spell == "INVISIBILITY":
+		return character.EncounterInvisibility
+	case end.Outcome == OutcomeEscaped:
+		return character.EncounterParalysis
+	case end.Outcome == OutcomeVictory:
+		return character.EncounterVictory
+	case end.Outcome == OutcomeFled:
+		return character.EncounterFled
+	}
+	return character.EncounterDefeat
+}
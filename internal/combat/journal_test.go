@@ -0,0 +1,89 @@
+package combat
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+)
+
+// TestEncounter verifies a won fight becomes a journal entry with the
+// enemy as it started, the LP before and after and the combat log.
+func TestEncounter(t *testing.T) {
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	player.EnterSection(42)
+	player.AcquireHealingStone()
+	player.SetLP(player.MaximumLP - 50)
+	startLP := player.CurrentLP
+	rat, _ := NewEnemy("Rat", 10, 12, 14, 16, 18, 2, 20, 20, 3, 1, false)
+	roller := &MockRoller{NextRoll: 9}
+	cs := StartCombat(player, rat, roller)
+	cs.LogStart(player)
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	if _, ok := cs.Encounter(player, now); ok {
+		t.Fatal("Encounter() returned an entry for a fight still going on")
+	}
+
+	result, _ := UseHealingStone(player, cs.Ruleset(), roller)
+	cs.LogHealingStone(player, result)
+	cs.LogSpell("ARMOUR", 9, 25, true)
+	AutoResolve(cs, player, roller, FightOptions{Narrate: true})
+	cs.LogVictory(player)
+
+	encounter, ok := cs.Encounter(player, now)
+	if !ok {
+		t.Fatal("Encounter() = false; want the finished fight")
+	}
+	if encounter.Outcome != character.EncounterVictory || encounter.Section != 42 || !encounter.Time.Equal(now) {
+		t.Errorf("encounter = %s in section %d at %v; want a victory in section 42 at %v",
+			encounter.Outcome, encounter.Section, encounter.Time, now)
+	}
+	if encounter.LPBefore != startLP || encounter.LPAfter != player.CurrentLP || encounter.Rounds != 1 {
+		t.Errorf("LP %d → %d over %d rounds; want %d → %d over 1", encounter.LPBefore, encounter.LPAfter,
+			encounter.Rounds, startLP, player.CurrentLP)
+	}
+	wantEnemy := character.EnemySnapshot{Name: "Rat", Strength: 10, Speed: 12, Stamina: 14, Courage: 16, Luck: 18,
+		Skill: 2, MaximumLP: 20, LPBefore: 20, LPAfter: 0, WeaponBonus: 3, ArmorProtection: 1}
+	if !reflect.DeepEqual(encounter.Enemies, []character.EnemySnapshot{wantEnemy}) {
+		t.Errorf("enemies = %+v; want %+v", encounter.Enemies, wantEnemy)
+	}
+	if !reflect.DeepEqual(encounter.Items, []string{"Healing Stone"}) || !reflect.DeepEqual(encounter.Spells, []string{"ARMOUR"}) {
+		t.Errorf("items %v, spells %v; want the Healing Stone and ARMOUR", encounter.Items, encounter.Spells)
+	}
+	if !reflect.DeepEqual(encounter.Log, cs.Log()) {
+		t.Errorf("log = %q; want %q", encounter.Log, cs.Log())
+	}
+	if err := player.AddEncounter(encounter); err != nil {
+		t.Errorf("AddEncounter() unexpected error: %v", err)
+	}
+}
+
+// TestEncounterOutcome verifies how each ending is recorded in the journal.
+func TestEncounterOutcome(t *testing.T) {
+	tests := []struct {
+		name string
+		log  func(cs *CombatState)
+		want string
+	}{
+		{"Victory", func(cs *CombatState) { cs.Record(Event{Type: EventCombatEnded, Outcome: OutcomeVictory}) }, character.EncounterVictory},
+		{"Fled", func(cs *CombatState) { cs.LogFlee() }, character.EncounterFled},
+		{"Paralysis", func(cs *CombatState) { cs.LogSpellEnd("PARALYSIS") }, character.EncounterParalysis},
+		{"Invisibility", func(cs *CombatState) { cs.LogSpellEnd("INVISIBILITY") }, character.EncounterInvisibility},
+		{"Defeat", func(cs *CombatState) { cs.LogDefeat() }, character.EncounterDefeat},
+		{"Drained", func(cs *CombatState) { cs.Record(Event{Type: EventCombatEnded, Outcome: OutcomeDrained}) }, character.EncounterDefeat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+			enemy, _ := NewEnemy("Goblin", 40, 35, 30, 25, 20, 0, 150, 150, 5, 0, false)
+			cs := NewCombatState(enemy, 3)
+			tt.log(cs)
+			encounter, ok := cs.Encounter(player, time.Now())
+			if !ok || encounter.Outcome != tt.want {
+				t.Errorf("Encounter() outcome = %q (%v); want %q", encounter.Outcome, ok, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package combat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/internal/items"
+)
+
+// EnemyNames lists the names of the given opponents, e.g. "Orc, Wolf and Bat".
+func EnemyNames(opponents []*Opponent) string {
+	names := make([]string, len(opponents))
+	for i, opponent := range opponents {
+		names[i] = opponent.Enemy.Name
+	}
+	if len(names) <= 1 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// opponentNumber returns the enemy's number in setup order, counted from
+// 1, or 0 if it is not one of the opponents.
+func (cs *CombatState) opponentNumber(enemy *Enemy) int {
+	for i, opponent := range cs.Opponents {
+		if enemy != nil && opponent.Enemy == enemy {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// LogStart records the opening of a fight: the enemies, everyone's
+// initiative and LP, and who strikes first.
+func (cs *CombatState) LogStart(player *character.Character) {
+	first := PlayerName
+	if !cs.PlayerFirstStrike {
+		first = cs.ActingEnemy().Name
+	}
+	startingLP := []Term{{Name: PlayerName, Value: player.CurrentLP}}
+	for _, opponent := range cs.Opponents {
+		startingLP = append(startingLP, Term{Name: opponent.Enemy.Name, Value: opponent.Enemy.CurrentLP})
+	}
+	cs.Record(Event{
+		Type:       EventCombatStarted,
+		Actor:      first,
+		Target:     EnemyNames(cs.Opponents),
+		Initiative: cs.initiative(),
+		StartingLP: startingLP,
+	})
+}
+
+// initiative returns everyone's initiative, in turn order.
+func (cs *CombatState) initiative() []Term {
+	scores := make([]Term, 0, len(cs.TurnOrder))
+	for _, actor := range cs.TurnOrder {
+		if actor == PlayerActor {
+			scores = append(scores, Term{Name: PlayerName, Value: cs.PlayerInitiative})
+		} else {
+			opponent := cs.Opponents[actor]
+			scores = append(scores, Term{Name: opponent.Enemy.Name, Value: opponent.Initiative})
+		}
+	}
+	return scores
+}
+
+// LogPlayerAttack records a player attack and its item effects.
+func (cs *CombatState) LogPlayerAttack(player *character.Character, r PlayerAttackResult) {
+	if r.BloodPrice > 0 {
+		cs.Record(Event{
+			Type:      EventItemUsed,
+			Actor:     PlayerName,
+			Item:      items.DoombringerName,
+			Effect:    EffectBloodPrice,
+			Amount:    r.BloodPrice,
+			LP:        player.CurrentLP - r.SoulThirst, // Before the hit healed
+			MaximumLP: player.MaximumLP,
+		})
+	}
+	if r.Drained {
+		cs.Record(Event{Type: EventCombatEnded, Actor: PlayerName, Outcome: OutcomeDrained})
+		return
+	}
+
+	cs.Record(Event{
+		Type:        EventAttackRolled,
+		Actor:       PlayerName,
+		Target:      r.Target.Name,
+		Opponent:    cs.opponentNumber(r.Target),
+		Rolls:       []int{r.Roll},
+		Requirement: r.Requirement,
+		Success:     r.Hit,
+	})
+	if !r.Hit {
+		if r.Doombringer {
+			cs.Record(Event{Type: EventItemUsed, Actor: PlayerName, Item: items.DoombringerName, Effect: EffectSoulThirst})
+		}
+		return
+	}
+
+	if r.OrbBonus > 0 {
+		cs.Record(Event{
+			Type:     EventItemUsed,
+			Actor:    PlayerName,
+			Target:   r.Target.Name,
+			Opponent: cs.opponentNumber(r.Target),
+			Item:     items.TheOrbName,
+			Effect:   EffectDamageBonus,
+			Amount:   r.OrbBonus,
+		})
+	}
+	cs.Record(Event{
+		Type:      EventDamageDealt,
+		Actor:     PlayerName,
+		Target:    r.Target.Name,
+		Opponent:  cs.opponentNumber(r.Target),
+		Amount:    r.FinalDamage,
+		Modifiers: r.Modifiers,
+		LP:        r.TargetLP,
+		MaximumLP: r.Target.MaximumLP,
+	})
+
+	if r.Doombringer && r.FinalDamage > 0 {
+		cs.Record(Event{
+			Type:      EventItemUsed,
+			Actor:     PlayerName,
+			Item:      items.DoombringerName,
+			Effect:    EffectSoulThirst,
+			Success:   true,
+			Amount:    r.SoulThirst,
+			LP:        player.CurrentLP,
+			MaximumLP: player.MaximumLP,
+		})
+	}
+}
+
+// LogEnemyAttack records an enemy's attack on the player.
+func (cs *CombatState) LogEnemyAttack(enemy *Enemy, r AttackResult) {
+	cs.Record(Event{
+		Type:        EventAttackRolled,
+		Actor:       enemy.Name,
+		Target:      PlayerName,
+		Opponent:    cs.opponentNumber(enemy),
+		Rolls:       []int{r.Roll},
+		Requirement: r.Requirement,
+		Success:     r.Hit,
+	})
+	if r.Hit {
+		cs.Record(Event{
+			Type:      EventDamageDealt,
+			Actor:     enemy.Name,
+			Target:    PlayerName,
+			Opponent:  cs.opponentNumber(enemy),
+			Amount:    r.FinalDamage,
+			Modifiers: r.Modifiers,
+			LP:        r.TargetLP,
+		})
+	}
+}
+
+// LogRest records the player resting and the free attacks it allowed.
+func (cs *CombatState) LogRest(r RestResult) {
+	for _, attack := range r.Attacks {
+		cs.AddLogEntry(fmt.Sprintf("[R%d] %s attacks while you rest...", cs.CurrentRound, attack.Enemy.Name))
+		cs.LogEnemyAttack(attack.Enemy, attack.AttackResult)
+	}
+	cs.Record(Event{Type: EventRestTaken, Actor: PlayerName})
+}
+
+// LogEnemyTurn records an enemy's turn: an attack, or a rest and the
+// player's free attack.
+func (cs *CombatState) LogEnemyTurn(player *character.Character, r EnemyTurnResult) {
+	round := cs.CurrentRound
+	if !r.Rested {
+		cs.LogEnemyAttack(r.Enemy, *r.Attack)
+		return
+	}
+
+	cs.AddLogEntry(fmt.Sprintf("[R%d] %s's endurance depleted! %s must rest.", round, r.Enemy.Name, r.Enemy.Name))
+	cs.AddLogEntry(fmt.Sprintf("[R%d] You attack %s while it rests...", round, r.Enemy.Name))
+	cs.LogPlayerAttack(player, *r.FreeAttack)
+	if !r.FreeAttack.Drained {
+		cs.Record(Event{Type: EventRestTaken, Actor: r.Enemy.Name, Opponent: cs.opponentNumber(r.Enemy)})
+	}
+}
+
+// LogHealingStone records the use of the Healing Stone.
+func (cs *CombatState) LogHealingStone(player *character.Character, r HealingStoneResult) {
+	cs.Record(Event{
+		Type:      EventItemUsed,
+		Actor:     PlayerName,
+		Item:      items.HealingStoneName,
+		Effect:    EffectHeal,
+		Rolls:     []int{r.Roll},
+		Amount:    r.Healed,
+		Charges:   r.ChargesLeft,
+		LP:        player.CurrentLP,
+		MaximumLP: player.MaximumLP,
+	})
+}
+
+// LogOrbThrow records the throw of The Orb.
+func (cs *CombatState) LogOrbThrow(r OrbThrowResult) {
+	cs.Record(Event{
+		Type:        EventItemUsed,
+		Actor:       PlayerName,
+		Target:      r.Target.Name,
+		Opponent:    cs.opponentNumber(r.Target),
+		Item:        items.TheOrbName,
+		Effect:      EffectThrow,
+		Rolls:       []int{r.Roll},
+		Requirement: OrbThrowRequirement,
+		Success:     r.Hit,
+		Amount:      r.Damage,
+		LP:          r.Target.CurrentLP,
+		MaximumLP:   r.Target.MaximumLP,
+	})
+}
+
+// LogDeathSave records a death save: the score rolled against the
+// player's luck and, if it succeeded, the new initiative.
+func (cs *CombatState) LogDeathSave(player *character.Character, score int, survived bool) {
+	event := Event{
+		Type:        EventDeathSaveRolled,
+		Actor:       PlayerName,
+		Rolls:       []int{score / cs.Ruleset().DeathSaveMultiplier},
+		Requirement: player.Luck,
+		Success:     survived,
+		Amount:      score,
+		LP:          player.CurrentLP,
+		MaximumLP:   player.MaximumLP,
+	}
+	if survived {
+		event.Initiative = cs.initiative()
+	}
+	cs.Record(event)
+}
+
+// LogDefeat records the player's death.
+func (cs *CombatState) LogDefeat() {
+	cs.Record(Event{Type: EventCombatEnded, Actor: PlayerName, Outcome: OutcomeDefeat})
+}
+
+// LogFlee records the player running away.
+func (cs *CombatState) LogFlee() {
+	cs.Record(Event{Type: EventCombatEnded, Actor: PlayerName, Outcome: OutcomeFled})
+}
+
+// LogVictory records the end of a won fight, after ResolveEncounterVictory.
+func (cs *CombatState) LogVictory(player *character.Character) {
+	cs.Record(Event{Type: EventCombatEnded, Actor: PlayerName, Target: EnemyNames(cs.Opponents), Outcome: OutcomeVictory})
+	cs.AddLogEntry(fmt.Sprintf("[Victory] Skill increased to %d. Enemies defeated: %d", player.Skill, player.EnemiesDefeated))
+}
+
+// LogSpell records a spell cast by the player during the fight: the FFR
+// roll against the score it needed and the POW it cost.
+func (cs *CombatState) LogSpell(spell string, roll, powerSpent int, success bool) {
+	target := ""
+	if cs.Enemy != nil {
+		target = cs.Enemy.Name
+	}
+	cs.Record(Event{
+		Type:        EventSpellApplied,
+		Actor:       PlayerName,
+		Target:      target,
+		Opponent:    cs.opponentNumber(cs.Enemy),
+		Spell:       spell,
+		Rolls:       []int{roll},
+		Requirement: cs.Ruleset().FFRThreshold,
+		Success:     success,
+		Amount:      powerSpent,
+	})
+}
+
+// LogSpellDamage records damage dealt to the current target by a spell.
+func (cs *CombatState) LogSpellDamage(spell string, damage int) {
+	cs.Record(Event{
+		Type:      EventDamageDealt,
+		Actor:     PlayerName,
+		Target:    cs.Enemy.Name,
+		Opponent:  cs.opponentNumber(cs.Enemy),
+		Spell:     spell,
+		Amount:    damage,
+		LP:        cs.Enemy.CurrentLP,
+		MaximumLP: cs.Enemy.MaximumLP,
+	})
+}
+
+// LogSpellEnd records a spell taking the player out of the fight. No enemy
+// is slain, so even INVISIBILITY counts as an escape rather than a victory.
+func (cs *CombatState) LogSpellEnd(spell string) {
+	cs.Record(Event{Type: EventCombatEnded, Actor: PlayerName, Spell: spell, Outcome: OutcomeEscaped})
+}
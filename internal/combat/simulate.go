@@ -0,0 +1,198 @@
+package combat
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/internal/dice"
+	"github.com/benoit/saga-demonspawn/internal/rules"
+)
+
+// LPBuckets is the number of bands the final LP distribution is split into:
+// one for death, then one per 10% of maximum LP.
+const LPBuckets = 11
+
+// SimulationOptions controls a Monte Carlo run.
+type SimulationOptions struct {
+	Fights    int           // Number of fights to play (default 10000)
+	Workers   int           // Goroutines sharing the fights (default: one per CPU)
+	Seed      int64         // Base seed; worker i rolls with Seed+i (0 picks one from the clock)
+	MaxTurns  int           // Turns after which a fight counts as unfinished (see FightOptions)
+	HealBelow int           // Use the Healing Stone when LP falls below this % of maximum (0 never)
+	Rules     rules.Ruleset // Rules the fights are played by (default: rules.Default)
+}
+
+// SimulationResult summarises the fights played by Simulate.
+type SimulationResult struct {
+	Fights             int            // Fights played
+	Wins               int            // Fights where every enemy died
+	Losses             int            // Fights where Fire*Wolf died
+	Unfinished         int            // Fights stopped after MaxTurns
+	TotalRounds        int            // Rounds fought across all fights
+	DeathSaves         int            // Fights where the death save was rolled
+	DeathSavesSurvived int            // Death saves that succeeded
+	HealingStoneUses   int            // Times the Healing Stone was invoked
+	TotalLPRemaining   int            // Final LP summed over won fights
+	LPDistribution     [LPBuckets]int // Final LP bands: 0 = dead, i = up to i×10% of maximum
+}
+
+// WinProbability returns the share of fights won, from 0 to 1.
+func (r SimulationResult) WinProbability() float64 {
+	return ratio(r.Wins, r.Fights)
+}
+
+// ExpectedRounds returns the average number of rounds per fight.
+func (r SimulationResult) ExpectedRounds() float64 {
+	return ratio(r.TotalRounds, r.Fights)
+}
+
+// DeathSaveRate returns the share of fights in which the death save was needed.
+func (r SimulationResult) DeathSaveRate() float64 {
+	return ratio(r.DeathSaves, r.Fights)
+}
+
+// AverageLPRemaining returns the average LP left after a won fight.
+func (r SimulationResult) AverageLPRemaining() float64 {
+	return ratio(r.TotalLPRemaining, r.Wins)
+}
+
+// LPBucketLabel describes an LP distribution band, e.g. "41-50%".
+func LPBucketLabel(bucket int) string {
+	if bucket == 0 {
+		return "dead"
+	}
+	return fmt.Sprintf("%d-%d%%", (bucket-1)*10+1, bucket*10)
+}
+
+// ratio divides two counts, returning 0 when there is nothing to divide.
+func ratio(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) / float64(whole)
+}
+
+// add merges another worker's results into r.
+func (r *SimulationResult) add(other SimulationResult) {
+	r.Fights += other.Fights
+	r.Wins += other.Wins
+	r.Losses += other.Losses
+	r.Unfinished += other.Unfinished
+	r.TotalRounds += other.TotalRounds
+	r.DeathSaves += other.DeathSaves
+	r.DeathSavesSurvived += other.DeathSavesSurvived
+	r.HealingStoneUses += other.HealingStoneUses
+	r.TotalLPRemaining += other.TotalLPRemaining
+	for i := range r.LPDistribution {
+		r.LPDistribution[i] += other.LPDistribution[i]
+	}
+}
+
+// Simulate plays out many fights between the player and the given enemies
+// and reports the odds. Neither the player nor the enemies are modified.
+// Each worker rolls with its own seeded dice, so a given seed and worker
+// count always give the same result.
+func Simulate(player *character.Character, enemies []*Enemy, opts SimulationOptions) (SimulationResult, error) {
+	if len(enemies) == 0 {
+		return SimulationResult{}, fmt.Errorf("at least one enemy is required")
+	}
+	if opts.Fights <= 0 {
+		opts.Fights = 10000
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+	if opts.Workers > opts.Fights {
+		opts.Workers = opts.Fights
+	}
+	if opts.Seed == 0 {
+		opts.Seed = time.Now().UnixNano()
+	}
+	if opts.Rules.IsZero() {
+		opts.Rules = rules.Default()
+	}
+
+	results := make([]SimulationResult, opts.Workers)
+	var wg sync.WaitGroup
+	for w := 0; w < opts.Workers; w++ {
+		// Spread the fights as evenly as possible
+		fights := opts.Fights / opts.Workers
+		if w < opts.Fights%opts.Workers {
+			fights++
+		}
+
+		wg.Add(1)
+		go func(worker, fights int) {
+			defer wg.Done()
+			roller := dice.NewSeededRoller(opts.Seed + int64(worker))
+			for i := 0; i < fights; i++ {
+				results[worker].add(simulateFight(player, enemies, roller, opts))
+			}
+		}(w, fights)
+	}
+	wg.Wait()
+
+	total := SimulationResult{}
+	for _, result := range results {
+		total.add(result)
+	}
+	return total, nil
+}
+
+// simulateFight plays one fight on copies of the combatants.
+func simulateFight(original *character.Character, originals []*Enemy, roller dice.Roller, opts SimulationOptions) SimulationResult {
+	player := original.Clone()
+	enemies := make([]*Enemy, len(originals))
+	for i, enemy := range originals {
+		copied := *enemy
+		enemies[i] = &copied
+	}
+
+	cs := StartEncounterWithRules(player, enemies, opts.Rules, roller)
+	outcome := AutoResolve(cs, player, roller, FightOptions{MaxTurns: opts.MaxTurns, HealBelow: opts.HealBelow})
+
+	result := SimulationResult{
+		Fights:           1,
+		TotalRounds:      outcome.Rounds,
+		HealingStoneUses: outcome.HealingStoneUses,
+	}
+	if outcome.DeathSaveRolled {
+		result.DeathSaves = 1
+	}
+	if outcome.DeathSaveSurvived {
+		result.DeathSavesSurvived = 1
+	}
+	switch {
+	case outcome.Victory:
+		result.Wins = 1
+		result.TotalLPRemaining = player.CurrentLP
+		result.LPDistribution[lpBucket(player.CurrentLP, player.MaximumLP)]++
+	case outcome.Unfinished:
+		result.Unfinished = 1
+	default:
+		result.Losses = 1
+		result.LPDistribution[0]++
+	}
+	return result
+}
+
+// shouldHeal returns true when the Healing Stone should be used this turn.
+func shouldHeal(player *character.Character, healBelow int) bool {
+	return healBelow > 0 && player.HealingStoneCharges > 0 &&
+		player.CurrentLP*100 < player.MaximumLP*healBelow
+}
+
+// lpBucket returns the LP distribution band for the final LP.
+func lpBucket(lp, maxLP int) int {
+	if lp <= 0 || maxLP <= 0 {
+		return 0
+	}
+	bucket := (lp*10 + maxLP - 1) / maxLP
+	if bucket > LPBuckets-1 {
+		bucket = LPBuckets - 1
+	}
+	return bucket
+}
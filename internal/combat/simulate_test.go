@@ -0,0 +1,100 @@
+package combat
+
+import (
+	"testing"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+)
+
+// TestSimulate verifies the odds, determinism and that inputs are untouched.
+func TestSimulate(t *testing.T) {
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	rat, _ := NewEnemy("Rat", 10, 10, 10, 10, 10, 0, 20, 20, 0, 0, false)
+	opts := SimulationOptions{Fights: 500, Workers: 4, Seed: 42}
+
+	result, err := Simulate(player, []*Enemy{rat}, opts)
+	if err != nil {
+		t.Fatalf("Simulate() unexpected error: %v", err)
+	}
+	if result.Fights != 500 || result.Wins+result.Losses+result.Unfinished != 500 {
+		t.Errorf("Simulate() played %d fights (%d+%d+%d), want 500", result.Fights, result.Wins, result.Losses, result.Unfinished)
+	}
+	if result.WinProbability() != 1 {
+		t.Errorf("WinProbability() = %.3f against a rat, want 1", result.WinProbability())
+	}
+	if result.ExpectedRounds() < 1 {
+		t.Errorf("ExpectedRounds() = %.2f, want at least 1", result.ExpectedRounds())
+	}
+	if rat.CurrentLP != 20 || player.CurrentLP != player.MaximumLP {
+		t.Error("Simulate() should not modify the player or the enemies")
+	}
+
+	again, _ := Simulate(player, []*Enemy{rat}, opts)
+	if again != result {
+		t.Error("Simulate() with the same seed and workers should give the same result")
+	}
+
+	dragon, _ := NewEnemy("Dragon", 96, 96, 96, 96, 96, 50, 2000, 2000, 50, 30, false)
+	doomed, _ := Simulate(player, []*Enemy{dragon}, opts)
+	if doomed.WinProbability() > 0.01 {
+		t.Errorf("WinProbability() = %.3f against a dragon, want about 0", doomed.WinProbability())
+	}
+	if doomed.DeathSaveRate() == 0 || doomed.LPDistribution[0] == 0 {
+		t.Error("losing fights should use the death save and end at 0 LP")
+	}
+
+	if _, err := Simulate(player, nil, opts); err == nil {
+		t.Error("Simulate() expected error without enemies")
+	}
+}
+
+// TestLPBucket verifies the final LP bands.
+func TestLPBucket(t *testing.T) {
+	tests := []struct {
+		lp, maxLP, want int
+	}{
+		{0, 400, 0},
+		{-5, 400, 0},
+		{1, 400, 1},
+		{40, 400, 1},
+		{41, 400, 2},
+		{400, 400, 10},
+		{450, 400, 10},
+	}
+
+	for _, tt := range tests {
+		if got := lpBucket(tt.lp, tt.maxLP); got != tt.want {
+			t.Errorf("lpBucket(%d, %d) = %d, want %d", tt.lp, tt.maxLP, got, tt.want)
+		}
+	}
+	if LPBucketLabel(5) != "41-50%" {
+		t.Errorf("LPBucketLabel(5) = %q, want 41-50%%", LPBucketLabel(5))
+	}
+}
+
+// TestAutoResolve verifies a narrated automatic fight.
+func TestAutoResolve(t *testing.T) {
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	rat, _ := NewEnemy("Rat", 10, 10, 10, 10, 10, 0, 20, 20, 0, 0, false)
+	// Initiative: player 7 + 184, rat 7 + 30; the player hits on 9 for 45 + 30 + 10 (sword)
+	roller := &MockRoller{NextRoll: 9}
+	cs := StartCombat(player, rat, roller)
+	cs.LogStart(player)
+
+	outcome := AutoResolve(cs, player, roller, FightOptions{Narrate: true})
+
+	if !outcome.Victory || outcome.Rounds != 1 || outcome.DeathSaveRolled {
+		t.Errorf("AutoResolve() = %+v, want a first-round victory", outcome)
+	}
+	if cs.IsActive {
+		t.Error("AutoResolve() should end the fight")
+	}
+	log := cs.Log()
+	last := log[len(log)-1]
+	if last != "[R1] Rat takes 85 damage (0 LP remaining)" {
+		t.Errorf("last log entry = %q", last)
+	}
+	if player.EnemiesDefeated != 0 {
+		t.Error("AutoResolve() should leave victory rewards to ResolveEncounterVictory")
+	}
+}
@@ -0,0 +1,73 @@
+package combat
+
+import (
+	"github.com/benoit/saga-demonspawn/internal/character"
+)
+
+// HitChance returns the chance of 2d6 scoring the requirement or more,
+// from 0 to 1.
+func HitChance(requirement int) float64 {
+	hits := 0
+	for a := 1; a <= 6; a++ {
+		for b := 1; b <= 6; b++ {
+			if a+b >= requirement {
+				hits++
+			}
+		}
+	}
+	return float64(hits) / 36
+}
+
+// TallyStatistics adds the fight's events to the player's lifetime
+// statistics when it ends or is left. Events already tallied are skipped,
+// so calling it again only adds what happened since. Spells are not
+// counted here; they are recorded when cast, in a fight or not.
+func (cs *CombatState) TallyStatistics(player *character.Character) {
+	stats := &player.Statistics
+	for _, event := range cs.Events[cs.Tallied:] {
+		switch event.Type {
+		case EventAttackRolled:
+			if event.Actor == PlayerName {
+				stats.AttacksMade++
+				if event.Success {
+					stats.AttacksHit++
+				}
+				stats.ExpectedHits += HitChance(event.Requirement)
+			}
+		case EventDamageDealt:
+			if event.Actor == PlayerName {
+				stats.DamageDealt += event.Amount
+			} else if event.Target == PlayerName {
+				stats.DamageTaken += event.Amount
+			}
+		case EventItemUsed:
+			switch event.Effect {
+			case EffectBloodPrice:
+				stats.BloodPricePaid += event.Amount
+			case EffectSoulThirst:
+				stats.RecordHeal(character.HealSourceDoombringer, event.Amount)
+			case EffectHeal:
+				stats.RecordHeal(character.HealSourceHealingStone, event.Amount)
+			case EffectThrow:
+				stats.DamageDealt += event.Amount
+			}
+		case EventDeathSaveRolled:
+			stats.DeathSavesUsed++
+			if event.Success {
+				stats.DeathSavesPassed++
+			}
+		case EventCombatEnded:
+			switch event.Outcome {
+			case OutcomeVictory:
+				stats.FightsWon++
+			case OutcomeDefeat, OutcomeDrained:
+				stats.FightsLost++
+			case OutcomeFled:
+				stats.FightsFled++
+			case OutcomeEscaped:
+				stats.FightsEscaped++
+			}
+		}
+	}
+	cs.Tallied = len(cs.Events)
+}
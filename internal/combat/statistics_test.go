@@ -0,0 +1,110 @@
+package combat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+)
+
+// TestHitChance verifies the chance of 2d6 reaching a score.
+func TestHitChance(t *testing.T) {
+	tests := []struct {
+		requirement int
+		want        float64
+	}{
+		{2, 1},
+		{7, 21.0 / 36},
+		{12, 1.0 / 36},
+		{13, 0},
+	}
+	for _, tt := range tests {
+		if got := HitChance(tt.requirement); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("HitChance(%d) = %v; want %v", tt.requirement, got, tt.want)
+		}
+	}
+}
+
+// TestTallyStatistics verifies a fight's events are added to the player's
+// statistics once.
+func TestTallyStatistics(t *testing.T) {
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	enemy, _ := NewEnemy("Goblin", 40, 35, 30, 25, 20, 0, 150, 150, 5, 0, false)
+	cs := NewCombatState(enemy, 3)
+	cs.Events = []Event{
+		{Type: EventCombatStarted, Actor: PlayerName},
+		{Type: EventItemUsed, Actor: PlayerName, Effect: EffectBloodPrice, Amount: 10},
+		{Type: EventAttackRolled, Actor: PlayerName, Requirement: 7, Success: true},
+		{Type: EventDamageDealt, Actor: PlayerName, Target: "Goblin", Amount: 60},
+		{Type: EventItemUsed, Actor: PlayerName, Effect: EffectSoulThirst, Success: true, Amount: 60},
+		{Type: EventAttackRolled, Actor: "Goblin", Target: PlayerName, Requirement: 8, Success: true},
+		{Type: EventDamageDealt, Actor: "Goblin", Target: PlayerName, Amount: 35},
+		{Type: EventAttackRolled, Actor: PlayerName, Requirement: 12},
+		{Type: EventItemUsed, Actor: PlayerName, Effect: EffectHeal, Amount: 20},
+		{Type: EventItemUsed, Actor: PlayerName, Target: "Goblin", Effect: EffectThrow, Success: true, Amount: 200},
+		{Type: EventDeathSaveRolled, Actor: PlayerName, Success: true},
+		{Type: EventSpellApplied, Actor: PlayerName, Spell: "FIREBALL", Success: true, Amount: 15},
+		{Type: EventDamageDealt, Actor: PlayerName, Target: "Goblin", Spell: "FIREBALL", Amount: 50},
+		{Type: EventCombatEnded, Actor: PlayerName, Outcome: OutcomeVictory},
+	}
+
+	cs.TallyStatistics(player)
+	cs.TallyStatistics(player)
+
+	s := player.Statistics
+	if s.AttacksMade != 2 || s.AttacksHit != 1 || math.Abs(s.ExpectedHits-22.0/36) > 1e-9 {
+		t.Errorf("attacks = %d made, %d hit, %.3f expected; want 2, 1, %.3f", s.AttacksMade, s.AttacksHit, s.ExpectedHits, 22.0/36)
+	}
+	if s.DamageDealt != 310 || s.DamageTaken != 35 || s.BloodPricePaid != 10 {
+		t.Errorf("damage dealt %d, taken %d, blood price %d; want 310, 35, 10", s.DamageDealt, s.DamageTaken, s.BloodPricePaid)
+	}
+	if s.LPHealed[character.HealSourceDoombringer] != 60 || s.LPHealed[character.HealSourceHealingStone] != 20 {
+		t.Errorf("LP healed = %v; want 60 by Doombringer and 20 by the Healing Stone", s.LPHealed)
+	}
+	if s.DeathSavesUsed != 1 || s.DeathSavesPassed != 1 || s.FightsWon != 1 || s.Fights() != 1 {
+		t.Errorf("death saves %d/%d, fights won %d of %d; want 1/1, 1 of 1", s.DeathSavesPassed, s.DeathSavesUsed, s.FightsWon, s.Fights())
+	}
+	if len(s.Spells) != 0 {
+		t.Errorf("spells = %v; want them left to the cast", s.Spells)
+	}
+
+	// Only what happens after the tally is added next time
+	cs.Record(Event{Type: EventCombatEnded, Actor: PlayerName, Outcome: OutcomeFled})
+	cs.TallyStatistics(player)
+	if player.Statistics.FightsFled != 1 || player.Statistics.AttacksMade != 2 {
+		t.Errorf("statistics after a second tally = %+v", player.Statistics)
+	}
+}
+
+// TestTallySpellEnd verifies a spell ending the fight counts as an escape,
+// INVISIBILITY included.
+func TestTallySpellEnd(t *testing.T) {
+	for _, spell := range []string{"INVISIBILITY", "PARALYSIS"} {
+		t.Run(spell, func(t *testing.T) {
+			player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+			enemy, _ := NewEnemy("Goblin", 40, 35, 30, 25, 20, 0, 150, 150, 5, 0, false)
+			cs := NewCombatState(enemy, 3)
+			cs.LogSpellEnd(spell)
+			cs.TallyStatistics(player)
+
+			s := player.Statistics
+			if s.FightsEscaped != 1 || s.FightsWon != 0 {
+				t.Errorf("fights escaped %d, won %d; want 1 and 0", s.FightsEscaped, s.FightsWon)
+			}
+			if want := "Combat ended via " + spell + " (escaped)!"; cs.Log()[0] != want {
+				t.Errorf("log = %q; want %q", cs.Log()[0], want)
+			}
+		})
+	}
+}
+
+// TestTalliedValidate verifies a saved fight cannot claim more tallied
+// events than it has.
+func TestTalliedValidate(t *testing.T) {
+	enemy, _ := NewEnemy("Goblin", 40, 35, 30, 25, 20, 0, 150, 150, 5, 0, false)
+	cs := NewCombatState(enemy, 3)
+	cs.Tallied = 1
+	if err := cs.Validate(); err == nil {
+		t.Error("Validate() expected error")
+	}
+}
@@ -0,0 +1,105 @@
+package combat
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+)
+
+// InProgress returns true if the fight can still be played on: it has not
+// been fled or won, and the player has not lost with the death save spent.
+func (cs *CombatState) InProgress(player *character.Character) bool {
+	if !cs.IsActive || CheckVictory(cs) {
+		return false
+	}
+	return !(CheckDefeat(player, cs) && cs.DeathSaveUsed)
+}
+
+// Suspend stores a fight in progress in the player's save, so it can be
+// resumed after the character is loaded again. A nil or finished fight
+// clears any fight stored before.
+func Suspend(player *character.Character, cs *CombatState) error {
+	if cs == nil || !cs.InProgress(player) {
+		player.Combat = nil
+		return nil
+	}
+
+	data, err := json.Marshal(cs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal combat: %w", err)
+	}
+	player.Combat = data
+	return nil
+}
+
+// Interrupted returns the fight stored in the player's save by Suspend,
+// or nil if there is none.
+func Interrupted(player *character.Character) (*CombatState, error) {
+	if len(player.Combat) == 0 {
+		return nil, nil
+	}
+
+	var cs CombatState
+	if err := json.Unmarshal(player.Combat, &cs); err != nil {
+		return nil, fmt.Errorf("failed to parse saved combat: %w", err)
+	}
+	if cs.Events == nil {
+		cs.Events = []Event{}
+	}
+	if err := cs.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid saved combat: %w", err)
+	}
+	cs.retarget()
+	return &cs, nil
+}
+
+// Validate checks that a fight read back from a save is consistent: every
+// opponent has an enemy and the turn order, turn and target point at
+// combatants of the encounter.
+func (cs *CombatState) Validate() error {
+	if len(cs.Opponents) == 0 {
+		return fmt.Errorf("combat has no opponents")
+	}
+	for i, opponent := range cs.Opponents {
+		if opponent == nil || opponent.Enemy == nil {
+			return fmt.Errorf("opponent %d has no enemy", i+1)
+		}
+		if opponent.Enemy.Name == "" {
+			return fmt.Errorf("opponent %d has no name", i+1)
+		}
+		if opponent.Enemy.MaximumLP <= 0 {
+			return fmt.Errorf("%s: maximum LP must be positive: %d", opponent.Enemy.Name, opponent.Enemy.MaximumLP)
+		}
+		if opponent.RoundsSinceLastRest < 0 {
+			return fmt.Errorf("%s: rounds since last rest cannot be negative", opponent.Enemy.Name)
+		}
+	}
+
+	if cs.CurrentRound < 1 {
+		return fmt.Errorf("invalid round: %d", cs.CurrentRound)
+	}
+	if cs.RoundsSinceLastRest < 0 {
+		return fmt.Errorf("rounds since last rest cannot be negative: %d", cs.RoundsSinceLastRest)
+	}
+	if len(cs.TurnOrder) != len(cs.Opponents)+1 {
+		return fmt.Errorf("turn order has %d actors; want %d", len(cs.TurnOrder), len(cs.Opponents)+1)
+	}
+	seen := make(map[int]bool, len(cs.TurnOrder))
+	for _, actor := range cs.TurnOrder {
+		if actor != PlayerActor && (actor < 0 || actor >= len(cs.Opponents)) || seen[actor] {
+			return fmt.Errorf("invalid turn order: %v", cs.TurnOrder)
+		}
+		seen[actor] = true
+	}
+	if cs.TurnIndex < 0 || cs.TurnIndex >= len(cs.TurnOrder) {
+		return fmt.Errorf("invalid turn: %d", cs.TurnIndex)
+	}
+	if cs.Target < 0 || cs.Target >= len(cs.Opponents) {
+		return fmt.Errorf("invalid target: %d", cs.Target)
+	}
+	if cs.Tallied < 0 || cs.Tallied > len(cs.Events) {
+		return fmt.Errorf("invalid tallied events: %d of %d", cs.Tallied, len(cs.Events))
+	}
+	return nil
+}
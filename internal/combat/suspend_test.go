@@ -0,0 +1,169 @@
+package combat
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+)
+
+// TestSuspendAndResume verifies a fight survives a save and load of the
+// character, with its log, death save, endurance and initiative.
+func TestSuspendAndResume(t *testing.T) {
+	dir := t.TempDir()
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	goblin, _ := NewEnemy("Goblin", 40, 35, 30, 25, 20, 0, 150, 150, 5, 0, false)
+	orc, _ := NewEnemy("Orc", 50, 30, 40, 30, 20, 2, 180, 180, 8, 2, false)
+	cs := StartEncounter(player, []*Enemy{goblin, orc}, &MockRoller{Rolls: []int{6, 9, 4}})
+	cs.LogStart(player)
+	cs.SetTarget(1)
+	cs.CurrentRound = 3
+	cs.DeathSaveUsed = true
+	cs.RoundsSinceLastRest = 2
+	cs.Opponents[0].RoundsSinceLastRest = 1
+	orc.CurrentLP = 60
+
+	if err := Suspend(player, cs); err != nil {
+		t.Fatalf("Suspend() unexpected error: %v", err)
+	}
+	if err := player.Save(dir); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+	loaded, err := character.Load(player.SavePath(dir))
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+
+	resumed, err := Interrupted(loaded)
+	if err != nil {
+		t.Fatalf("Interrupted() unexpected error: %v", err)
+	}
+	if resumed == nil {
+		t.Fatal("Interrupted() = nil; want the suspended fight")
+	}
+	if resumed.CurrentRound != 3 || !resumed.DeathSaveUsed || resumed.RoundsSinceLastRest != 2 {
+		t.Errorf("resumed round %d, death save used %v, rounds since rest %d; want 3, true, 2",
+			resumed.CurrentRound, resumed.DeathSaveUsed, resumed.RoundsSinceLastRest)
+	}
+	if resumed.PlayerInitiative != cs.PlayerInitiative || resumed.Opponents[1].Initiative != cs.Opponents[1].Initiative {
+		t.Errorf("resumed initiative %d/%d; want %d/%d", resumed.PlayerInitiative, resumed.Opponents[1].Initiative,
+			cs.PlayerInitiative, cs.Opponents[1].Initiative)
+	}
+	if len(resumed.TurnOrder) != 3 || resumed.TurnIndex != cs.TurnIndex || resumed.PlayerTurn != cs.PlayerTurn {
+		t.Errorf("resumed turn order %v at %d; want %v at %d", resumed.TurnOrder, resumed.TurnIndex, cs.TurnOrder, cs.TurnIndex)
+	}
+	if resumed.Opponents[0].RoundsSinceLastRest != 1 {
+		t.Errorf("Goblin rounds since rest = %d; want 1", resumed.Opponents[0].RoundsSinceLastRest)
+	}
+	if !reflect.DeepEqual(resumed.Events, cs.Events) || len(resumed.Events) == 0 {
+		t.Errorf("resumed events = %+v; want %+v", resumed.Events, cs.Events)
+	}
+	if resumed.Enemy == nil || resumed.Enemy.Name != "Orc" || resumed.Enemy.CurrentLP != 60 {
+		t.Errorf("resumed target = %+v; want the wounded Orc", resumed.Enemy)
+	}
+}
+
+// TestSuspendFinishedFight verifies a fight that is over is not kept.
+func TestSuspendFinishedFight(t *testing.T) {
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	enemy, _ := NewEnemy("Goblin", 40, 35, 30, 25, 20, 0, 150, 150, 5, 0, false)
+
+	cs := NewCombatState(enemy, 3)
+	Suspend(player, cs)
+	if player.Combat == nil {
+		t.Fatal("Suspend() did not store a fight in progress")
+	}
+
+	tests := []struct {
+		name   string
+		modify func(cs *CombatState, player *character.Character)
+	}{
+		{"No fight", nil},
+		{"Fled", func(cs *CombatState, _ *character.Character) { Flee(cs) }},
+		{"Won", func(cs *CombatState, _ *character.Character) { cs.Enemy.CurrentLP = 0 }},
+		{"Lost", func(cs *CombatState, p *character.Character) { p.SetLP(0); cs.DeathSaveUsed = true }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := player.Clone()
+			var fight *CombatState
+			if tt.modify != nil {
+				fight = cs.Clone()
+				tt.modify(fight, p)
+			}
+			if err := Suspend(p, fight); err != nil {
+				t.Fatalf("Suspend() unexpected error: %v", err)
+			}
+			if p.Combat != nil {
+				t.Error("Suspend() kept a fight that is over")
+			}
+			if resumed, _ := Interrupted(p); resumed != nil {
+				t.Error("Interrupted() returned a fight that is over")
+			}
+		})
+	}
+
+	// A death save still to roll keeps the fight going
+	p := player.Clone()
+	p.SetLP(0)
+	Suspend(p, cs.Clone())
+	if p.Combat == nil {
+		t.Error("Suspend() dropped a fight with the death save still to roll")
+	}
+}
+
+// TestInterruptedInvalid verifies a corrupt saved fight is reported.
+func TestInterruptedInvalid(t *testing.T) {
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"Not JSON", `{"opponents": [`},
+		{"No opponents", `{"is_active": true, "current_round": 1, "opponents": [], "turn_order": [-1]}`},
+		{"Turn order outside the encounter", `{"is_active": true, "current_round": 1, "target": 0,
+			"opponents": [{"enemy": {"name": "Goblin", "maximum_lp": 10, "current_lp": 10}}], "turn_order": [-1, 3]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := player.Clone()
+			p.Combat = []byte(tt.data)
+			if _, err := Interrupted(p); err == nil {
+				t.Error("Interrupted() expected error")
+			}
+		})
+	}
+}
+
+// TestSuspendedFightInSaveFile verifies the fight is written with the character.
+func TestSuspendedFightInSaveFile(t *testing.T) {
+	dir := t.TempDir()
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	enemy, _ := NewEnemy("Goblin", 40, 35, 30, 25, 20, 0, 150, 150, 5, 0, false)
+	Suspend(player, NewCombatState(enemy, 3))
+	player.Save(dir)
+
+	data, _ := os.ReadFile(player.SavePath(dir))
+	if !json.Valid(data) || !strings.Contains(string(data), `"combat": {`) {
+		t.Errorf("save file has no combat object:\n%s", data)
+	}
+}
+
+// TestInterruptedWithoutEvents verifies a fight saved without events
+// resumes with an empty log.
+func TestInterruptedWithoutEvents(t *testing.T) {
+	player, _ := character.New(64, 56, 72, 48, 80, 40, 56)
+	player.Combat = []byte(`{"is_active": true, "current_round": 2, "target": 0, "turn_order": [-1, 0],
+		"opponents": [{"enemy": {"name": "Goblin", "maximum_lp": 10, "current_lp": 10}}]}`)
+
+	resumed, err := Interrupted(player)
+	if err != nil {
+		t.Fatalf("Interrupted() unexpected error: %v", err)
+	}
+	if resumed.Events == nil || len(resumed.Log()) != 0 {
+		t.Errorf("Events = %v, want an empty log", resumed.Events)
+	}
+}
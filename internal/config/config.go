@@ -6,6 +6,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
+
+	"github.com/benoit/saga-demonspawn/internal/rules"
+	"github.com/benoit/saga-demonspawn/internal/safefile"
 )
 
 // Config holds all user preferences and settings.
@@ -16,17 +20,22 @@ type Config struct {
 	ShowAnimations bool   `json:"show_animations"` // Enable visual transitions
 
 	// Gameplay settings
-	ConfirmActions bool `json:"confirm_actions"` // Require confirmation for risky actions
-	AutoSave       bool `json:"auto_save"`       // Save character on application exit
-	ShowRollDetails bool `json:"show_roll_details"` // Display dice roll breakdowns
+	ConfirmActions  bool   `json:"confirm_actions"`   // Require confirmation for risky actions
+	AutoSave        bool   `json:"auto_save"`         // Save character on exit and in the background
+	AutoSaveMinutes int    `json:"auto_save_minutes"` // Minutes between background saves (0 = only after key events)
+	ShowRollDetails bool   `json:"show_roll_details"` // Display dice roll breakdowns
+	ManualDice      bool   `json:"manual_dice"`       // Type in physical dice results instead of rolling
+	Ruleset         string `json:"ruleset"`           // Rules profile: "original", "revised" or a custom profile
 
 	// Accessibility settings
 	HighContrast  bool `json:"high_contrast"`  // Accessibility: enhanced contrast
 	ReducedMotion bool `json:"reduced_motion"` // Reduce visual effects
 
 	// File settings
-	SaveDirectory   string `json:"save_directory"`    // Character save location
-	CharacterBackup bool   `json:"character_backup"`  // Create timestamped backups
+	SaveDirectory    string `json:"save_directory"`      // Character save location
+	CharacterBackup  bool   `json:"character_backup"`    // Back up a save slot before overwriting it
+	BackupCount      int    `json:"backup_count"`        // Backups kept per save slot
+	BackupMaxAgeDays int    `json:"backup_max_age_days"` // Backups older than this are deleted (0 = never)
 }
 
 // Default returns a configuration with default values.
@@ -38,16 +47,21 @@ func Default() *Config {
 	}
 
 	return &Config{
-		Theme:           "dark",
-		UseUnicode:      true,
-		ShowAnimations:  true,
-		ConfirmActions:  true,
-		AutoSave:        true,
-		ShowRollDetails: true,
-		HighContrast:    false,
-		ReducedMotion:   false,
-		SaveDirectory:   saveDir,
-		CharacterBackup: true,
+		Theme:            "dark",
+		UseUnicode:       true,
+		ShowAnimations:   true,
+		ConfirmActions:   true,
+		AutoSave:         true,
+		AutoSaveMinutes:  5,
+		ShowRollDetails:  true,
+		ManualDice:       false,
+		Ruleset:          rules.DefaultID,
+		HighContrast:     false,
+		ReducedMotion:    false,
+		SaveDirectory:    saveDir,
+		CharacterBackup:  true,
+		BackupCount:      10,
+		BackupMaxAgeDays: 30,
 	}
 }
 
@@ -64,12 +78,14 @@ func Load(path string) (*Config, error) {
 		return Default(), fmt.Errorf("failed to read config: %w", err)
 	}
 
-	var cfg Config
-	if err := json.Unmarshal(data, &cfg); err != nil {
+	// Fields missing from the file, e.g. added since it was written, keep
+	// their default; a field set to zero stays zero
+	cfg := Default()
+	if err := json.Unmarshal(data, cfg); err != nil {
 		return Default(), fmt.Errorf("failed to parse config: %w", err)
 	}
 
-	// Validate and apply defaults for any missing fields
+	// Validate and apply defaults for any fields left empty
 	cfg.ApplyDefaults()
 	
 	if err := cfg.Validate(); err != nil {
@@ -77,7 +93,7 @@ func Load(path string) (*Config, error) {
 		return Default(), fmt.Errorf("config validation failed: %w", err)
 	}
 
-	return &cfg, nil
+	return cfg, nil
 }
 
 // Save saves the configuration to the specified path.
@@ -93,7 +109,7 @@ func (c *Config) Save(path string) error {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := safefile.WriteFile(path, data, 0644); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
 
@@ -112,6 +128,16 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid theme: %s (must be dark, light, or custom)", c.Theme)
 	}
 
+	if c.BackupCount < 0 {
+		return fmt.Errorf("invalid backup count: %d", c.BackupCount)
+	}
+	if c.AutoSaveMinutes < 0 {
+		return fmt.Errorf("invalid autosave interval: %d minutes", c.AutoSaveMinutes)
+	}
+	if c.BackupMaxAgeDays < 0 {
+		return fmt.Errorf("invalid backup age limit: %d days", c.BackupMaxAgeDays)
+	}
+
 	// Validate save directory exists or can be created
 	if c.SaveDirectory != "" {
 		if err := os.MkdirAll(c.SaveDirectory, 0755); err != nil {
@@ -133,6 +159,18 @@ func (c *Config) ApplyDefaults() {
 	if c.SaveDirectory == "" {
 		c.SaveDirectory = defaults.SaveDirectory
 	}
+	if c.Ruleset == "" {
+		c.Ruleset = defaults.Ruleset
+	}
+}
+
+// AutoSaveInterval returns the time between background saves, or 0 if the
+// character is only saved in the background after key events.
+func (c *Config) AutoSaveInterval() time.Duration {
+	if !c.AutoSave {
+		return 0
+	}
+	return time.Duration(c.AutoSaveMinutes) * time.Minute
 }
 
 // GetConfigPath returns the default configuration file path.
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeConfig writes a config file and returns its path. The default save
+// directory is moved to a temporary home.
+func writeConfig(t *testing.T, data string) string {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestLoadAutoSaveMinutes verifies a config written before the autosave
+// interval existed gets the default interval, and an explicit 0 stays off.
+func TestLoadAutoSaveMinutes(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want int
+	}{
+		{"Missing", `{"theme": "dark", "auto_save": true}`, Default().AutoSaveMinutes},
+		{"Off", `{"theme": "dark", "auto_save": true, "auto_save_minutes": 0}`, 0},
+		{"Set", `{"theme": "dark", "auto_save": true, "auto_save_minutes": 15}`, 15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Load(writeConfig(t, tt.data))
+			if err != nil {
+				t.Fatalf("Load() unexpected error: %v", err)
+			}
+			if cfg.AutoSaveMinutes != tt.want {
+				t.Errorf("AutoSaveMinutes = %d; want %d", cfg.AutoSaveMinutes, tt.want)
+			}
+		})
+	}
+}
+
+// TestLoadBackupLimits verifies each backup limit missing from a config
+// gets its default on its own, and a limit set to 0 is kept.
+func TestLoadBackupLimits(t *testing.T) {
+	defaults := Default()
+	tests := []struct {
+		name      string
+		data      string
+		wantCount int
+		wantAge   int
+	}{
+		{"Missing", `{"theme": "dark"}`, defaults.BackupCount, defaults.BackupMaxAgeDays},
+		{"By age only", `{"theme": "dark", "backup_count": 0, "backup_max_age_days": 7}`, 0, 7},
+		{"Count only set", `{"theme": "dark", "backup_count": 3}`, 3, defaults.BackupMaxAgeDays},
+		{"Age only set", `{"theme": "dark", "backup_max_age_days": 0}`, defaults.BackupCount, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Load(writeConfig(t, tt.data))
+			if err != nil {
+				t.Fatalf("Load() unexpected error: %v", err)
+			}
+			if cfg.BackupCount != tt.wantCount || cfg.BackupMaxAgeDays != tt.wantAge {
+				t.Errorf("backups = %d kept, %d days; want %d, %d", cfg.BackupCount, cfg.BackupMaxAgeDays, tt.wantCount, tt.wantAge)
+			}
+		})
+	}
+}
@@ -26,6 +26,36 @@ type Roller interface {
 	SetSeed(seed int64)
 }
 
+// SidedRoller is implemented by rollers that can throw dice other than d6,
+// for dice expressions such as 1d20.
+type SidedRoller interface {
+	// CanRollDie reports whether a die with this many sides can be rolled.
+	CanRollDie(sides int) bool
+
+	// RollDie rolls one die and returns the result (1-sides).
+	RollDie(sides int) int
+}
+
+// CanRollDie returns true if roller can throw a die with this many sides.
+// Every roller can throw a d6.
+func CanRollDie(roller Roller, sides int) bool {
+	if sides == 6 {
+		return true
+	}
+	s, ok := roller.(SidedRoller)
+	return ok && s.CanRollDie(sides)
+}
+
+// RollDie rolls one die with roller. A d6 is rolled with Roll1D6 so that
+// labels, recording and physical dice apply; other dice need a SidedRoller,
+// check with CanRollDie first.
+func RollDie(roller Roller, sides int) int {
+	if sides == 6 {
+		return roller.Roll1D6()
+	}
+	return roller.(SidedRoller).RollDie(sides)
+}
+
 // StandardRoller implements the Roller interface using Go's math/rand.
 type StandardRoller struct {
 	rng *rand.Rand
@@ -69,6 +99,16 @@ func (r *StandardRoller) SetSeed(seed int64) {
 	r.rng = rand.New(rand.NewSource(seed))
 }
 
+// CanRollDie returns true for any die with at least two sides.
+func (r *StandardRoller) CanRollDie(sides int) bool {
+	return sides >= 2
+}
+
+// RollDie rolls one die with the specified number of sides (1-sides).
+func (r *StandardRoller) RollDie(sides int) int {
+	return r.rollDie(sides)
+}
+
 // rollDie is a helper function that rolls a single die with the specified number of sides.
 // Returns a value from 1 to sides (inclusive).
 func (r *StandardRoller) rollDie(sides int) int {
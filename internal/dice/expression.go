@@ -0,0 +1,451 @@
+package dice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Limits on dice expressions, to keep rolls readable.
+const (
+	MaxDice  = 100  // Dice in one group, e.g. 100d6
+	MaxSides = 1000 // Sides on one die
+)
+
+// Values that may appear in an expression, e.g. 2d6*10<=LCK.
+var expressionNames = map[string]bool{
+	"STR": true, "SPD": true, "STA": true, "CRG": true, "LCK": true, "CHM": true, "ATT": true,
+	"SKL": true, "LP": true, "POW": true,
+}
+
+// Expression is a parsed dice expression such as "2d6*10<=LCK" or
+// "4d6kh3+2". It supports NdM dice (N defaults to 1), keep-highest with
+// kN or khN, whole numbers, the character values STR, SPD, STA, CRG, LCK,
+// CHM, ATT, SKL, LP and POW, the operators + - * × / (dividing rounds down),
+// parentheses, and one comparison (< <= = >= > ≤ ≥) that turns the roll
+// into a test.
+type Expression struct {
+	source     string
+	left       exprNode
+	comparison string // "" when the expression is not a test
+	right      exprNode
+}
+
+// DiceGroup is the outcome of one NdM term of an expression.
+type DiceGroup struct {
+	Count int    // Dice thrown
+	Sides int    // Sides per die
+	Keep  int    // Highest dice kept (Count when all are kept)
+	Rolls []int  // Each die, in the order thrown
+	Kept  []bool // Whether each die counted towards the total
+	Total int    // Sum of the kept dice
+}
+
+// ExpressionResult is the outcome of rolling an expression.
+type ExpressionResult struct {
+	Expression string      // Expression as typed
+	Groups     []DiceGroup // Every dice term, left to right
+	Total      int         // Value of the roll (left of any comparison)
+	IsTest     bool        // Whether the expression had a comparison
+	Comparison string      // The comparison operator, normalised (<, <=, =, >=, >)
+	Target     int         // Value of the right-hand side of a test
+	Success    bool        // Whether the test passed
+}
+
+// String describes the result, e.g. "2d6*10<=LCK: [3 5] → 80 vs 56 FAIL".
+func (r ExpressionResult) String() string {
+	var b strings.Builder
+	b.WriteString(r.Expression + ":")
+	for _, group := range r.Groups {
+		dice := make([]string, len(group.Rolls))
+		for i, roll := range group.Rolls {
+			dice[i] = strconv.Itoa(roll)
+			if !group.Kept[i] {
+				dice[i] = "(" + dice[i] + ")"
+			}
+		}
+		b.WriteString(" [" + strings.Join(dice, " ") + "]")
+	}
+	b.WriteString(fmt.Sprintf(" → %d", r.Total))
+	if r.IsTest {
+		outcome := "FAIL"
+		if r.Success {
+			outcome = "PASS"
+		}
+		b.WriteString(fmt.Sprintf(" %s %d %s", r.Comparison, r.Target, outcome))
+	}
+	return b.String()
+}
+
+// ParseExpression parses a dice expression.
+func ParseExpression(source string) (*Expression, error) {
+	p := &exprParser{input: []rune(strings.TrimSpace(source))}
+	if len(p.input) == 0 {
+		return nil, fmt.Errorf("enter a dice expression such as 2d6")
+	}
+
+	left, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	expr := &Expression{source: string(p.input), left: left}
+
+	if comparison := p.parseComparison(); comparison != "" {
+		expr.comparison = comparison
+		if expr.right, err = p.parseSum(); err != nil {
+			return nil, err
+		}
+	}
+
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("unexpected %q at position %d", string(p.input[p.pos]), p.pos+1)
+	}
+	return expr, nil
+}
+
+// String returns the expression as typed.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// IsTest returns true when the expression compares the roll to a target.
+func (e *Expression) IsTest() bool {
+	return e.comparison != ""
+}
+
+// Roll rolls the expression. Character values are looked up in values by
+// their upper-case names; d6 are rolled with Roll1D6 so labels, recording
+// and physical dice apply, other dice need a SidedRoller.
+func (e *Expression) Roll(roller Roller, values map[string]int) (ExpressionResult, error) {
+	ctx := &exprContext{roller: roller, values: values}
+	result := ExpressionResult{Expression: e.source}
+
+	total, err := e.left.eval(ctx)
+	if err != nil {
+		return ExpressionResult{}, err
+	}
+	result.Total = total
+
+	if e.comparison != "" {
+		target, err := e.right.eval(ctx)
+		if err != nil {
+			return ExpressionResult{}, err
+		}
+		result.IsTest = true
+		result.Comparison = e.comparison
+		result.Target = target
+		result.Success = compare(total, e.comparison, target)
+	}
+
+	result.Groups = ctx.groups
+	return result, nil
+}
+
+// RollExpression parses and rolls an expression in one go.
+func RollExpression(source string, roller Roller, values map[string]int) (ExpressionResult, error) {
+	expr, err := ParseExpression(source)
+	if err != nil {
+		return ExpressionResult{}, err
+	}
+	return expr.Roll(roller, values)
+}
+
+// compare applies a normalised comparison operator.
+func compare(a int, op string, b int) bool {
+	switch op {
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case "=":
+		return a == b
+	case ">=":
+		return a >= b
+	case ">":
+		return a > b
+	}
+	return false
+}
+
+// exprContext carries what evaluation needs and collects the dice thrown.
+type exprContext struct {
+	roller Roller
+	values map[string]int
+	groups []DiceGroup
+}
+
+// exprNode is a node of a parsed expression.
+type exprNode interface {
+	eval(ctx *exprContext) (int, error)
+}
+
+// numberNode is a whole number.
+type numberNode int
+
+func (n numberNode) eval(*exprContext) (int, error) {
+	return int(n), nil
+}
+
+// nameNode is a character value such as LCK.
+type nameNode string
+
+func (n nameNode) eval(ctx *exprContext) (int, error) {
+	value, ok := ctx.values[string(n)]
+	if !ok {
+		return 0, fmt.Errorf("%s needs a character to be loaded", string(n))
+	}
+	return value, nil
+}
+
+// diceNode is an NdM term, optionally keeping the highest dice.
+type diceNode struct {
+	count, sides, keep int
+}
+
+func (n diceNode) eval(ctx *exprContext) (int, error) {
+	if n.sides != 6 && !CanRollDie(ctx.roller, n.sides) {
+		return 0, fmt.Errorf("only d6 can be rolled here, not d%d", n.sides)
+	}
+
+	group := DiceGroup{Count: n.count, Sides: n.sides, Keep: n.keep}
+	for i := 0; i < n.count; i++ {
+		group.Rolls = append(group.Rolls, RollDie(ctx.roller, n.sides))
+	}
+
+	// Keep the highest dice, the earliest first on ties
+	group.Kept = make([]bool, n.count)
+	for kept := 0; kept < n.keep; kept++ {
+		best := -1
+		for i, roll := range group.Rolls {
+			if !group.Kept[i] && (best < 0 || roll > group.Rolls[best]) {
+				best = i
+			}
+		}
+		group.Kept[best] = true
+		group.Total += group.Rolls[best]
+	}
+
+	ctx.groups = append(ctx.groups, group)
+	return group.Total, nil
+}
+
+// binaryNode applies an arithmetic operator.
+type binaryNode struct {
+	op          rune
+	left, right exprNode
+}
+
+func (n binaryNode) eval(ctx *exprContext) (int, error) {
+	left, err := n.left.eval(ctx)
+	if err != nil {
+		return 0, err
+	}
+	right, err := n.right.eval(ctx)
+	if err != nil {
+		return 0, err
+	}
+	switch n.op {
+	case '+':
+		return left + right, nil
+	case '-':
+		return left - right, nil
+	case '*':
+		return left * right, nil
+	default:
+		if right == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return left / right, nil
+	}
+}
+
+// exprParser is a recursive descent parser over the expression's runes.
+type exprParser struct {
+	input []rune
+	pos   int
+}
+
+// peek returns the next non-space rune, or 0 at the end.
+func (p *exprParser) peek() rune {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+// skipSpaces moves past spaces.
+func (p *exprParser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+// parseSum parses terms joined by + and -.
+func (p *exprParser) parseSum() (exprNode, error) {
+	node, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if op != '+' && op != '-' {
+			return node, nil
+		}
+		p.pos++
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		node = binaryNode{op: op, left: node, right: right}
+	}
+}
+
+// parseProduct parses factors joined by *, ×, x and /.
+func (p *exprParser) parseProduct() (exprNode, error) {
+	node, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		switch op {
+		case '*', '×', 'x', 'X':
+			op = '*'
+		case '/', '÷':
+			op = '/'
+		default:
+			return node, nil
+		}
+		p.pos++
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		node = binaryNode{op: op, left: node, right: right}
+	}
+}
+
+// parseFactor parses a number, dice, a character value, a negation or a
+// parenthesised sum.
+func (p *exprParser) parseFactor() (exprNode, error) {
+	r := p.peek()
+	switch {
+	case r == 0:
+		return nil, fmt.Errorf("expression ends too early")
+	case r == '(':
+		p.pos++
+		node, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("missing )")
+		}
+		p.pos++
+		return node, nil
+	case r == '-':
+		p.pos++
+		node, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return binaryNode{op: '-', left: numberNode(0), right: node}, nil
+	case isDigit(r):
+		n := p.readNumber()
+		if p.atDice() {
+			return p.parseDice(n)
+		}
+		return numberNode(n), nil
+	case p.atDice():
+		return p.parseDice(1)
+	case unicode.IsLetter(r):
+		start := p.pos
+		for p.pos < len(p.input) && unicode.IsLetter(p.input[p.pos]) {
+			p.pos++
+		}
+		name := strings.ToUpper(string(p.input[start:p.pos]))
+		if !expressionNames[name] {
+			return nil, fmt.Errorf("unknown value %q (use STR, SPD, STA, CRG, LCK, CHM, ATT, SKL, LP or POW)", name)
+		}
+		return nameNode(name), nil
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", string(r), p.pos+1)
+}
+
+// atDice returns true when the input continues with "d" and a digit.
+func (p *exprParser) atDice() bool {
+	return p.pos+1 < len(p.input) && (p.input[p.pos] == 'd' || p.input[p.pos] == 'D') &&
+		isDigit(p.input[p.pos+1])
+}
+
+// parseDice parses the "dM" and optional "kN"/"khN" after a dice count.
+func (p *exprParser) parseDice(count int) (exprNode, error) {
+	p.pos++ // d
+	sides := p.readNumber()
+	keep := count
+
+	if p.pos < len(p.input) && (p.input[p.pos] == 'k' || p.input[p.pos] == 'K') {
+		p.pos++
+		if p.pos < len(p.input) && (p.input[p.pos] == 'h' || p.input[p.pos] == 'H') {
+			p.pos++
+		}
+		if p.pos >= len(p.input) || !isDigit(p.input[p.pos]) {
+			return nil, fmt.Errorf("keep-highest needs a number, e.g. 4d6kh3")
+		}
+		keep = p.readNumber()
+	}
+
+	switch {
+	case count < 1 || count > MaxDice:
+		return nil, fmt.Errorf("roll between 1 and %d dice, not %d", MaxDice, count)
+	case sides < 2 || sides > MaxSides:
+		return nil, fmt.Errorf("dice have between 2 and %d sides, not %d", MaxSides, sides)
+	case keep < 1 || keep > count:
+		return nil, fmt.Errorf("keep between 1 and %d dice, not %d", count, keep)
+	}
+	return diceNode{count: count, sides: sides, keep: keep}, nil
+}
+
+// readNumber reads a run of digits, capped to stay well within int range.
+func (p *exprParser) readNumber() int {
+	n := 0
+	for p.pos < len(p.input) && isDigit(p.input[p.pos]) {
+		if n < 1_000_000 {
+			n = n*10 + int(p.input[p.pos]-'0')
+		}
+		p.pos++
+	}
+	return n
+}
+
+// isDigit returns true for the ASCII digits 0 to 9; other scripts' digits
+// would not convert to numbers by subtracting '0'.
+func isDigit(r rune) bool {
+	return '0' <= r && r <= '9'
+}
+
+// parseComparison reads a comparison operator, normalised, or "".
+func (p *exprParser) parseComparison() string {
+	switch p.peek() {
+	case '≤':
+		p.pos++
+		return "<="
+	case '≥':
+		p.pos++
+		return ">="
+	case '<', '>', '=':
+		op := string(p.input[p.pos])
+		p.pos++
+		if p.pos < len(p.input) && p.input[p.pos] == '=' {
+			p.pos++
+			if op != "=" {
+				op += "="
+			}
+		}
+		return op
+	}
+	return ""
+}
@@ -0,0 +1,148 @@
+package dice
+
+import (
+	"strings"
+	"testing"
+)
+
+// fixedDice returns a roller that throws the given d6 in order.
+func fixedDice(dice ...int) Roller {
+	rolls := make([]RecordedRoll, len(dice))
+	for i, die := range dice {
+		rolls[i] = RecordedRoll{Seq: i + 1, Kind: Kind1D6, Value: die}
+	}
+	return NewReplayRoller(rolls, NewSeededRoller(1))
+}
+
+// TestRollExpression verifies arithmetic, keep-highest and tests.
+func TestRollExpression(t *testing.T) {
+	values := map[string]int{"LCK": 56, "SKL": 2}
+
+	tests := []struct {
+		expr    string
+		dice    []int
+		total   int
+		isTest  bool
+		target  int
+		success bool
+	}{
+		{"2d6", []int{3, 4}, 7, false, 0, false},
+		{"d6", []int{5}, 5, false, 0, false},
+		{"1d6×10", []int{4}, 40, false, 0, false},
+		{"1d6 x 10", []int{4}, 40, false, 0, false},
+		{"2d6+3-1", []int{6, 6}, 14, false, 0, false},
+		{"(1d6+1)*2", []int{2}, 6, false, 0, false},
+		{"7/2", nil, 3, false, 0, false},
+		{"4d6kh3", []int{1, 5, 3, 6}, 14, false, 0, false},
+		{"3d6k1", []int{2, 2, 1}, 2, false, 0, false},
+		{"2d6*10<=LCK", []int{2, 3}, 50, true, 56, true},
+		{"2d6*10 ≤ lck", []int{3, 3}, 60, true, 56, false},
+		{"2d6+SKL>=7", []int{2, 3}, 7, true, 7, true},
+		{"1d6=6", []int{6}, 6, true, 6, true},
+		{"-1d6", []int{4}, -4, false, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			result, err := RollExpression(tt.expr, fixedDice(tt.dice...), values)
+			if err != nil {
+				t.Fatalf("RollExpression() unexpected error: %v", err)
+			}
+			if result.Total != tt.total || result.IsTest != tt.isTest ||
+				result.Target != tt.target || result.Success != tt.success {
+				t.Errorf("RollExpression() = %+v, want total %d, test %v, target %d, success %v",
+					result, tt.total, tt.isTest, tt.target, tt.success)
+			}
+		})
+	}
+}
+
+// TestRollExpressionGroups verifies each die is reported with whether it was kept.
+func TestRollExpressionGroups(t *testing.T) {
+	result, err := RollExpression("4d6kh3+1d6", fixedDice(1, 5, 3, 5, 2), nil)
+	if err != nil {
+		t.Fatalf("RollExpression() unexpected error: %v", err)
+	}
+	if len(result.Groups) != 2 {
+		t.Fatalf("Groups = %d, want 2", len(result.Groups))
+	}
+	kept := result.Groups[0].Kept
+	if kept[0] || !kept[1] || !kept[2] || !kept[3] || result.Groups[0].Total != 13 {
+		t.Errorf("first group = %+v, want the 1 dropped and a total of 13", result.Groups[0])
+	}
+	if got, want := result.String(), "4d6kh3+1d6: [(1) 5 3 5] [2] → 15"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+// TestParseExpressionErrors verifies malformed expressions are rejected.
+func TestParseExpressionErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"", "enter a dice expression"},
+		{"2d6+", "ends too early"},
+		{"(2d6", "missing )"},
+		{"2d6 3", "unexpected"},
+		{"0d6", "between 1 and 100 dice"},
+		{"101d6", "between 1 and 100 dice"},
+		{"1d1", "between 2 and 1000 sides"},
+		{"2d6kh3", "keep between 1 and 2"},
+		{"4d6k", "keep-highest needs a number"},
+		{"2d6<=FOO", "unknown value"},
+		{"2d6?", "unexpected"},
+		{"٣d6", "unexpected"},
+		{"2d٦", "unexpected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseExpression(tt.expr)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseExpression(%q) error = %v, want %q", tt.expr, err, tt.want)
+			}
+		})
+	}
+}
+
+// TestRollExpressionErrors verifies errors found while rolling.
+func TestRollExpressionErrors(t *testing.T) {
+	if _, err := RollExpression("2d6<=LCK", fixedDice(1, 1), nil); err == nil {
+		t.Error("RollExpression() expected error without a character")
+	}
+	if _, err := RollExpression("6/(1d6-1d6)", fixedDice(3, 3), nil); err == nil {
+		t.Error("RollExpression() expected error for division by zero")
+	}
+
+	manual := NewManualRoller(NewSeededRoller(1))
+	manual.SetEnabled(true)
+	if _, err := RollExpression("1d20", manual, nil); err == nil {
+		t.Error("RollExpression() expected error for a d20 with physical dice")
+	}
+	manual.SetEnabled(false)
+	result, err := RollExpression("1d20", manual, nil)
+	if err != nil || result.Total < 1 || result.Total > 20 {
+		t.Errorf("RollExpression(1d20) = %d, %v; want 1-20", result.Total, err)
+	}
+}
+
+// TestRecordingRollerOtherDice verifies dice other than d6 are recorded and replayed.
+func TestRecordingRollerOtherDice(t *testing.T) {
+	var file strings.Builder
+	recorder := NewRecordingRoller(NewSeededRoller(7), &file)
+	first, err := RollExpression("3d20", recorder, nil)
+	if err != nil {
+		t.Fatalf("RollExpression() unexpected error: %v", err)
+	}
+
+	rolls, err := ReadSession(strings.NewReader(file.String()))
+	if err != nil || len(rolls) != 3 || rolls[0].Kind != "d20" {
+		t.Fatalf("ReadSession() = %v, %v; want three d20 rolls", rolls, err)
+	}
+	replay := NewReplayRoller(rolls, NewSeededRoller(1))
+	second, _ := RollExpression("3d20", replay, nil)
+	if second.Total != first.Total || replay.Err() != nil {
+		t.Errorf("replayed total = %d (err %v), want %d", second.Total, replay.Err(), first.Total)
+	}
+}
@@ -0,0 +1,85 @@
+package dice
+
+import "math"
+
+// History keeps the expression rolls of a session, oldest first.
+type History struct {
+	results []ExpressionResult
+}
+
+// HistoryStats summarises the rolls in a History.
+type HistoryStats struct {
+	Rolls     int     // Expressions rolled
+	Mean      float64 // Average total
+	StdDev    float64 // Standard deviation of the totals
+	Min       int     // Lowest total
+	Max       int     // Highest total
+	Tests     int     // Rolls with a comparison
+	Successes int     // Tests passed
+	Dice      int     // d6 thrown, kept or not
+	Faces     [7]int  // How often each d6 face came up, indexed 1-6
+}
+
+// Add appends a roll to the history.
+func (h *History) Add(result ExpressionResult) {
+	h.results = append(h.results, result)
+}
+
+// Results returns the rolls, oldest first.
+func (h *History) Results() []ExpressionResult {
+	return h.results
+}
+
+// Len returns the number of rolls.
+func (h *History) Len() int {
+	return len(h.results)
+}
+
+// Last returns the most recent roll, if any.
+func (h *History) Last() (ExpressionResult, bool) {
+	if len(h.results) == 0 {
+		return ExpressionResult{}, false
+	}
+	return h.results[len(h.results)-1], true
+}
+
+// Stats computes the distribution of the rolls so far.
+func (h *History) Stats() HistoryStats {
+	var stats HistoryStats
+	if len(h.results) == 0 {
+		return stats
+	}
+
+	stats.Rolls = len(h.results)
+	stats.Min, stats.Max = h.results[0].Total, h.results[0].Total
+	sum := 0
+	for _, result := range h.results {
+		sum += result.Total
+		stats.Min = min(stats.Min, result.Total)
+		stats.Max = max(stats.Max, result.Total)
+		if result.IsTest {
+			stats.Tests++
+			if result.Success {
+				stats.Successes++
+			}
+		}
+		for _, group := range result.Groups {
+			if group.Sides != 6 {
+				continue
+			}
+			for _, roll := range group.Rolls {
+				stats.Dice++
+				stats.Faces[roll]++
+			}
+		}
+	}
+
+	stats.Mean = float64(sum) / float64(stats.Rolls)
+	variance := 0.0
+	for _, result := range h.results {
+		diff := float64(result.Total) - stats.Mean
+		variance += diff * diff
+	}
+	stats.StdDev = math.Sqrt(variance / float64(stats.Rolls))
+	return stats
+}
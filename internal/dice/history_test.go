@@ -0,0 +1,34 @@
+package dice
+
+import "testing"
+
+// TestHistoryStats verifies the distribution of the rolls in a history.
+func TestHistoryStats(t *testing.T) {
+	var history History
+	if stats := history.Stats(); stats.Rolls != 0 {
+		t.Errorf("empty Stats() = %+v, want no rolls", stats)
+	}
+
+	roller := fixedDice(2, 6, 6, 6, 1)
+	for _, expr := range []string{"2d6", "2d6*10<=LCK", "1d6>=4"} {
+		result, err := RollExpression(expr, roller, map[string]int{"LCK": 56})
+		if err != nil {
+			t.Fatalf("RollExpression(%q) unexpected error: %v", expr, err)
+		}
+		history.Add(result)
+	}
+
+	stats := history.Stats()
+	if stats.Rolls != 3 || stats.Min != 1 || stats.Max != 120 || stats.Mean != 43 {
+		t.Errorf("Stats() = %+v, want 3 rolls from 1 to 120 averaging 43", stats)
+	}
+	if stats.Tests != 2 || stats.Successes != 0 {
+		t.Errorf("Stats() tests = %d/%d, want 0 of 2 passed", stats.Successes, stats.Tests)
+	}
+	if stats.Dice != 5 || stats.Faces[6] != 3 || stats.Faces[1] != 1 || stats.Faces[3] != 0 {
+		t.Errorf("Stats() faces = %v over %d dice, want three 6s and one 1 over 5", stats.Faces, stats.Dice)
+	}
+	if last, ok := history.Last(); !ok || last.Expression != "1d6>=4" {
+		t.Errorf("Last() = %v, %v; want the 1d6>=4 roll", last, ok)
+	}
+}
@@ -0,0 +1,188 @@
+package dice
+
+import "fmt"
+
+// DiceRequest describes a roll the player has to make with physical dice.
+type DiceRequest struct {
+	Kind  string // Kind1D6, Kind2D6 or KindCharacteristic
+	Label string // What the roll is for, if known
+	Dice  int    // Number of dice to throw
+}
+
+// ManualRoller lets the player roll real dice and type the results in.
+// It cannot wait for input itself, so an action is played in attempts:
+// call Begin, perform the action, then check Pending. If a roll was asked
+// for that has not been entered yet, the attempt used placeholder values
+// and must be undone; Enter the dice and play the action again from the
+// start. Once an attempt completes, call Finish; to abandon the action,
+// call Cancel. While disabled, every roll is made by the fallback roller.
+//
+// A fallback replaying a session (a Replayer) supplies its recorded rolls
+// before any dice are asked for, and one recording the session (a
+// RollNoter) is told of every roll of each completed action.
+type ManualRoller struct {
+	fallback Roller
+	enabled  bool
+	entered  []manualRoll
+	next     int
+	label    string
+	pending  *DiceRequest
+}
+
+// manualRoll is a roll entered for the action being played.
+type manualRoll struct {
+	kind  string
+	label string
+	total int // Sum of the dice
+}
+
+// NewManualRoller creates a manual roller that uses fallback while disabled.
+func NewManualRoller(fallback Roller) *ManualRoller {
+	return &ManualRoller{fallback: fallback}
+}
+
+// SetEnabled switches between typed-in dice and the fallback roller.
+func (r *ManualRoller) SetEnabled(enabled bool) {
+	r.enabled = enabled
+	r.Cancel()
+}
+
+// Enabled returns true when dice results are typed in.
+func (r *ManualRoller) Enabled() bool {
+	return r.enabled
+}
+
+// Roll2D6 returns the sum of the next two dice entered.
+func (r *ManualRoller) Roll2D6() int {
+	if !r.enabled {
+		return r.fallback.Roll2D6()
+	}
+	return r.take(Kind2D6, 2)
+}
+
+// Roll1D6 returns the next die entered.
+func (r *ManualRoller) Roll1D6() int {
+	if !r.enabled {
+		return r.fallback.Roll1D6()
+	}
+	return r.take(Kind1D6, 1)
+}
+
+// RollCharacteristic returns the sum of the next two dice entered × 8.
+func (r *ManualRoller) RollCharacteristic() int {
+	if !r.enabled {
+		return r.fallback.RollCharacteristic()
+	}
+	return r.take(KindCharacteristic, 2) * 8
+}
+
+// CanRollDie reports whether a die other than a d6 can be rolled: only
+// while disabled, as physical dice are entered as 1-6.
+func (r *ManualRoller) CanRollDie(sides int) bool {
+	return sides == 6 || (!r.enabled && CanRollDie(r.fallback, sides))
+}
+
+// RollDie rolls one die with the fallback roller, or takes a typed d6.
+func (r *ManualRoller) RollDie(sides int) int {
+	if sides == 6 {
+		return r.Roll1D6()
+	}
+	return RollDie(r.fallback, sides)
+}
+
+// SetSeed reseeds the fallback roller.
+func (r *ManualRoller) SetSeed(seed int64) {
+	r.fallback.SetSeed(seed)
+}
+
+// SetLabel names the purpose of the next roll.
+func (r *ManualRoller) SetLabel(label string) {
+	if !r.enabled {
+		Label(r.fallback, label)
+		return
+	}
+	r.label = label
+}
+
+// Begin starts an attempt at an action, reusing the dice entered so far.
+func (r *ManualRoller) Begin() {
+	r.next = 0
+	r.pending = nil
+	r.label = ""
+}
+
+// Pending returns the first roll of the attempt that still needs dice,
+// or nil if every roll was covered.
+func (r *ManualRoller) Pending() *DiceRequest {
+	return r.pending
+}
+
+// Enter supplies the dice for the pending roll.
+func (r *ManualRoller) Enter(dice []int) error {
+	if r.pending == nil {
+		return fmt.Errorf("no roll is waiting for dice")
+	}
+	if len(dice) != r.pending.Dice {
+		return fmt.Errorf("enter %d dice, got %d", r.pending.Dice, len(dice))
+	}
+	total := 0
+	for _, die := range dice {
+		if die < 1 || die > 6 {
+			return fmt.Errorf("a die shows 1 to 6, not %d", die)
+		}
+		total += die
+	}
+	r.entered = append(r.entered, manualRoll{kind: r.pending.Kind, label: r.pending.Label, total: total})
+	r.pending = nil
+	return nil
+}
+
+// Finish completes an action: its rolls are passed on to a fallback
+// recording the session, and the dice entered are forgotten.
+func (r *ManualRoller) Finish() {
+	if noter, ok := r.fallback.(RollNoter); ok && r.pending == nil {
+		for _, roll := range r.entered[:r.next] {
+			value := roll.total
+			if roll.kind == KindCharacteristic {
+				value *= 8
+			}
+			noter.NoteRoll(roll.kind, roll.label, value)
+		}
+	}
+	r.Cancel()
+}
+
+// Cancel forgets the dice entered for an action that was abandoned.
+func (r *ManualRoller) Cancel() {
+	r.entered = nil
+	r.Begin()
+}
+
+// take returns the sum of the dice of the next roll entered. When it has
+// not been entered yet, a replayed session supplies it if it can; if not,
+// the roll becomes pending and each die counts as 1.
+func (r *ManualRoller) take(kind string, count int) int {
+	label := r.label
+	r.label = ""
+	if r.pending == nil && r.next < len(r.entered) {
+		total := r.entered[r.next].total
+		r.next++
+		return total
+	}
+	if r.pending != nil {
+		return count
+	}
+
+	if replayer, ok := r.fallback.(Replayer); ok {
+		if value, ok := replayer.NextRecorded(kind, label); ok {
+			if kind == KindCharacteristic {
+				value /= 8
+			}
+			r.entered = append(r.entered, manualRoll{kind: kind, label: label, total: value})
+			r.next++
+			return value
+		}
+	}
+	r.pending = &DiceRequest{Kind: kind, Label: label, Dice: count}
+	return count
+}
@@ -0,0 +1,141 @@
+package dice
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// TestManualRoller verifies an action is replayed until every die is entered.
+func TestManualRoller(t *testing.T) {
+	roller := NewManualRoller(NewSeededRoller(1))
+	roller.SetEnabled(true)
+
+	// The action: a labelled 2d6 roll, then 1d6 if it was 7 or more
+	action := func() (int, int) {
+		Label(roller, LabelToHit)
+		toHit := roller.Roll2D6()
+		damage := 0
+		if toHit >= 7 {
+			damage = roller.Roll1D6()
+		}
+		return toHit, damage
+	}
+
+	roller.Begin()
+	action()
+	request := roller.Pending()
+	if request == nil || request.Kind != Kind2D6 || request.Label != LabelToHit || request.Dice != 2 {
+		t.Fatalf("Pending() = %+v, want 2 dice for the to-hit roll", request)
+	}
+	if err := roller.Enter([]int{3, 7}); err == nil {
+		t.Error("Enter() expected error for a 7")
+	}
+	if err := roller.Enter([]int{3}); err == nil {
+		t.Error("Enter() expected error for too few dice")
+	}
+	if err := roller.Enter([]int{3, 5}); err != nil {
+		t.Fatalf("Enter() unexpected error: %v", err)
+	}
+
+	roller.Begin()
+	action()
+	if request := roller.Pending(); request == nil || request.Kind != Kind1D6 {
+		t.Fatalf("Pending() = %+v, want 1 die for damage", request)
+	}
+	roller.Enter([]int{6})
+
+	roller.Begin()
+	toHit, damage := action()
+	if roller.Pending() != nil || toHit != 8 || damage != 6 {
+		t.Errorf("action = %d, %d with pending %+v; want 8, 6 and nothing pending", toHit, damage, roller.Pending())
+	}
+
+	roller.Finish()
+	roller.Begin()
+	action()
+	if roller.Pending() == nil {
+		t.Error("Finish() should forget the dice entered")
+	}
+}
+
+// TestManualRollerCharacteristic verifies characteristics are two dice × 8.
+func TestManualRollerCharacteristic(t *testing.T) {
+	roller := NewManualRoller(NewSeededRoller(1))
+	roller.SetEnabled(true)
+	roller.Begin()
+	roller.RollCharacteristic()
+	roller.Enter([]int{6, 5})
+	roller.Begin()
+	if got := roller.RollCharacteristic(); got != 88 {
+		t.Errorf("RollCharacteristic() = %d, want 88", got)
+	}
+
+	roller.SetEnabled(false)
+	roller.Begin()
+	if got := roller.RollCharacteristic(); got < 16 || got > 96 || roller.Pending() != nil {
+		t.Errorf("disabled RollCharacteristic() = %d, pending %+v; want a random roll", got, roller.Pending())
+	}
+}
+
+// TestManualRollerSession verifies typed dice are recorded once the action
+// completes, and a recorded session is replayed without asking for dice.
+func TestManualRollerSession(t *testing.T) {
+	var file bytes.Buffer
+	roller := NewManualRoller(NewRecordingRoller(NewSeededRoller(1), &file))
+	roller.SetEnabled(true)
+	action := func() int {
+		Label(roller, LabelToHit)
+		toHit := roller.Roll2D6()
+		return toHit + roller.RollCharacteristic()
+	}
+
+	// A cancelled action records nothing
+	roller.Begin()
+	action()
+	roller.Enter([]int{1, 1})
+	roller.Cancel()
+
+	for _, dice := range [][]int{{4, 5}, {6, 2}} {
+		roller.Begin()
+		action()
+		roller.Enter(dice)
+	}
+	roller.Begin()
+	if got := action(); got != 9+64 || roller.Pending() != nil {
+		t.Fatalf("action = %d with pending %+v; want 73", got, roller.Pending())
+	}
+	roller.Finish()
+
+	rolls, err := ReadSession(&file)
+	if err != nil {
+		t.Fatalf("ReadSession() unexpected error: %v", err)
+	}
+	want := []RecordedRoll{
+		{Seq: 1, Kind: Kind2D6, Label: LabelToHit, Value: 9},
+		{Seq: 2, Kind: KindCharacteristic, Value: 64},
+	}
+	if !reflect.DeepEqual(rolls, want) {
+		t.Fatalf("recorded %v; want %v", rolls, want)
+	}
+
+	// Replayed with physical dice on, the session needs no dice typed in
+	replay := NewReplayRoller(rolls, NewSeededRoller(1))
+	roller = NewManualRoller(replay)
+	roller.SetEnabled(true)
+	roller.Begin()
+	if got := action(); got != 73 || roller.Pending() != nil {
+		t.Errorf("replayed action = %d with pending %+v; want 73 and nothing pending", got, roller.Pending())
+	}
+	roller.Finish()
+	if replay.Remaining() != 0 || replay.Err() != nil {
+		t.Errorf("Remaining() = %d, Err() = %v; want the session used up", replay.Remaining(), replay.Err())
+	}
+
+	// Once the session runs out, the dice are asked for again
+	roller.Begin()
+	action()
+	if roller.Pending() == nil {
+		t.Error("Pending() = nil after the session ran out; want the to-hit roll")
+	}
+}
@@ -0,0 +1,326 @@
+package dice
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Kinds of roll recorded in a session file.
+const (
+	Kind1D6            = "1d6"
+	Kind2D6            = "2d6"
+	KindCharacteristic = "characteristic"
+)
+
+// KindDie is the kind recorded for one die other than a d6, e.g. "d20".
+func KindDie(sides int) string {
+	return fmt.Sprintf("d%d", sides)
+}
+
+// Labels for the rolls the rules call for.
+const (
+	LabelInitiative         = "initiative"
+	LabelToHit              = "to-hit"
+	LabelEnemyToHit         = "enemy to-hit"
+	LabelDeathSave          = "death save"
+	LabelHealingStone       = "healing stone"
+	LabelOrbThrow           = "orb throw"
+	LabelNaturalInclination = "natural inclination"
+	LabelFFR                = "FFR"
+	LabelPoisonNeedle       = "poison needle"
+	LabelCharacteristicTest = "test"
+)
+
+// Labeler is implemented by rollers that note what a roll is for.
+// The label applies to the next roll only.
+type Labeler interface {
+	SetLabel(label string)
+}
+
+// Label names the purpose of the next roll (initiative, to-hit, FFR...)
+// when the roller keeps track of it. Other rollers ignore it.
+func Label(roller Roller, label string) {
+	if l, ok := roller.(Labeler); ok {
+		l.SetLabel(label)
+	}
+}
+
+// RollNoter is implemented by rollers recording a session, so rolls made
+// without them, such as physical dice typed in, are recorded too.
+type RollNoter interface {
+	NoteRoll(kind, label string, value int)
+}
+
+// Replayer is implemented by rollers replaying a session, so rolls that
+// would be made without them, such as physical dice, are replayed too.
+type Replayer interface {
+	// NextRecorded returns the next recorded roll and moves past it, if
+	// it is of the given kind and purpose.
+	NextRecorded(kind, label string) (int, bool)
+}
+
+// RecordedRoll is one roll in a session file.
+type RecordedRoll struct {
+	Seq   int    `json:"seq"`             // Position in the session, from 1
+	Kind  string `json:"kind"`            // Kind1D6, Kind2D6, KindCharacteristic or KindDie
+	Label string `json:"label,omitempty"` // What the roll was for
+	Value int    `json:"value"`           // Result of the roll
+}
+
+// String describes the roll, e.g. "#12 2d6 (to-hit) = 8".
+func (r RecordedRoll) String() string {
+	return fmt.Sprintf("#%d %s = %d", r.Seq, describeRoll(r.Kind, r.Label), r.Value)
+}
+
+// describeRoll names a kind of roll and its purpose, e.g. "2d6 (to-hit)".
+func describeRoll(kind, label string) string {
+	if label == "" {
+		return kind
+	}
+	return fmt.Sprintf("%s (%s)", kind, label)
+}
+
+// RecordingRoller wraps another Roller and writes every roll to a session
+// file as one JSON object per line, so a session can be audited or replayed.
+type RecordingRoller struct {
+	inner Roller
+	enc   *json.Encoder
+	label string
+	rolls []RecordedRoll
+	err   error
+}
+
+// NewRecordingRoller records the rolls made by inner to w.
+func NewRecordingRoller(inner Roller, w io.Writer) *RecordingRoller {
+	return &RecordingRoller{inner: inner, enc: json.NewEncoder(w)}
+}
+
+// Roll2D6 rolls with the wrapped roller and records the result.
+func (r *RecordingRoller) Roll2D6() int {
+	return r.record(Kind2D6, r.inner.Roll2D6())
+}
+
+// Roll1D6 rolls with the wrapped roller and records the result.
+func (r *RecordingRoller) Roll1D6() int {
+	return r.record(Kind1D6, r.inner.Roll1D6())
+}
+
+// RollCharacteristic rolls with the wrapped roller and records the result.
+func (r *RecordingRoller) RollCharacteristic() int {
+	return r.record(KindCharacteristic, r.inner.RollCharacteristic())
+}
+
+// CanRollDie reports whether the wrapped roller can throw this die.
+func (r *RecordingRoller) CanRollDie(sides int) bool {
+	return CanRollDie(r.inner, sides)
+}
+
+// RollDie rolls one die with the wrapped roller and records the result.
+func (r *RecordingRoller) RollDie(sides int) int {
+	if sides == 6 {
+		return r.Roll1D6()
+	}
+	return r.record(KindDie(sides), RollDie(r.inner, sides))
+}
+
+// SetSeed reseeds the wrapped roller.
+func (r *RecordingRoller) SetSeed(seed int64) {
+	r.inner.SetSeed(seed)
+}
+
+// SetLabel names the purpose of the next roll.
+func (r *RecordingRoller) SetLabel(label string) {
+	r.label = label
+	Label(r.inner, label)
+}
+
+// NoteRoll records a roll made without the wrapped roller.
+func (r *RecordingRoller) NoteRoll(kind, label string, value int) {
+	r.label = label
+	r.record(kind, value)
+}
+
+// NextRecorded replays the next roll if the wrapped roller replays a
+// session. The roll is recorded once it is used, through NoteRoll.
+func (r *RecordingRoller) NextRecorded(kind, label string) (int, bool) {
+	if replayer, ok := r.inner.(Replayer); ok {
+		return replayer.NextRecorded(kind, label)
+	}
+	return 0, false
+}
+
+// Rolls returns the rolls recorded so far.
+func (r *RecordingRoller) Rolls() []RecordedRoll {
+	return r.rolls
+}
+
+// Err returns the first error met writing the session file.
+func (r *RecordingRoller) Err() error {
+	return r.err
+}
+
+// record notes a roll and writes it out.
+func (r *RecordingRoller) record(kind string, value int) int {
+	roll := RecordedRoll{Seq: len(r.rolls) + 1, Kind: kind, Label: r.label, Value: value}
+	r.label = ""
+	r.rolls = append(r.rolls, roll)
+	if r.err == nil {
+		if err := r.enc.Encode(roll); err != nil {
+			r.err = fmt.Errorf("failed to record roll: %w", err)
+		}
+	}
+	return value
+}
+
+// ReadSession reads the rolls written by a RecordingRoller.
+func ReadSession(rd io.Reader) ([]RecordedRoll, error) {
+	rolls := []RecordedRoll{}
+	scanner := bufio.NewScanner(rd)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var roll RecordedRoll
+		if err := json.Unmarshal(scanner.Bytes(), &roll); err != nil {
+			return nil, fmt.Errorf("line %d: failed to parse roll: %w", line, err)
+		}
+		if !validRoll(roll) {
+			return nil, fmt.Errorf("line %d: invalid roll %s", line, roll)
+		}
+		rolls = append(rolls, roll)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+	return rolls, nil
+}
+
+// LoadSession reads a session file written by a RecordingRoller.
+func LoadSession(path string) ([]RecordedRoll, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open session: %w", err)
+	}
+	defer file.Close()
+	return ReadSession(file)
+}
+
+// validRoll checks that a recorded value could come from its kind of roll.
+func validRoll(roll RecordedRoll) bool {
+	switch roll.Kind {
+	case Kind1D6:
+		return roll.Value >= 1 && roll.Value <= 6
+	case Kind2D6:
+		return roll.Value >= 2 && roll.Value <= 12
+	case KindCharacteristic:
+		return roll.Value >= 16 && roll.Value <= 96 && roll.Value%8 == 0
+	}
+	var sides int
+	if _, err := fmt.Sscanf(roll.Kind, "d%d", &sides); err == nil && KindDie(sides) == roll.Kind {
+		return roll.Value >= 1 && roll.Value <= sides
+	}
+	return false
+}
+
+// ReplayRoller feeds back the rolls of a recorded session, in order.
+// Once the session runs out, or the game asks for a different roll than
+// was recorded, replay stops and the fallback roller takes over; Err
+// reports why.
+type ReplayRoller struct {
+	rolls    []RecordedRoll
+	next     int
+	fallback Roller
+	label    string
+	err      error
+}
+
+// NewReplayRoller replays rolls, then rolls with fallback.
+func NewReplayRoller(rolls []RecordedRoll, fallback Roller) *ReplayRoller {
+	return &ReplayRoller{rolls: rolls, fallback: fallback}
+}
+
+// Roll2D6 returns the next recorded 2d6 roll.
+func (r *ReplayRoller) Roll2D6() int {
+	return r.replay(Kind2D6, r.fallback.Roll2D6)
+}
+
+// Roll1D6 returns the next recorded 1d6 roll.
+func (r *ReplayRoller) Roll1D6() int {
+	return r.replay(Kind1D6, r.fallback.Roll1D6)
+}
+
+// RollCharacteristic returns the next recorded characteristic roll.
+func (r *ReplayRoller) RollCharacteristic() int {
+	return r.replay(KindCharacteristic, r.fallback.RollCharacteristic)
+}
+
+// CanRollDie reports whether the fallback roller can throw this die.
+func (r *ReplayRoller) CanRollDie(sides int) bool {
+	return CanRollDie(r.fallback, sides)
+}
+
+// RollDie returns the next recorded roll of this die.
+func (r *ReplayRoller) RollDie(sides int) int {
+	if sides == 6 {
+		return r.Roll1D6()
+	}
+	return r.replay(KindDie(sides), func() int { return RollDie(r.fallback, sides) })
+}
+
+// SetSeed reseeds the fallback roller.
+func (r *ReplayRoller) SetSeed(seed int64) {
+	r.fallback.SetSeed(seed)
+}
+
+// SetLabel names the purpose of the next roll, checked against the session.
+func (r *ReplayRoller) SetLabel(label string) {
+	r.label = label
+}
+
+// Remaining returns the number of recorded rolls not replayed yet.
+func (r *ReplayRoller) Remaining() int {
+	if r.err != nil {
+		return 0
+	}
+	return len(r.rolls) - r.next
+}
+
+// Err reports why replay stopped before the end of the session, if it did.
+func (r *ReplayRoller) Err() error {
+	return r.err
+}
+
+// replay returns the next recorded roll if it matches what is asked for,
+// and otherwise rolls with roll.
+func (r *ReplayRoller) replay(kind string, roll func() int) int {
+	label := r.label
+	r.label = ""
+	if value, ok := r.NextRecorded(kind, label); ok {
+		return value
+	}
+	return roll()
+}
+
+// NextRecorded returns the next recorded roll if it matches what is asked
+// for. Once the session runs out or diverges, it returns false.
+func (r *ReplayRoller) NextRecorded(kind, label string) (int, bool) {
+	if r.err != nil {
+		return 0, false
+	}
+	if r.next >= len(r.rolls) {
+		r.err = fmt.Errorf("session ran out after %d rolls", len(r.rolls))
+		return 0, false
+	}
+
+	recorded := r.rolls[r.next]
+	if recorded.Kind != kind || recorded.Label != label {
+		r.err = fmt.Errorf("session diverged at roll %d: recorded %s, game asked for %s",
+			recorded.Seq, describeRoll(recorded.Kind, recorded.Label), describeRoll(kind, label))
+		return 0, false
+	}
+	r.next++
+	return recorded.Value, true
+}
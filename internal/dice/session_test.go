@@ -0,0 +1,107 @@
+package dice
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestRecordingRoller verifies every roll is labelled and written to the session.
+func TestRecordingRoller(t *testing.T) {
+	var file bytes.Buffer
+	roller := NewRecordingRoller(NewSeededRoller(42), &file)
+
+	Label(roller, LabelInitiative)
+	first := roller.Roll2D6()
+	second := roller.Roll1D6()
+	Label(roller, "STR")
+	third := roller.RollCharacteristic()
+
+	rolls, err := ReadSession(&file)
+	if err != nil {
+		t.Fatalf("ReadSession() unexpected error: %v", err)
+	}
+	want := []RecordedRoll{
+		{Seq: 1, Kind: Kind2D6, Label: LabelInitiative, Value: first},
+		{Seq: 2, Kind: Kind1D6, Value: second}, // Labels apply to one roll only
+		{Seq: 3, Kind: KindCharacteristic, Label: "STR", Value: third},
+	}
+	if len(rolls) != len(want) {
+		t.Fatalf("ReadSession() = %d rolls, want %d", len(rolls), len(want))
+	}
+	for i := range want {
+		if rolls[i] != want[i] {
+			t.Errorf("roll %d = %v, want %v", i, rolls[i], want[i])
+		}
+	}
+	if len(roller.Rolls()) != 3 || roller.Err() != nil {
+		t.Errorf("Rolls() = %d, Err() = %v; want 3 rolls and no error", len(roller.Rolls()), roller.Err())
+	}
+}
+
+// TestReplayRoller verifies a session is played back, then the fallback takes over.
+func TestReplayRoller(t *testing.T) {
+	rolls := []RecordedRoll{
+		{Seq: 1, Kind: Kind2D6, Label: LabelToHit, Value: 12},
+		{Seq: 2, Kind: Kind1D6, Value: 1},
+	}
+
+	roller := NewReplayRoller(rolls, NewSeededRoller(1))
+	Label(roller, LabelToHit)
+	if got := roller.Roll2D6(); got != 12 {
+		t.Errorf("first roll = %d, want 12", got)
+	}
+	if got := roller.Roll1D6(); got != 1 {
+		t.Errorf("second roll = %d, want 1", got)
+	}
+	if roller.Remaining() != 0 || roller.Err() != nil {
+		t.Errorf("Remaining() = %d, Err() = %v; want 0 and no error", roller.Remaining(), roller.Err())
+	}
+	if got := roller.Roll1D6(); got < 1 || got > 6 {
+		t.Errorf("fallback roll = %d, want 1-6", got)
+	}
+	if roller.Err() == nil {
+		t.Error("Err() should report the session ran out")
+	}
+
+	tests := []struct {
+		name string
+		roll func(r *ReplayRoller)
+	}{
+		{"Different kind", func(r *ReplayRoller) { Label(r, LabelToHit); r.Roll1D6() }},
+		{"Different label", func(r *ReplayRoller) { Label(r, LabelDeathSave); r.Roll2D6() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roller := NewReplayRoller(rolls, NewSeededRoller(1))
+			tt.roll(roller)
+			if roller.Err() == nil || !strings.Contains(roller.Err().Error(), "diverged at roll 1") {
+				t.Errorf("Err() = %v, want divergence at roll 1", roller.Err())
+			}
+			if roller.Remaining() != 0 {
+				t.Error("replay should stop once the session diverges")
+			}
+		})
+	}
+}
+
+// TestReadSessionInvalid verifies impossible rolls are rejected.
+func TestReadSessionInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"Not JSON", "not json\n"},
+		{"Unknown kind", `{"seq":1,"kind":"3d6","value":9}` + "\n"},
+		{"Out of range", `{"seq":1,"kind":"2d6","value":13}` + "\n"},
+		{"Not a characteristic", `{"seq":1,"kind":"characteristic","value":20}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadSession(strings.NewReader(tt.data)); err == nil {
+				t.Error("ReadSession() expected error")
+			}
+		})
+	}
+}
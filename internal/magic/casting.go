@@ -3,7 +3,9 @@ package magic
 import (
 	"fmt"
 
+	"github.com/benoit/saga-demonspawn/internal/character"
 	"github.com/benoit/saga-demonspawn/internal/dice"
+	"github.com/benoit/saga-demonspawn/internal/rules"
 )
 
 // CastResult represents the outcome of a spell casting attempt.
@@ -13,15 +15,18 @@ type CastResult struct {
 	InsufficientPower bool   // Not enough POW
 	Message           string // Human-readable result message
 	PowerSpent        int    // Amount of POW consumed
+	Roll              int    // The Fundamental Failure Rate 2d6 roll
 	RequiresSacrifice bool   // Whether LP sacrifice is needed
 	SacrificeAmount   int    // Amount of LP to sacrifice for POW
 }
 
 // NaturalInclinationCheck performs the natural inclination check.
-// Returns true if Fire*Wolf overcomes his aversion to magic (roll >= 4).
-func NaturalInclinationCheck(roller dice.Roller) (bool, int) {
+// Returns true if Fire*Wolf overcomes his aversion to magic (roll >= 4
+// in the book; see rules.Ruleset.InclinationThreshold).
+func NaturalInclinationCheck(ruleset rules.Ruleset, roller dice.Roller) (bool, int) {
+	dice.Label(roller, dice.LabelNaturalInclination)
 	roll := roller.Roll2D6()
-	return roll >= 4, roll
+	return ruleset.InclinationPassed(roll), roll
 }
 
 // CanAffordSpell checks if character has enough POW for the spell.
@@ -43,19 +48,39 @@ func CanSacrificeLP(currentLP int, sacrificeAmount int) bool {
 }
 
 // FundamentalFailureRate performs the FFR check.
-// Returns true if spell succeeds (roll >= 6).
-func FundamentalFailureRate(roller dice.Roller) (bool, int) {
+// Returns true if spell succeeds (roll >= 6 in the book; see
+// rules.Ruleset.FFRThreshold).
+func FundamentalFailureRate(ruleset rules.Ruleset, roller dice.Roller) (bool, int) {
+	dice.Label(roller, dice.LabelFFR)
 	roll := roller.Roll2D6()
-	return roll >= 6, roll
+	return ruleset.FFRPassed(roll), roll
 }
 
 // ValidateCast checks if a spell can be cast given the current context.
-func ValidateCast(spell *Spell, currentPOW int, currentLP int, inCombat bool, isDead bool) CastResult {
+// The section magic state enforces the natural inclination check and the
+// rule that a spell is never cast twice in one section.
+func ValidateCast(spell *Spell, currentPOW int, currentLP int, inCombat bool, isDead bool, section character.SectionMagic) CastResult {
 	result := CastResult{
 		Success:   false,
 		FFRFailed: false,
 	}
 
+	// Check natural inclination for this section
+	if !section.InclinationChecked {
+		result.Message = "Roll the natural inclination check before using magic in this section"
+		return result
+	}
+	if !section.InclinationPassed {
+		result.Message = "Fire*Wolf refuses to use sorcery for the rest of this section"
+		return result
+	}
+
+	// Check repeated spell restriction
+	if section.HasCast(spell.Name) {
+		result.Message = fmt.Sprintf("%s has already been cast in this section", spell.Name)
+		return result
+	}
+
 	// Check death-only restriction (RESURRECTION)
 	if spell.DeathOnly && !isDead {
 		result.Message = "This spell can only be cast when you are dead"
@@ -96,17 +121,18 @@ func ValidateCast(spell *Spell, currentPOW int, currentLP int, inCombat bool, is
 
 // PerformCast executes the spell casting after validation.
 // Returns the result including FFR check outcome.
-func PerformCast(spell *Spell, roller dice.Roller) CastResult {
+func PerformCast(spell *Spell, ruleset rules.Ruleset, roller dice.Roller) CastResult {
 	result := CastResult{
 		PowerSpent: spell.PowerCost,
 	}
 
 	// Perform Fundamental Failure Rate check
-	ffrSuccess, ffrRoll := FundamentalFailureRate(roller)
+	ffrSuccess, ffrRoll := FundamentalFailureRate(ruleset, roller)
+	result.Roll = ffrRoll
 	if !ffrSuccess {
 		result.Success = false
 		result.FFRFailed = true
-		result.Message = fmt.Sprintf("The spell fizzles and fails! (rolled %d, needed 6+)", ffrRoll)
+		result.Message = fmt.Sprintf("The spell fizzles and fails! (rolled %d, needed %d+)", ffrRoll, ruleset.FFRThreshold)
 		return result
 	}
 
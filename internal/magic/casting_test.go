@@ -3,7 +3,9 @@ package magic
 import (
 	"testing"
 
+	"github.com/benoit/saga-demonspawn/internal/character"
 	"github.com/benoit/saga-demonspawn/internal/dice"
+	"github.com/benoit/saga-demonspawn/internal/rules"
 )
 
 // TestNaturalInclinationCheck verifies the natural inclination check mechanics.
@@ -20,7 +22,7 @@ func TestNaturalInclinationCheck(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			roller := dice.NewSeededRoller(tt.seed)
-			success, roll := NaturalInclinationCheck(roller)
+			success, roll := NaturalInclinationCheck(rules.Default(), roller)
 			
 			if roll < tt.wantMin || roll > tt.wantMax {
 				t.Errorf("NaturalInclinationCheck() roll = %d, want between %d and %d", roll, tt.wantMin, tt.wantMax)
@@ -119,7 +121,7 @@ func TestFundamentalFailureRate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			roller := dice.NewSeededRoller(tt.seed)
-			success, roll := FundamentalFailureRate(roller)
+			success, roll := FundamentalFailureRate(rules.Default(), roller)
 			
 			if roll < tt.wantMin || roll > tt.wantMax {
 				t.Errorf("FundamentalFailureRate() roll = %d, want between %d and %d", roll, tt.wantMin, tt.wantMax)
@@ -142,6 +144,7 @@ func TestValidateCast(t *testing.T) {
 		currentLP     int
 		inCombat      bool
 		isDead        bool
+		section       *character.SectionMagic
 		wantSuccess   bool
 		wantSacrifice bool
 	}{
@@ -195,11 +198,56 @@ func TestValidateCast(t *testing.T) {
 			wantSuccess:   true,
 			wantSacrifice: false,
 		},
+		{
+			name:          "inclination not yet checked",
+			spell:         &Spell{Name: "FIREBALL", PowerCost: 15, CombatOnly: true},
+			currentPOW:    50,
+			currentLP:     100,
+			inCombat:      true,
+			section:       &character.SectionMagic{},
+			wantSuccess:   false,
+			wantSacrifice: false,
+		},
+		{
+			name:          "inclination failed this section",
+			spell:         &Spell{Name: "FIREBALL", PowerCost: 15, CombatOnly: true},
+			currentPOW:    50,
+			currentLP:     100,
+			inCombat:      true,
+			section:       &character.SectionMagic{InclinationChecked: true, InclinationPassed: false},
+			wantSuccess:   false,
+			wantSacrifice: false,
+		},
+		{
+			name:          "spell already cast this section",
+			spell:         &Spell{Name: "FIREBALL", PowerCost: 15, CombatOnly: true},
+			currentPOW:    50,
+			currentLP:     100,
+			inCombat:      true,
+			section:       &character.SectionMagic{InclinationChecked: true, InclinationPassed: true, SpellsCast: []string{"FIREBALL"}},
+			wantSuccess:   false,
+			wantSacrifice: false,
+		},
+		{
+			name:          "different spell already cast this section",
+			spell:         &Spell{Name: "FIREBALL", PowerCost: 15, CombatOnly: true},
+			currentPOW:    50,
+			currentLP:     100,
+			inCombat:      true,
+			section:       &character.SectionMagic{InclinationChecked: true, InclinationPassed: true, SpellsCast: []string{"ARMOUR"}},
+			wantSuccess:   true,
+			wantSacrifice: false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := ValidateCast(tt.spell, tt.currentPOW, tt.currentLP, tt.inCombat, tt.isDead)
+			// Default to a section where Fire*Wolf has agreed to use magic
+			section := character.SectionMagic{InclinationChecked: true, InclinationPassed: true}
+			if tt.section != nil {
+				section = *tt.section
+			}
+			result := ValidateCast(tt.spell, tt.currentPOW, tt.currentLP, tt.inCombat, tt.isDead, section)
 			
 			if result.Success != tt.wantSuccess {
 				t.Errorf("ValidateCast() Success = %v, want %v", result.Success, tt.wantSuccess)
@@ -219,7 +267,7 @@ func TestPerformCast(t *testing.T) {
 	// Test with successful FFR (roll >= 6)
 	t.Run("successful cast", func(t *testing.T) {
 		roller := dice.NewSeededRoller(100) // Use a seed that gives high roll
-		result := PerformCast(spell, roller)
+		result := PerformCast(spell, rules.Default(), roller)
 		
 		if result.PowerSpent != spell.PowerCost {
 			t.Errorf("PerformCast() PowerSpent = %d, want %d", result.PowerSpent, spell.PowerCost)
@@ -13,11 +13,13 @@ type SpellEffect struct {
 	DamageDealt    int    // For offensive spells
 	LPRestored     int    // For healing/restoration
 	CombatEnded    bool   // Whether combat should end
-	Victory        bool   // Whether combat ended in victory
+	Victory        bool   // Whether the fight ends in the player's favour (still logged as an escape)
 	EnemyKilled    bool   // Whether enemy was killed
 	CharacterDied  bool   // Whether character died (for RESURRECTION)
 	RequiresReroll bool   // Whether stats need rerolling (RESURRECTION)
 	NavigateTo     string // Section to navigate to (CRYPT, RETRACE)
+	TargetSection  int    // Gamebook section number to move to (RETRACE)
+	RewindSection  bool   // Whether to roll back to the start of the section (TIMEWARP, RESURRECTION)
 }
 
 // ApplyARMOUR applies the ARMOUR spell effect.
@@ -75,6 +77,7 @@ func ApplyPARALYSIS() SpellEffect {
 // ApplyPOISONNEEDLE applies the POISON NEEDLE spell effect.
 func ApplyPOISONNEEDLE(roller dice.Roller) SpellEffect {
 	// Roll 1d6: 1-3 = immune, 4-6 = affected
+	dice.Label(roller, dice.LabelPoisonNeedle)
 	roll := roller.Roll1D6()
 	
 	if roll >= 4 {
@@ -98,23 +101,27 @@ func ApplyRESURRECTION() SpellEffect {
 		Success:        true,
 		Message:        "Death is not your fate! You are resurrected at the start of this section.",
 		RequiresReroll: true,
+		RewindSection:  true,
 	}
 }
 
 // ApplyRETRACE applies the RETRACE spell effect.
-func ApplyRETRACE(sectionName string) SpellEffect {
+// The section must be one Fire*Wolf has already visited.
+func ApplyRETRACE(section int) SpellEffect {
 	return SpellEffect{
-		Success:    true,
-		Message:    fmt.Sprintf("You trace your steps back to: %s", sectionName),
-		NavigateTo: sectionName,
+		Success:       true,
+		Message:       fmt.Sprintf("You trace your steps back to section %d.", section),
+		NavigateTo:    "RETRACE",
+		TargetSection: section,
 	}
 }
 
 // ApplyTIMEWARP applies the TIMEWARP spell effect.
 func ApplyTIMEWARP() SpellEffect {
 	return SpellEffect{
-		Success: true,
-		Message: "Time warps around you! You return to the beginning of this section.",
+		Success:       true,
+		Message:       "Time warps around you! You return to the beginning of this section.",
+		RewindSection: true,
 	}
 }
 
@@ -0,0 +1,267 @@
+// Package rules defines the numbers the combat and magic engines play by.
+// The book's original rules and the revised rules of the bundled ruleset
+// ship as profiles; custom profiles are JSON files in the save directory.
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Built-in profile identifiers.
+const (
+	// OriginalID plays by the numbers printed in the book.
+	OriginalID = "original"
+	// RevisedID plays by saga_demonspawn_ruleset.md.
+	RevisedID = "revised"
+	// DefaultID is the profile used when none is configured.
+	DefaultID = RevisedID
+)
+
+// Ruleset holds every number of the rules that differs between editions.
+type Ruleset struct {
+	Name string `json:"name"` // Shown in Settings
+
+	// To-hit roll
+	ToHitBase     int `json:"to_hit_base"`    // 2d6 score needed to hit before modifiers
+	ToHitMinimum  int `json:"to_hit_minimum"` // The score needed never drops below this
+	SkillDivisor  int `json:"skill_divisor"`  // Every this many SKL points lower the score needed by 1
+	LuckThreshold int `json:"luck_threshold"` // LCK at or above this lowers the score needed by 1
+
+	// Damage
+	DamageMultiplier   int `json:"damage_multiplier"`   // To-hit roll × this is the base damage
+	StrengthDivisor    int `json:"strength_divisor"`    // Every this many STR points...
+	StrengthMultiplier int `json:"strength_multiplier"` // ...add this much damage
+
+	// Endurance and death
+	EnduranceDivisor    int `json:"endurance_divisor"`     // STA ÷ this is the rounds fought before resting
+	DeathSaveMultiplier int `json:"death_save_multiplier"` // 2d6 × this must be at most LCK to survive
+
+	// Magic
+	FFRThreshold         int `json:"ffr_threshold"`         // 2d6 score for a spell to work
+	InclinationThreshold int `json:"inclination_threshold"` // 2d6 score to use magic in a section
+
+	// Healing Stone: HealingStoneDice d6 × HealingStoneMultiplier LP
+	HealingStoneDice       int `json:"healing_stone_dice"`
+	HealingStoneMultiplier int `json:"healing_stone_multiplier"`
+}
+
+// Original returns the rules as printed in the book: the to-hit roll
+// times 10 is the base damage.
+func Original() Ruleset {
+	r := Revised()
+	r.Name = "Original"
+	r.DamageMultiplier = 10
+	return r
+}
+
+// Revised returns the rules of saga_demonspawn_ruleset.md.
+func Revised() Ruleset {
+	return Ruleset{
+		Name:                   "Revised",
+		ToHitBase:              7,
+		ToHitMinimum:           2,
+		SkillDivisor:           10,
+		LuckThreshold:          72,
+		DamageMultiplier:       5,
+		StrengthDivisor:        10,
+		StrengthMultiplier:     5,
+		EnduranceDivisor:       20,
+		DeathSaveMultiplier:    10,
+		FFRThreshold:           6,
+		InclinationThreshold:   4,
+		HealingStoneDice:       1,
+		HealingStoneMultiplier: 10,
+	}
+}
+
+// Default returns the profile used when none is configured.
+func Default() Ruleset {
+	return Revised()
+}
+
+// builtin returns the built-in profile with the given identifier.
+func builtin(id string) (Ruleset, bool) {
+	switch id {
+	case OriginalID:
+		return Original(), true
+	case RevisedID:
+		return Revised(), true
+	}
+	return Ruleset{}, false
+}
+
+// IsZero returns true for a ruleset that was never set, such as one
+// missing from a fight saved before rulesets existed.
+func (r Ruleset) IsZero() bool {
+	return r == Ruleset{}
+}
+
+// Validate checks that the divisors are positive and the thresholds can
+// be rolled.
+func (r Ruleset) Validate() error {
+	positive := []struct {
+		name  string
+		value int
+	}{
+		{"skill_divisor", r.SkillDivisor},
+		{"strength_divisor", r.StrengthDivisor},
+		{"endurance_divisor", r.EnduranceDivisor},
+		{"damage_multiplier", r.DamageMultiplier},
+		{"death_save_multiplier", r.DeathSaveMultiplier},
+		{"healing_stone_dice", r.HealingStoneDice},
+		{"healing_stone_multiplier", r.HealingStoneMultiplier},
+	}
+	for _, field := range positive {
+		if field.value <= 0 {
+			return fmt.Errorf("%s must be positive: %d", field.name, field.value)
+		}
+	}
+	if r.StrengthMultiplier < 0 {
+		return fmt.Errorf("strength_multiplier cannot be negative: %d", r.StrengthMultiplier)
+	}
+
+	rolls := []struct {
+		name  string
+		value int
+	}{
+		{"to_hit_base", r.ToHitBase},
+		{"to_hit_minimum", r.ToHitMinimum},
+		{"ffr_threshold", r.FFRThreshold},
+		{"inclination_threshold", r.InclinationThreshold},
+	}
+	for _, field := range rolls {
+		if field.value < 2 || field.value > 12 {
+			return fmt.Errorf("%s must be a 2d6 score (2-12): %d", field.name, field.value)
+		}
+	}
+	if r.ToHitMinimum > r.ToHitBase {
+		return fmt.Errorf("to_hit_minimum %d is above to_hit_base %d", r.ToHitMinimum, r.ToHitBase)
+	}
+	return nil
+}
+
+// ToHitRequirement returns the 2d6 score needed to hit: the base, less 1
+// per SkillDivisor points of skill and 1 for luck at LuckThreshold or
+// more, never below ToHitMinimum.
+func (r Ruleset) ToHitRequirement(skill, luck int) int {
+	requirement := r.ToHitBase - skill/r.SkillDivisor
+	if luck >= r.LuckThreshold {
+		requirement--
+	}
+	if requirement < r.ToHitMinimum {
+		requirement = r.ToHitMinimum
+	}
+	return requirement
+}
+
+// Damage returns the damage of a hit before armor: the to-hit roll times
+// DamageMultiplier, plus StrengthMultiplier per StrengthDivisor points of
+// strength, plus the weapon bonus.
+func (r Ruleset) Damage(roll, strength, weaponBonus int) int {
+	return roll*r.DamageMultiplier + r.StrengthBonus(strength) + weaponBonus
+}
+
+// StrengthBonus returns the damage a hit gains from strength.
+func (r Ruleset) StrengthBonus(strength int) int {
+	return (strength / r.StrengthDivisor) * r.StrengthMultiplier
+}
+
+// EnduranceLimit returns the rounds a combatant with the given stamina
+// fights before resting.
+func (r Ruleset) EnduranceLimit(stamina int) int {
+	return stamina / r.EnduranceDivisor
+}
+
+// DeathSaveScore turns a death save's 2d6 roll into the score compared
+// with LCK.
+func (r Ruleset) DeathSaveScore(roll int) int {
+	return roll * r.DeathSaveMultiplier
+}
+
+// FFRPassed returns true if a 2d6 roll makes a spell work.
+func (r Ruleset) FFRPassed(roll int) bool {
+	return roll >= r.FFRThreshold
+}
+
+// InclinationPassed returns true if a 2d6 roll lets Fire*Wolf use magic.
+func (r Ruleset) InclinationPassed(roll int) bool {
+	return roll >= r.InclinationThreshold
+}
+
+// HealingStoneLP turns the Healing Stone's dice total into LP.
+func (r Ruleset) HealingStoneLP(roll int) int {
+	return roll * r.HealingStoneMultiplier
+}
+
+// HealingStoneFormula describes the Healing Stone roll, e.g. "1d6×10".
+func (r Ruleset) HealingStoneFormula() string {
+	return fmt.Sprintf("%dd6×%d", r.HealingStoneDice, r.HealingStoneMultiplier)
+}
+
+// Dir returns where custom profiles are kept in the save directory.
+func Dir(directory string) string {
+	return filepath.Join(directory, "rulesets")
+}
+
+// Path returns the file of a custom profile in the save directory.
+func Path(directory, id string) string {
+	return filepath.Join(Dir(directory), id+".json")
+}
+
+// Load reads a custom profile. Fields the file leaves out keep their
+// revised value; a profile without a name is named after its file.
+func Load(path string) (Ruleset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Ruleset{}, fmt.Errorf("failed to read ruleset: %w", err)
+	}
+
+	r := Revised()
+	r.Name = ""
+	if err := json.Unmarshal(data, &r); err != nil {
+		return Ruleset{}, fmt.Errorf("failed to parse ruleset: %w", err)
+	}
+	if r.Name == "" {
+		r.Name = strings.TrimSuffix(filepath.Base(path), ".json")
+	}
+	if err := r.Validate(); err != nil {
+		return Ruleset{}, fmt.Errorf("invalid ruleset %s: %w", filepath.Base(path), err)
+	}
+	return r, nil
+}
+
+// Find returns the profile with the given identifier: a built-in one or
+// a custom profile in the save directory.
+func Find(directory, id string) (Ruleset, error) {
+	if id == "" {
+		return Default(), nil
+	}
+	if r, ok := builtin(id); ok {
+		return r, nil
+	}
+	return Load(Path(directory, id))
+}
+
+// List returns the identifiers of every profile: the built-in ones, then
+// the custom profiles of the save directory in name order.
+func List(directory string) []string {
+	ids := []string{OriginalID, RevisedID}
+	paths, err := filepath.Glob(filepath.Join(Dir(directory), "*.json"))
+	if err != nil {
+		return ids
+	}
+	custom := []string{}
+	for _, path := range paths {
+		id := strings.TrimSuffix(filepath.Base(path), ".json")
+		if _, ok := builtin(id); !ok {
+			custom = append(custom, id)
+		}
+	}
+	sort.Strings(custom)
+	return append(ids, custom...)
+}
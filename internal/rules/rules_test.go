@@ -0,0 +1,114 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestProfiles verifies the numbers that differ between the built-in profiles.
+func TestProfiles(t *testing.T) {
+	original, revised := Original(), Revised()
+	for _, r := range []Ruleset{original, revised} {
+		if err := r.Validate(); err != nil {
+			t.Errorf("%s.Validate() unexpected error: %v", r.Name, err)
+		}
+	}
+
+	if got := original.Damage(8, 65, 10); got != 8*10+30+10 {
+		t.Errorf("Original Damage(8, 65, 10) = %d, want 120", got)
+	}
+	if got := revised.Damage(8, 65, 10); got != 8*5+30+10 {
+		t.Errorf("Revised Damage(8, 65, 10) = %d, want 80", got)
+	}
+	if got := revised.EnduranceLimit(72); got != 3 {
+		t.Errorf("EnduranceLimit(72) = %d, want 3 (STA ÷ 20)", got)
+	}
+	if got := revised.ToHitRequirement(20, 80); got != 4 {
+		t.Errorf("ToHitRequirement(20, 80) = %d, want 4", got)
+	}
+	if got := revised.ToHitRequirement(100, 80); got != 2 {
+		t.Errorf("ToHitRequirement(100, 80) = %d, want the minimum 2", got)
+	}
+	if got := revised.DeathSaveScore(7); got != 70 {
+		t.Errorf("DeathSaveScore(7) = %d, want 70", got)
+	}
+	if got := revised.HealingStoneFormula(); got != "1d6×10" {
+		t.Errorf("HealingStoneFormula() = %q, want 1d6×10", got)
+	}
+	if !revised.FFRPassed(6) || revised.FFRPassed(5) {
+		t.Error("FFRPassed() should need 6 or better")
+	}
+	if !revised.InclinationPassed(4) || revised.InclinationPassed(3) {
+		t.Error("InclinationPassed() should need 4 or better")
+	}
+	if !(Ruleset{}).IsZero() || revised.IsZero() {
+		t.Error("IsZero() should only be true for an unset ruleset")
+	}
+}
+
+// TestValidate verifies that unplayable numbers are refused.
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(r *Ruleset)
+	}{
+		{"zero skill divisor", func(r *Ruleset) { r.SkillDivisor = 0 }},
+		{"zero endurance divisor", func(r *Ruleset) { r.EnduranceDivisor = 0 }},
+		{"unrollable FFR", func(r *Ruleset) { r.FFRThreshold = 13 }},
+		{"minimum above base", func(r *Ruleset) { r.ToHitMinimum = 8 }},
+		{"negative strength bonus", func(r *Ruleset) { r.StrengthMultiplier = -5 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Revised()
+			tt.change(&r)
+			if err := r.Validate(); err == nil {
+				t.Error("Validate() expected error")
+			}
+		})
+	}
+}
+
+// TestCustomProfiles verifies loading, listing and finding profiles.
+func TestCustomProfiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(Dir(dir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(Path(dir, "brutal"), []byte(`{"damage_multiplier": 15, "ffr_threshold": 8}`), 0644)
+	os.WriteFile(Path(dir, "broken"), []byte(`{"skill_divisor": 0}`), 0644)
+	os.WriteFile(filepath.Join(Dir(dir), "notes.txt"), []byte("ignored"), 0644)
+
+	brutal, err := Find(dir, "brutal")
+	if err != nil {
+		t.Fatalf("Find(brutal) unexpected error: %v", err)
+	}
+	want := Revised()
+	want.Name = "brutal"
+	want.DamageMultiplier = 15
+	want.FFRThreshold = 8
+	if brutal != want {
+		t.Errorf("Find(brutal) = %+v, want %+v", brutal, want)
+	}
+
+	if _, err := Find(dir, "broken"); err == nil {
+		t.Error("Find(broken) expected validation error")
+	}
+	if _, err := Find(dir, "missing"); err == nil {
+		t.Error("Find(missing) expected error")
+	}
+	if r, err := Find(dir, OriginalID); err != nil || r != Original() {
+		t.Errorf("Find(original) = %+v, %v", r, err)
+	}
+	if r, err := Find(dir, ""); err != nil || r != Default() {
+		t.Errorf("Find(\"\") = %+v, %v, want the default profile", r, err)
+	}
+
+	ids := List(dir)
+	if !reflect.DeepEqual(ids, []string{OriginalID, RevisedID, "broken", "brutal"}) {
+		t.Errorf("List() = %v", ids)
+	}
+}
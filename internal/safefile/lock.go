@@ -0,0 +1,166 @@
+package safefile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrLocked is returned, wrapped in a *LockedError, when another instance
+// of saga holds a lock.
+var ErrLocked = errors.New("in use by another instance of saga")
+
+// LockedError describes the lock held by another instance.
+type LockedError struct {
+	Path  string    // Lock file
+	Owner LockOwner // Who holds it
+}
+
+// Error describes who holds the lock.
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("%v (process %d on %s, since %s)",
+		ErrLocked, e.Owner.PID, e.Owner.Host, e.Owner.Since.Format("2006-01-02 15:04"))
+}
+
+// Unwrap makes errors.Is(err, ErrLocked) true.
+func (e *LockedError) Unwrap() error {
+	return ErrLocked
+}
+
+// LockOwner is written to a lock file to identify the instance holding it.
+type LockOwner struct {
+	PID   int       `json:"pid"`   // Process holding the lock
+	Host  string    `json:"host"`  // Machine the process runs on
+	Since time.Time `json:"since"` // When the lock was taken
+}
+
+// Lock is an advisory lock file held by this process. Other instances
+// see it through TryLock and Owner; nothing stops a program that ignores it.
+type Lock struct {
+	path string // Lock file, as given to TryLock
+	key  string // Its absolute path, in held
+}
+
+// held lists the lock files this process holds, by absolute path, so a
+// lock is exclusive between goroutines as well as between instances.
+var held = struct {
+	sync.Mutex
+	paths map[string]*Lock
+}{paths: map[string]*Lock{}}
+
+// TryLock creates the lock file at path. If another running instance
+// holds it, or another goroutine of this one, a *LockedError is returned.
+// A lock left behind by a process that has exited is taken over, and so
+// is one naming this process that no Lock holds any more.
+func TryLock(path string) (*Lock, error) {
+	key, err := filepath.Abs(path)
+	if err != nil {
+		key = path
+	}
+	held.Lock()
+	defer held.Unlock()
+
+	self := currentOwner()
+	if held.paths[key] != nil {
+		owner, _ := Owner(path)
+		return nil, &LockedError{Path: path, Owner: owner}
+	}
+	data, err := json.Marshal(self)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			_, werr := f.Write(data)
+			cerr := f.Close()
+			if werr != nil || cerr != nil {
+				os.Remove(path)
+				return nil, fmt.Errorf("failed to write lock file: %w", errors.Join(werr, cerr))
+			}
+			lock := &Lock{path: path, key: key}
+			held.paths[key] = lock
+			return lock, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create lock file: %w", err)
+		}
+
+		owner, running := Owner(path)
+		if running && (owner.PID != self.PID || owner.Host != self.Host) {
+			return nil, &LockedError{Path: path, Owner: owner}
+		}
+		// Stale: the owner is gone, or is this process without a Lock
+		// holding it, so take the lock over
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove stale lock file: %w", err)
+		}
+	}
+	owner, _ := Owner(path)
+	return nil, &LockedError{Path: path, Owner: owner}
+}
+
+// Owner reads the lock file at path and reports whether a running process
+// holds it. A missing or unreadable lock file, or one whose process has
+// exited, is not held.
+func Owner(path string) (LockOwner, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return LockOwner{}, false
+	}
+	var owner LockOwner
+	if err := json.Unmarshal(data, &owner); err != nil || owner.PID <= 0 {
+		return LockOwner{}, false
+	}
+	if host, _ := os.Hostname(); owner.Host != host {
+		// A process on another machine sharing the folder cannot be
+		// checked, so its lock is honoured
+		return owner, true
+	}
+	return owner, processRunning(owner.PID)
+}
+
+// HeldByOther returns a *LockedError if a running process other than this
+// one holds the lock file at path, and nil otherwise.
+func HeldByOther(path string) error {
+	owner, held := Owner(path)
+	self := currentOwner()
+	if !held || owner.PID == self.PID && owner.Host == self.Host {
+		return nil
+	}
+	return &LockedError{Path: path, Owner: owner}
+}
+
+// Unlock removes the lock file. Unlocking a nil lock does nothing.
+func (l *Lock) Unlock() error {
+	if l == nil {
+		return nil
+	}
+	held.Lock()
+	defer held.Unlock()
+	if held.paths[l.key] != l {
+		// Already released
+		return nil
+	}
+	delete(held.paths, l.key)
+	if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove lock file: %w", err)
+	}
+	return nil
+}
+
+// Path returns the lock file.
+func (l *Lock) Path() string {
+	return l.path
+}
+
+// currentOwner identifies this process in a lock file.
+func currentOwner() LockOwner {
+	host, _ := os.Hostname()
+	return LockOwner{PID: os.Getpid(), Host: host, Since: time.Now()}
+}
@@ -0,0 +1,158 @@
+package safefile
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// writeLock writes a lock file as another process would.
+func writeLock(t *testing.T, path string, pid int) {
+	t.Helper()
+	host, _ := os.Hostname()
+	data, _ := json.Marshal(LockOwner{PID: pid, Host: host, Since: time.Now()})
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestTryLock verifies a lock is exclusive until it is released.
+func TestTryLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slot.lock")
+
+	lock, err := TryLock(path)
+	if err != nil {
+		t.Fatalf("TryLock() unexpected error: %v", err)
+	}
+	if owner, held := Owner(path); !held || owner.PID != os.Getpid() {
+		t.Errorf("Owner() = %+v, %v; want this process", owner, held)
+	}
+	// Not even this process may take it again until it is released
+	if _, err := TryLock(path); !errors.Is(err, ErrLocked) {
+		t.Errorf("TryLock() error = %v on a lock already held; want ErrLocked", err)
+	}
+	if err := HeldByOther(path); err != nil {
+		t.Errorf("HeldByOther() = %v for this process's lock", err)
+	}
+
+	if err := lock.Unlock(); err != nil {
+		t.Fatalf("Unlock() unexpected error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Unlock() left the lock file")
+	}
+}
+
+// TestTryLockGoroutines verifies two goroutines of one process never hold
+// the same lock at once.
+func TestTryLockGoroutines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "saves.lock")
+	var active, entered atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 20; n++ {
+				lock, err := TryLock(path)
+				for errors.Is(err, ErrLocked) {
+					time.Sleep(time.Millisecond)
+					lock, err = TryLock(path)
+				}
+				if err != nil {
+					t.Errorf("TryLock() unexpected error: %v", err)
+					return
+				}
+				if active.Add(1) != 1 {
+					t.Error("two goroutines hold the lock at once")
+				}
+				entered.Add(1)
+				time.Sleep(time.Millisecond)
+				if _, err := os.Stat(path); err != nil {
+					t.Errorf("lock file gone while held: %v", err)
+				}
+				active.Add(-1)
+				if err := lock.Unlock(); err != nil {
+					t.Errorf("Unlock() unexpected error: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if entered.Load() != 40 {
+		t.Errorf("lock taken %d times; want 40", entered.Load())
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("lock file left after every goroutine released it")
+	}
+}
+
+// TestUnlockTwice verifies a lock released twice does not release the
+// lock taken by someone else in between.
+func TestUnlockTwice(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slot.lock")
+	first, _ := TryLock(path)
+	first.Unlock()
+	second, err := TryLock(path)
+	if err != nil {
+		t.Fatalf("TryLock() unexpected error: %v", err)
+	}
+	first.Unlock()
+	if _, err := TryLock(path); !errors.Is(err, ErrLocked) {
+		t.Errorf("TryLock() error = %v after a stale Unlock; want ErrLocked", err)
+	}
+	second.Unlock()
+}
+
+// TestTryLockHeldByOther verifies another running instance's lock is refused.
+func TestTryLockHeldByOther(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slot.lock")
+	writeLock(t, path, os.Getppid())
+
+	_, err := TryLock(path)
+	if !errors.Is(err, ErrLocked) {
+		t.Fatalf("TryLock() error = %v; want ErrLocked", err)
+	}
+	var locked *LockedError
+	if !errors.As(err, &locked) || locked.Owner.PID != os.Getppid() {
+		t.Errorf("TryLock() error = %v; want the owner's process ID", err)
+	}
+	if err := HeldByOther(path); !errors.Is(err, ErrLocked) {
+		t.Errorf("HeldByOther() = %v; want ErrLocked", err)
+	}
+}
+
+// TestTryLockStale verifies a lock left by an exited process is taken over.
+func TestTryLockStale(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name  string
+		write func(path string)
+	}{
+		{"Exited process", func(path string) { writeLock(t, path, 1<<22+12345) }}, // Above any Linux PID
+		{"Corrupt lock file", func(path string) { os.WriteFile(path, []byte("{"), 0644) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.name == "Exited process" && runtime.GOOS == "windows" {
+				t.Skip("process checks are not available on Windows")
+			}
+			path := filepath.Join(dir, tt.name+".lock")
+			tt.write(path)
+			lock, err := TryLock(path)
+			if err != nil {
+				t.Fatalf("TryLock() unexpected error: %v", err)
+			}
+			if owner, _ := Owner(path); owner.PID != os.Getpid() {
+				t.Errorf("lock owned by %d; want this process", owner.PID)
+			}
+			lock.Unlock()
+		})
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package safefile
+
+// processRunning reports whether a process with the given ID exists.
+// It cannot be checked here, so a lock is always assumed to be live;
+// stale lock files must be deleted by hand.
+func processRunning(pid int) bool {
+	return true
+}
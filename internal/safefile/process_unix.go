@@ -0,0 +1,15 @@
+//go:build unix
+
+package safefile
+
+import (
+	"errors"
+	"syscall"
+)
+
+// processRunning reports whether a process with the given ID exists.
+func processRunning(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	// EPERM: the process exists but belongs to another user
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
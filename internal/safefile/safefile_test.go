@@ -0,0 +1,40 @@
+package safefile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestWriteFile verifies files are replaced whole and no temporary file
+// is left behind.
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "save.json")
+
+	if err := WriteFile(path, []byte("first"), 0644); err != nil {
+		t.Fatalf("WriteFile() unexpected error: %v", err)
+	}
+	if err := WriteFile(path, []byte("second"), 0600); err != nil {
+		t.Fatalf("WriteFile() unexpected error: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != "second" {
+		t.Errorf("file holds %q; want second", data)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("file mode = %v; want 0600", info.Mode().Perm())
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("directory has %d files; want only the written file", len(entries))
+	}
+}
+
+// TestWriteFileMissingDirectory verifies a failed write leaves nothing behind.
+func TestWriteFileMissingDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "save.json")
+	if err := WriteFile(path, []byte("data"), 0644); err == nil {
+		t.Error("WriteFile() expected error for a missing directory")
+	}
+}
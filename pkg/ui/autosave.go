@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/internal/combat"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// saveMu keeps a background save from writing the slot files and the save
+// manifest while the UI goroutine writes them too: a save, Save As, a
+// slot rename or a backup restore. The slot and manifest locks only keep
+// other instances out.
+var saveMu sync.Mutex
+
+// saveEpoch counts the writes from the UI goroutine: a save, Save As, a
+// slot rename or a backup restore. A background save started before one
+// of them would put back an older state, so it is skipped. Guarded by
+// saveMu.
+var saveEpoch int
+
+// errSaveSuperseded reports a background save skipped because the UI
+// goroutine wrote the saves after it started.
+var errSaveSuperseded = errors.New("save superseded by a later one")
+
+// currentSaveEpoch returns the save epoch a background save starts in.
+func currentSaveEpoch() int {
+	saveMu.Lock()
+	defer saveMu.Unlock()
+	return saveEpoch
+}
+
+// AutosaveTickMsg is sent every autosave interval. Ticks from an
+// interval that has since been changed in Settings are ignored.
+type AutosaveTickMsg struct {
+	Generation int
+}
+
+// AutosaveDoneMsg reports a background save once it is written, or
+// skipped with errSaveSuperseded.
+type AutosaveDoneMsg struct {
+	Character *character.Character // Character that was saved
+	Slot      string               // Slot it was saved to
+	SavedAt   time.Time
+	Err       error
+}
+
+// autosaveTick returns a command sending the next AutosaveTickMsg, or nil
+// if periodic autosave is off.
+func (m Model) autosaveTick() tea.Cmd {
+	if m.Config == nil {
+		return nil
+	}
+	interval := m.Config.AutoSaveInterval()
+	if interval <= 0 {
+		return nil
+	}
+	generation := m.AutosaveGeneration
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return AutosaveTickMsg{Generation: generation}
+	})
+}
+
+// RestartAutosave starts a new tick chain after the interval changed.
+// Ticks still pending from the old chain are ignored.
+func (m *Model) RestartAutosave() tea.Cmd {
+	m.AutosaveGeneration++
+	return m.autosaveTick()
+}
+
+// RequestAutosave asks for the character to be saved in the background
+// after a key event: a fight ended, an item was acquired, a spell was
+// cast or a new section was entered.
+func (m *Model) RequestAutosave() {
+	m.AutosaveDue = true
+}
+
+// canAutosave returns true if the character is open in this session:
+// it holds its save slot, or it is new and has none yet. A character
+// left behind by Save & Exit is not saved again.
+func (m *Model) canAutosave() bool {
+	if m.Character == nil || m.Config == nil || !m.Config.AutoSave {
+		return false
+	}
+	return m.SlotLock != nil || m.Character.Slot == ""
+}
+
+// startAutosave returns a command saving a copy of the character, with
+// the fight in progress, off the UI goroutine. If a save is already
+// running, another one follows when it is done.
+func (m *Model) startAutosave() tea.Cmd {
+	if !m.canAutosave() {
+		m.AutosaveDue = false
+		return nil
+	}
+	if m.Autosaving {
+		m.AutosaveDue = true
+		return nil
+	}
+	m.Autosaving = true
+	m.AutosaveDue = false
+
+	original := m.Character
+	char := original.Clone()
+	fight := m.CombatState
+	if fight == nil {
+		fight = m.SavedCombat
+	}
+	if fight != nil {
+		fight = fight.Clone()
+	}
+	directory := m.SaveDirectory()
+	policy := m.backupPolicyIfEnabled()
+	epoch := currentSaveEpoch()
+
+	return func() tea.Msg {
+		err := writeAutosave(char, fight, directory, policy, epoch)
+		return AutosaveDoneMsg{Character: original, Slot: char.Slot, SavedAt: char.LastSaved, Err: err}
+	}
+}
+
+// finishAutosave records a finished background save. A new character
+// takes the slot it was saved to. A skipped save is not an error: a later
+// write already holds the character.
+func (m *Model) finishAutosave(msg AutosaveDoneMsg) tea.Cmd {
+	m.Autosaving = false
+	m.AutosaveErr = msg.Err
+	if errors.Is(msg.Err, errSaveSuperseded) {
+		m.AutosaveErr = nil
+	} else if msg.Err == nil {
+		m.LastAutosave = msg.SavedAt
+		if msg.Character == m.Character {
+			if m.Character.Slot == "" {
+				m.Character.Slot = msg.Slot
+			}
+			m.AutosaveErr = m.LockSlot()
+		}
+	}
+	if m.AutosaveDue {
+		return m.startAutosave()
+	}
+	return nil
+}
+
+// writeSave suspends fight in char and saves char to its slot from the
+// UI goroutine, backing up the slot first when policy is not nil.
+func writeSave(char *character.Character, fight *combat.CombatState, directory string, policy *character.BackupPolicy) error {
+	saveMu.Lock()
+	defer saveMu.Unlock()
+
+	if err := saveLocked(char, fight, directory, policy); err != nil {
+		return err
+	}
+	saveEpoch++
+	return nil
+}
+
+// writeAutosave is writeSave for a background save started in the given
+// epoch. It writes nothing if the UI goroutine has written since.
+func writeAutosave(char *character.Character, fight *combat.CombatState, directory string, policy *character.BackupPolicy, epoch int) error {
+	saveMu.Lock()
+	defer saveMu.Unlock()
+
+	if epoch != saveEpoch {
+		return errSaveSuperseded
+	}
+	return saveLocked(char, fight, directory, policy)
+}
+
+// saveLocked does the work of writeSave with saveMu held.
+func saveLocked(char *character.Character, fight *combat.CombatState, directory string, policy *character.BackupPolicy) error {
+	if err := combat.Suspend(char, fight); err != nil {
+		return err
+	}
+	if policy != nil {
+		return char.SaveWithBackup(directory, *policy)
+	}
+	return char.Save(directory)
+}
@@ -0,0 +1,247 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/internal/dice"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestAutosaveDuringDiceEntry verifies the autosave timer and a background
+// save finishing are not lost while physical dice are being typed in, and
+// that other messages are held back until the dice are in.
+func TestAutosaveDuringDiceEntry(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModelWithRoller(dice.NewSeededRoller(1))
+	m.Config.AutoSave = true
+	m.Config.ManualDice = true
+	m.ManualDice.SetEnabled(true)
+	m.Character, _ = character.New(50, 50, 50, 50, 50, 50, 50)
+	m.EnteringDice = true
+
+	next, cmd := m.Update(AutosaveTickMsg{Generation: m.AutosaveGeneration})
+	m = next.(Model)
+	if cmd == nil || !m.Autosaving {
+		t.Fatalf("tick during dice entry: autosaving %v, command %v; want a save started and the next tick", m.Autosaving, cmd)
+	}
+
+	next, _ = m.Update(AutosaveDoneMsg{Err: nil})
+	m = next.(Model)
+	if m.Autosaving {
+		t.Error("Autosaving still true after the save finished during dice entry")
+	}
+
+	// A later key event still starts a save
+	m.RequestAutosave()
+	next, _ = m.Update(AutosaveTickMsg{Generation: m.AutosaveGeneration})
+	if !next.(Model).Autosaving {
+		t.Error("autosave did not start again after dice entry")
+	}
+
+	// A message that may roll dice waits for the modal to close
+	next, _ = m.Update(EnemyTurnMsg{})
+	m = next.(Model)
+	if len(m.DeferredMsgs) != 1 {
+		t.Fatalf("deferred messages = %v; want the enemy turn", m.DeferredMsgs)
+	}
+	next, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = next.(Model)
+	if m.EnteringDice || len(m.DeferredMsgs) != 0 || cmd == nil {
+		t.Fatal("closing the dice modal did not replay the deferred messages")
+	}
+	if msg := cmd(); msg != (EnemyTurnMsg{}) {
+		t.Errorf("replayed message = %#v; want EnemyTurnMsg", msg)
+	}
+}
+
+// TestAutosaveSuperseded verifies a background save started before a
+// save, Save As or backup restore does not write the older state over it.
+func TestAutosaveSuperseded(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(t *testing.T, m *Model) int // Writes the slot, returning the LP now saved in it
+	}{
+		{"Save", func(t *testing.T, m *Model) int {
+			m.Character.SetLP(200)
+			if err := m.SaveCharacter(); err != nil {
+				t.Fatalf("SaveCharacter() unexpected error: %v", err)
+			}
+			return 200
+		}},
+		{"Save As", func(t *testing.T, m *Model) int {
+			m.SaveAs.Reset(m.Character, m.SaveDirectory())
+			m.SaveAs.AppendInput("Second")
+			if !m.SaveAs.Save() {
+				t.Fatal("Save As failed")
+			}
+			return 100 // The old slot keeps the state it had
+		}},
+		{"Restore", func(t *testing.T, m *Model) int {
+			m.RestoreBackup.Reset(m.Character, m.SaveDirectory())
+			restored := m.RestoreBackup.Restore(m.BackupPolicy())
+			if restored == nil {
+				t.Fatal("Restore() failed")
+			}
+			m.LoadCharacter(restored)
+			return restored.CurrentLP
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			m := NewModelWithRoller(dice.NewSeededRoller(1))
+			m.Config.AutoSave = true
+			m.Character, _ = character.New(50, 50, 50, 50, 50, 50, 50)
+			if err := m.SaveCharacter(); err != nil {
+				t.Fatalf("SaveCharacter() unexpected error: %v", err)
+			}
+			m.Character.SetLP(100)
+			if err := m.SaveCharacter(); err != nil {
+				t.Fatalf("SaveCharacter() unexpected error: %v", err)
+			}
+			path := m.Character.SavePath(m.SaveDirectory())
+
+			// The autosave of LP 50 waits while the slot is written
+			m.Character.SetLP(50)
+			cmd := m.startAutosave()
+			want := tt.write(t, &m)
+			msg := cmd().(AutosaveDoneMsg)
+			if !errors.Is(msg.Err, errSaveSuperseded) {
+				t.Errorf("autosave error = %v, want it skipped", msg.Err)
+			}
+			m.finishAutosave(msg)
+			if m.AutosaveErr != nil || m.Autosaving {
+				t.Errorf("AutosaveErr = %v, Autosaving = %v; want a quiet skip", m.AutosaveErr, m.Autosaving)
+			}
+
+			saved, err := character.Load(path)
+			if err != nil {
+				t.Fatalf("Load() unexpected error: %v", err)
+			}
+			if saved.CurrentLP != want {
+				t.Errorf("slot holds %d LP, want %d", saved.CurrentLP, want)
+			}
+		})
+	}
+}
+
+// newAutosaveModel returns a model with autosave on and a new character
+// that has no slot yet, saving under a temporary home directory.
+func newAutosaveModel(t *testing.T) Model {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModelWithRoller(dice.NewSeededRoller(1))
+	m.Config.AutoSave = true
+	m.Character, _ = character.New(50, 50, 50, 50, 50, 50, 50)
+	return m
+}
+
+// TestAutosaveTick verifies only ticks of the current interval save.
+func TestAutosaveTick(t *testing.T) {
+	tests := []struct {
+		name     string
+		stale    bool // The interval was changed after the tick was sent
+		wantSave bool
+	}{
+		{"Current interval", false, true},
+		{"Changed interval", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAutosaveModel(t)
+			tick := AutosaveTickMsg{Generation: m.AutosaveGeneration}
+			if tt.stale {
+				m.RestartAutosave()
+			}
+
+			next, cmd := m.Update(tick)
+			if got := next.(Model).Autosaving; got != tt.wantSave {
+				t.Errorf("Autosaving = %v, want %v", got, tt.wantSave)
+			}
+			if !tt.wantSave && cmd != nil {
+				t.Error("a stale tick should start nothing, not even the next tick")
+			}
+		})
+	}
+}
+
+// TestFinishAutosave verifies a finished save gives its slot only to the
+// character it saved, and a save asked for meanwhile follows.
+func TestFinishAutosave(t *testing.T) {
+	tests := []struct {
+		name     string
+		meantime func(m *Model) // What happens while the save is written
+		wantSlot bool           // The character now holds the saved slot
+		wantNext bool           // Another save starts
+	}{
+		{"Same character", func(m *Model) {}, true, false},
+		{"Other character loaded", func(m *Model) {
+			m.Character, _ = character.New(60, 60, 60, 60, 60, 60, 60)
+		}, false, false},
+		{"Save asked for", func(m *Model) { m.RequestAutosave() }, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAutosaveModel(t)
+			cmd := m.startAutosave()
+			if cmd == nil || !m.Autosaving {
+				t.Fatal("startAutosave() did not start a save")
+			}
+			tt.meantime(&m)
+			msg := cmd().(AutosaveDoneMsg)
+			if msg.Err != nil || msg.Slot == "" {
+				t.Fatalf("autosave = %+v, want a new slot", msg)
+			}
+
+			next := m.finishAutosave(msg)
+			if held := m.Character.Slot == msg.Slot && m.SlotLock != nil; held != tt.wantSlot {
+				t.Errorf("character slot %q, locked %v; want slot %q held: %v", m.Character.Slot, m.SlotLock != nil, msg.Slot, tt.wantSlot)
+			}
+			if (next != nil) != tt.wantNext || m.Autosaving != tt.wantNext {
+				t.Errorf("next save started = %v (Autosaving %v), want %v", next != nil, m.Autosaving, tt.wantNext)
+			}
+			if m.LastAutosave.IsZero() {
+				t.Error("LastAutosave not set")
+			}
+			m.ReleaseSlot()
+		})
+	}
+}
+
+// TestCanAutosave verifies which characters are saved in the background.
+func TestCanAutosave(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, m *Model)
+		want  bool
+	}{
+		{"New character", func(t *testing.T, m *Model) {}, true},
+		{"Holding its slot", func(t *testing.T, m *Model) {
+			if err := m.SaveCharacter(); err != nil {
+				t.Fatalf("SaveCharacter() unexpected error: %v", err)
+			}
+		}, true},
+		{"Left by Save & Exit", func(t *testing.T, m *Model) {
+			if err := m.SaveCharacter(); err != nil {
+				t.Fatalf("SaveCharacter() unexpected error: %v", err)
+			}
+			m.ReleaseSlot()
+		}, false},
+		{"Autosave off", func(t *testing.T, m *Model) { m.Config.AutoSave = false }, false},
+		{"No character", func(t *testing.T, m *Model) { m.Character = nil }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAutosaveModel(t)
+			tt.setup(t, &m)
+			if got := m.canAutosave(); got != tt.want {
+				t.Errorf("canAutosave() = %v, want %v", got, tt.want)
+			}
+			if cmd := m.startAutosave(); (cmd != nil) != tt.want {
+				t.Errorf("startAutosave() started a save: %v, want %v", cmd != nil, tt.want)
+			}
+			m.ReleaseSlot()
+		})
+	}
+}
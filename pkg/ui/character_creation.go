@@ -71,6 +71,7 @@ func (m *CharacterCreationModel) GetStep() CreationStep {
 
 // RollStrength rolls the Strength characteristic.
 func (m *CharacterCreationModel) RollStrength() int {
+	dice.Label(m.dice, "STR")
 	m.strength = m.dice.RollCharacteristic()
 	m.checkAllRolled()
 	return m.strength
@@ -78,6 +79,7 @@ func (m *CharacterCreationModel) RollStrength() int {
 
 // RollSpeed rolls the Speed characteristic.
 func (m *CharacterCreationModel) RollSpeed() int {
+	dice.Label(m.dice, "SPD")
 	m.speed = m.dice.RollCharacteristic()
 	m.checkAllRolled()
 	return m.speed
@@ -85,6 +87,7 @@ func (m *CharacterCreationModel) RollSpeed() int {
 
 // RollStamina rolls the Stamina characteristic.
 func (m *CharacterCreationModel) RollStamina() int {
+	dice.Label(m.dice, "STA")
 	m.stamina = m.dice.RollCharacteristic()
 	m.checkAllRolled()
 	return m.stamina
@@ -92,6 +95,7 @@ func (m *CharacterCreationModel) RollStamina() int {
 
 // RollCourage rolls the Courage characteristic.
 func (m *CharacterCreationModel) RollCourage() int {
+	dice.Label(m.dice, "CRG")
 	m.courage = m.dice.RollCharacteristic()
 	m.checkAllRolled()
 	return m.courage
@@ -99,6 +103,7 @@ func (m *CharacterCreationModel) RollCourage() int {
 
 // RollLuck rolls the Luck characteristic.
 func (m *CharacterCreationModel) RollLuck() int {
+	dice.Label(m.dice, "LCK")
 	m.luck = m.dice.RollCharacteristic()
 	m.checkAllRolled()
 	return m.luck
@@ -106,6 +111,7 @@ func (m *CharacterCreationModel) RollLuck() int {
 
 // RollCharm rolls the Charm characteristic.
 func (m *CharacterCreationModel) RollCharm() int {
+	dice.Label(m.dice, "CHM")
 	m.charm = m.dice.RollCharacteristic()
 	m.checkAllRolled()
 	return m.charm
@@ -113,6 +119,7 @@ func (m *CharacterCreationModel) RollCharm() int {
 
 // RollAttraction rolls the Attraction characteristic.
 func (m *CharacterCreationModel) RollAttraction() int {
+	dice.Label(m.dice, "ATT")
 	m.attraction = m.dice.RollCharacteristic()
 	m.checkAllRolled()
 	return m.attraction
@@ -2,13 +2,23 @@ package ui
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/internal/combat"
+	"github.com/benoit/saga-demonspawn/internal/items"
+	"github.com/benoit/saga-demonspawn/internal/rules"
 	"github.com/benoit/saga-demonspawn/pkg/ui/theme"
 )
 
+// bestiaryVisible is how many bestiary entries are listed at once.
+const bestiaryVisible = 8
+
 // CombatSetupModel handles manual enemy entry before combat.
 type CombatSetupModel struct {
 	// Enemy data fields
@@ -30,6 +40,25 @@ type CombatSetupModel struct {
 	inputMode    bool
 	errorMsg     string
 	fields       []string
+
+	// Enemies already added to the encounter
+	encounter []*combat.Enemy
+
+	// Odds simulation
+	simulating bool           // Whether a simulation is running
+	odds       []OddsScenario // Results of the last simulation
+	oddsErr    string         // Why the last simulation failed
+
+	// Bestiary picker
+	bestiary    *combat.Bestiary
+	saveDir     string                 // Directory holding the bestiary and exports
+	picking     bool                   // Whether the bestiary picker is open
+	query       string                 // Search text (name or section number)
+	results     []combat.BestiaryEntry // Entries matching the query
+	pickCursor  int                    // Selected entry
+	importing   bool                   // Whether typing the path of a file to import
+	importPath  string                 // Path being typed
+	bestiaryMsg string                 // Result of the last bestiary action
 }
 
 const (
@@ -76,9 +105,170 @@ func NewCombatSetupModel() CombatSetupModel {
 	}
 }
 
-// Reset clears all fields.
+// Reset clears all fields and the encounter. The bestiary is kept.
 func (m *CombatSetupModel) Reset() {
+	bestiary, saveDir := m.bestiary, m.saveDir
+	*m = NewCombatSetupModel()
+	m.bestiary, m.saveDir = bestiary, saveDir
+}
+
+// SetBestiary sets the enemy library and the directory it is saved in.
+func (m *CombatSetupModel) SetBestiary(bestiary *combat.Bestiary, saveDir string) {
+	m.bestiary = bestiary
+	m.saveDir = saveDir
+}
+
+// IsBusy returns true while typing a value or using the bestiary picker,
+// when Esc belongs to the setup screen rather than leaving it.
+func (m *CombatSetupModel) IsBusy() bool {
+	return m.inputMode || m.picking
+}
+
+// OpenBestiary shows the bestiary picker.
+func (m *CombatSetupModel) OpenBestiary() {
+	if m.bestiary == nil {
+		m.errorMsg = "the bestiary could not be loaded"
+		return
+	}
+	m.picking = true
+	m.importing = false
+	m.query = ""
+	m.bestiaryMsg = ""
+	m.refreshResults()
+}
+
+// refreshResults reruns the bestiary search for the current query.
+func (m *CombatSetupModel) refreshResults() {
+	m.results = m.bestiary.Search(m.query)
+	if m.pickCursor >= len(m.results) {
+		m.pickCursor = len(m.results) - 1
+	}
+	if m.pickCursor < 0 {
+		m.pickCursor = 0
+	}
+}
+
+// LoadTemplate fills the enemy fields from a bestiary entry.
+func (m *CombatSetupModel) LoadTemplate(entry combat.BestiaryEntry) {
+	e := entry.Enemy
+	m.name = e.Name
+	m.strength = strconv.Itoa(e.Strength)
+	m.speed = strconv.Itoa(e.Speed)
+	m.stamina = strconv.Itoa(e.Stamina)
+	m.courage = strconv.Itoa(e.Courage)
+	m.luck = strconv.Itoa(e.Luck)
+	m.skill = strconv.Itoa(e.Skill)
+	m.currentLP = strconv.Itoa(e.CurrentLP)
+	m.maximumLP = strconv.Itoa(e.MaximumLP)
+	m.weaponBonus = strconv.Itoa(e.WeaponBonus)
+	m.armorProtection = strconv.Itoa(e.ArmorProtection)
+	m.isDemonspawn = e.IsDemonspawn
+	m.errorMsg = ""
+}
+
+// ExportBestiary writes a copy of the bestiary to a timestamped file in
+// the save directory, ready to share.
+func (m *CombatSetupModel) ExportBestiary() {
+	path := filepath.Join(m.saveDir, fmt.Sprintf("bestiary_export_%s.json", time.Now().Format("20060102-150405")))
+	if err := m.bestiary.Save(path); err != nil {
+		m.bestiaryMsg = err.Error()
+		return
+	}
+	m.bestiaryMsg = fmt.Sprintf("Exported %d enemies to %s", len(m.bestiary.Entries), path)
+}
+
+// ImportBestiary merges a shared bestiary file into the library and saves it.
+func (m *CombatSetupModel) ImportBestiary(path string) {
+	path = strings.TrimSpace(path)
+	if _, err := os.Stat(path); err != nil {
+		m.bestiaryMsg = fmt.Sprintf("cannot import %s: %v", path, err)
+		return
+	}
+	other, err := combat.LoadBestiary(path)
+	if err != nil {
+		m.bestiaryMsg = err.Error()
+		return
+	}
+	added, updated, err := m.bestiary.Import(other)
+	if err != nil {
+		m.bestiaryMsg = err.Error()
+		return
+	}
+	if err := m.bestiary.Save(combat.BestiaryPath(m.saveDir)); err != nil {
+		m.bestiaryMsg = err.Error()
+		return
+	}
+	m.refreshResults()
+	m.bestiaryMsg = fmt.Sprintf("Imported %d new and %d updated enemies", added, updated)
+}
+
+// removeSelected deletes the highlighted entry from the library and saves it.
+func (m *CombatSetupModel) removeSelected() {
+	if m.pickCursor >= len(m.results) {
+		return
+	}
+	name := m.results[m.pickCursor].Enemy.Name
+	m.bestiary.Remove(name)
+	if err := m.bestiary.Save(combat.BestiaryPath(m.saveDir)); err != nil {
+		m.bestiaryMsg = err.Error()
+		return
+	}
+	m.refreshResults()
+	m.bestiaryMsg = fmt.Sprintf("Removed %s from the bestiary", name)
+}
+
+// AddToEncounter adds the enemy being entered to the encounter and clears
+// the fields for the next one.
+func (m *CombatSetupModel) AddToEncounter() error {
+	enemy, err := m.buildEnemy()
+	if err != nil {
+		return err
+	}
+	m.encounter = append(m.encounter, enemy)
+
+	encounter, bestiary, saveDir := m.encounter, m.bestiary, m.saveDir
 	*m = NewCombatSetupModel()
+	m.encounter, m.bestiary, m.saveDir = encounter, bestiary, saveDir
+	return nil
+}
+
+// RemoveLastEnemy takes the most recently added enemy out of the encounter.
+func (m *CombatSetupModel) RemoveLastEnemy() {
+	if len(m.encounter) > 0 {
+		m.encounter = m.encounter[:len(m.encounter)-1]
+	}
+}
+
+// BuildEncounter returns every enemy of the fight: those already added and
+// the one being entered, if any.
+func (m *CombatSetupModel) BuildEncounter() ([]*combat.Enemy, error) {
+	enemies := append([]*combat.Enemy{}, m.encounter...)
+	if strings.TrimSpace(m.name) == "" && len(enemies) > 0 {
+		return enemies, nil
+	}
+	enemy, err := m.buildEnemy()
+	if err != nil {
+		return nil, err
+	}
+	return append(enemies, enemy), nil
+}
+
+// buildEnemy validates the fields and creates the enemy they describe.
+func (m *CombatSetupModel) buildEnemy() (*combat.Enemy, error) {
+	if err := m.ValidateAndPrepare(); err != nil {
+		return nil, err
+	}
+	return combat.NewEnemy(m.GetEnemyData())
+}
+
+// SetOdds shows the results of a finished odds simulation.
+func (m *CombatSetupModel) SetOdds(msg OddsResultMsg) {
+	m.simulating = false
+	m.odds = msg.Scenarios
+	m.oddsErr = ""
+	if msg.Err != nil {
+		m.oddsErr = msg.Err.Error()
+	}
 }
 
 // GetFieldValue returns the current value of the focused field.
@@ -206,6 +396,9 @@ func (m *CombatSetupModel) GetEnemyData() (name string, str, spd, sta, crg, lck,
 func (m CombatSetupModel) Update(msg tea.Msg) (CombatSetupModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.picking {
+			return m.handleBestiaryMode(msg)
+		}
 		if m.inputMode {
 			return m.handleInputMode(msg)
 		}
@@ -224,6 +417,28 @@ func (m CombatSetupModel) handleNavigationMode(msg tea.KeyMsg) (CombatSetupModel
 		if m.focusedField < fieldTotalFields-1 {
 			m.focusedField++
 		}
+	case "b":
+		m.OpenBestiary()
+	case "a":
+		if err := m.AddToEncounter(); err != nil {
+			m.errorMsg = err.Error()
+		}
+	case "x":
+		m.RemoveLastEnemy()
+	case "o":
+		if m.simulating {
+			return m, nil
+		}
+		if _, err := m.BuildEncounter(); err != nil {
+			m.errorMsg = err.Error()
+			return m, nil
+		}
+		m.simulating = true
+		m.odds = nil
+		m.oddsErr = ""
+		return m, func() tea.Msg {
+			return SimulateOddsMsg{}
+		}
 	case "enter":
 		// Handle toggle for Demonspawn field
 		if m.focusedField == fieldIsDemonspawn {
@@ -237,7 +452,7 @@ func (m CombatSetupModel) handleNavigationMode(msg tea.KeyMsg) (CombatSetupModel
 		}
 		// Start combat if on that field
 		if m.focusedField == fieldStartCombat {
-			if err := m.ValidateAndPrepare(); err != nil {
+			if _, err := m.BuildEncounter(); err != nil {
 				m.errorMsg = err.Error()
 				return m, nil
 			}
@@ -250,6 +465,65 @@ func (m CombatSetupModel) handleNavigationMode(msg tea.KeyMsg) (CombatSetupModel
 	return m, nil
 }
 
+func (m CombatSetupModel) handleBestiaryMode(msg tea.KeyMsg) (CombatSetupModel, tea.Cmd) {
+	// Typing the path of a bestiary file to import
+	if m.importing {
+		switch msg.String() {
+		case "esc":
+			m.importing = false
+		case "enter":
+			m.importing = false
+			m.ImportBestiary(m.importPath)
+		case "backspace":
+			if len(m.importPath) > 0 {
+				m.importPath = m.importPath[:len(m.importPath)-1]
+			}
+		default:
+			if len(msg.Runes) > 0 {
+				m.importPath += string(msg.Runes)
+			}
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.picking = false
+	case "up":
+		if m.pickCursor > 0 {
+			m.pickCursor--
+		}
+	case "down":
+		if m.pickCursor < len(m.results)-1 {
+			m.pickCursor++
+		}
+	case "enter":
+		if m.pickCursor < len(m.results) {
+			m.LoadTemplate(m.results[m.pickCursor])
+			m.picking = false
+			m.focusedField = fieldStartCombat
+		}
+	case "ctrl+e":
+		m.ExportBestiary()
+	case "ctrl+o":
+		m.importing = true
+		m.importPath = ""
+	case "ctrl+d":
+		m.removeSelected()
+	case "backspace":
+		if len(m.query) > 0 {
+			m.query = m.query[:len(m.query)-1]
+			m.refreshResults()
+		}
+	default:
+		if len(msg.Runes) > 0 {
+			m.query += string(msg.Runes)
+			m.refreshResults()
+		}
+	}
+	return m, nil
+}
+
 func (m CombatSetupModel) handleInputMode(msg tea.KeyMsg) (CombatSetupModel, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
@@ -279,10 +553,24 @@ func (m CombatSetupModel) View() string {
 	var s strings.Builder
 	t := theme.Current()
 
+	if m.picking {
+		return m.viewBestiary()
+	}
+
 	s.WriteString("\n")
 	s.WriteString(theme.RenderTitle("COMBAT SETUP - Enter Enemy"))
 	s.WriteString("\n\n")
 
+	// Enemies already in the encounter
+	if len(m.encounter) > 0 {
+		names := make([]string, len(m.encounter))
+		for i, enemy := range m.encounter {
+			names[i] = fmt.Sprintf("%s (%d LP)", enemy.Name, enemy.CurrentLP)
+		}
+		s.WriteString("  " + theme.RenderLabel("Encounter", strings.Join(names, ", ")) + "\n")
+		s.WriteString("  " + t.MutedText.Render("Leave the name empty to fight these enemies only.") + "\n\n")
+	}
+
 	// Render fields
 	for i := 0; i < fieldStartCombat; i++ {
 		focused := i == m.focusedField
@@ -324,7 +612,7 @@ func (m CombatSetupModel) View() string {
 	if m.inputMode {
 		s.WriteString(theme.RenderKeyHelp("Type to edit", "Enter Confirm", "Esc Cancel") + "\n")
 	} else {
-		s.WriteString(theme.RenderKeyHelp("↑/↓ Navigate", "Enter Edit/Confirm", "Esc Back", "? Help") + "\n")
+		s.WriteString(theme.RenderKeyHelp("↑/↓ Navigate", "Enter Edit/Confirm", "b Pick from bestiary", "a Add another enemy", "x Remove last", "o Simulate odds", "Esc Back", "? Help") + "\n")
 	}
 
 	// Error message
@@ -332,8 +620,152 @@ func (m CombatSetupModel) View() string {
 		s.WriteString("\n" + theme.RenderError("Input Error", m.errorMsg, "Check your values and try again") + "\n")
 	}
 
+	s.WriteString(m.viewOdds())
+
+	return s.String()
+}
+
+// viewOdds renders the results of the odds simulation.
+func (m CombatSetupModel) viewOdds() string {
+	var s strings.Builder
+	t := theme.Current()
+
+	switch {
+	case m.simulating:
+		s.WriteString("\n" + t.MutedText.Render(fmt.Sprintf("  Simulating %d fights...", oddsFights)) + "\n")
+	case m.oddsErr != "":
+		s.WriteString("\n" + theme.RenderError("Simulation Failed", m.oddsErr, "") + "\n")
+	case len(m.odds) > 0:
+		s.WriteString("\n" + t.Heading.Render(fmt.Sprintf("  Odds over %d fights", m.odds[0].Result.Fights)) + "\n")
+		for _, scenario := range m.odds {
+			r := scenario.Result
+			line := fmt.Sprintf("%-24s win %5.1f%%  rounds %4.1f  death save %5.1f%%  LP left %3.0f",
+				scenario.Label, r.WinProbability()*100, r.ExpectedRounds(), r.DeathSaveRate()*100, r.AverageLPRemaining())
+			s.WriteString("  " + t.Body.Render(line) + "\n")
+		}
+
+		// LP distribution of the first scenario
+		r := m.odds[0].Result
+		s.WriteString("\n" + t.Label.Render("  LP remaining ("+m.odds[0].Label+"):") + "\n")
+		for bucket, count := range r.LPDistribution {
+			share := float64(count) / float64(r.Fights)
+			bar := strings.Repeat("█", int(share*30+0.5))
+			s.WriteString(t.MutedText.Render(fmt.Sprintf("    %-8s %5.1f%% ", combat.LPBucketLabel(bucket), share*100)) + t.Value.Render(bar) + "\n")
+		}
+	}
+
+	return s.String()
+}
+
+// viewBestiary renders the bestiary picker.
+func (m CombatSetupModel) viewBestiary() string {
+	var s strings.Builder
+	t := theme.Current()
+
+	s.WriteString("\n")
+	s.WriteString(theme.RenderTitle("COMBAT SETUP - Bestiary"))
+	s.WriteString("\n\n")
+
+	s.WriteString("  " + t.Label.Render("Search (name or section): ") + t.Emphasis.Render("["+m.query+"_]") + "\n")
+	s.WriteString("  " + t.MutedText.Render(fmt.Sprintf("%d of %d enemies", len(m.results), len(m.bestiary.Entries))) + "\n\n")
+
+	if len(m.results) == 0 {
+		s.WriteString(t.MutedText.Render("  No matching enemies. Save enemies after combat to build the bestiary.") + "\n")
+	}
+
+	// Scroll the list so the cursor stays visible
+	start := 0
+	if m.pickCursor >= bestiaryVisible {
+		start = m.pickCursor - bestiaryVisible + 1
+	}
+	for i := start; i < len(m.results) && i < start+bestiaryVisible; i++ {
+		entry := m.results[i]
+		e := entry.Enemy
+		line := fmt.Sprintf("%-20s LP %-4d SKL %-3d WPN +%-2d ARM -%d", e.Name, e.MaximumLP, e.Skill, e.WeaponBonus, e.ArmorProtection)
+		s.WriteString("  " + theme.RenderMenuItem(line, i == m.pickCursor) + "\n")
+		if i == m.pickCursor && len(entry.Sections) > 0 {
+			sections := make([]string, len(entry.Sections))
+			for j, section := range entry.Sections {
+				sections[j] = strconv.Itoa(section)
+			}
+			s.WriteString("      " + t.MutedText.Render("Sections: "+strings.Join(sections, ", ")) + "\n")
+		}
+	}
+	s.WriteString("\n")
+
+	if m.importing {
+		s.WriteString("  " + t.Label.Render("Import file: ") + t.Emphasis.Render("["+m.importPath+"_]") + "\n\n")
+		s.WriteString(theme.RenderKeyHelp("Type path", "Enter Import", "Esc Cancel") + "\n")
+	} else {
+		s.WriteString(theme.RenderKeyHelp("Type to search", "↑/↓ Select", "Enter Use", "Ctrl+E Export", "Ctrl+O Import", "Ctrl+D Delete", "Esc Back") + "\n")
+	}
+
+	if m.bestiaryMsg != "" {
+		s.WriteString("\n  " + t.Emphasis.Render(m.bestiaryMsg) + "\n")
+	}
+
 	return s.String()
 }
 
+// oddsFights is how many fights the setup screen simulates.
+const oddsFights = 10000
+
+// oddsHealBelow is the LP share (%) under which simulations use the Healing Stone.
+const oddsHealBelow = 33
+
+// OddsScenario is the simulated outcome of one way to fight the encounter.
+type OddsScenario struct {
+	Label  string
+	Result combat.SimulationResult
+}
+
+// SimulateOddsMsg asks for the odds of the encounter being set up.
+type SimulateOddsMsg struct{}
+
+// OddsResultMsg carries finished odds simulations back to the setup screen.
+type OddsResultMsg struct {
+	Scenarios []OddsScenario
+	Err       error
+}
+
+// simulateOdds returns a command that simulates the encounter in the
+// background: as equipped, then with the Healing Stone and Doombringer
+// when the character has them. The character is copied first so the
+// simulation never races with the UI.
+func simulateOdds(player *character.Character, enemies []*combat.Enemy, ruleset rules.Ruleset) tea.Cmd {
+	type variant struct {
+		label     string
+		player    *character.Character
+		healBelow int
+	}
+	variants := []variant{{"As equipped", player.Clone(), 0}}
+	if player.HealingStoneCharges > 0 {
+		variants = append(variants, variant{fmt.Sprintf("Healing Stone < %d%% LP", oddsHealBelow), player.Clone(), oddsHealBelow})
+	}
+	doombringerEquipped := player.EquippedWeapon != nil && player.EquippedWeapon.Name == items.DoombringerName
+	if player.DoombringerPossessed && !doombringerEquipped {
+		wielder := player.Clone()
+		doombringer := items.WeaponDoombringer
+		wielder.EquipWeapon(&doombringer)
+		variants = append(variants, variant{"Wielding Doombringer", wielder, 0})
+	}
+
+	return func() tea.Msg {
+		scenarios := []OddsScenario{}
+		for _, v := range variants {
+			result, err := combat.Simulate(v.player, enemies, combat.SimulationOptions{
+				Fights:    oddsFights,
+				HealBelow: v.healBelow,
+				Rules:     ruleset,
+			})
+			if err != nil {
+				return OddsResultMsg{Err: err}
+			}
+			scenarios = append(scenarios, OddsScenario{Label: v.label, Result: result})
+		}
+		return OddsResultMsg{Scenarios: scenarios}
+	}
+}
+
 // CombatStartMsg signals that combat should begin.
 type CombatStartMsg struct{}
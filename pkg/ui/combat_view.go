@@ -23,7 +23,6 @@ type CombatViewModel struct {
 	victoryState    bool
 	defeatState     bool
 	needsRest       bool
-	needsEnemyRest  bool
 	deathSaveActive bool
 
 	// Action menu
@@ -41,7 +40,34 @@ const (
 
 // NewCombatViewModel creates a new combat view model.
 func NewCombatViewModel(player *character.Character, combatState *combat.CombatState, roller dice.Roller) CombatViewModel {
-	// Build action list based on available items
+	return CombatViewModel{
+		player:          player,
+		combatState:     combatState,
+		roller:          roller,
+		selectedAction:  actionAttack,
+		waitingForInput: true,
+		victoryState:    false,
+		defeatState:     false,
+		needsRest:       false,
+		deathSaveActive: false,
+		actions:         combatActions(player),
+	}
+}
+
+// NewResumedCombatViewModel creates a combat view model for a fight
+// restored from a save. A player saved at 0 LP or below goes straight to
+// the death save.
+func NewResumedCombatViewModel(player *character.Character, combatState *combat.CombatState, roller dice.Roller) CombatViewModel {
+	m := NewCombatViewModel(player, combatState, roller)
+	m.waitingForInput = combatState.PlayerTurn
+	if combat.CheckDefeat(player, combatState) && !combatState.DeathSaveUsed {
+		m.deathSaveActive = true
+	}
+	return m
+}
+
+// combatActions builds the action menu from the items the player can use.
+func combatActions(player *character.Character) []string {
 	actions := []string{"Attack"}
 	
 	// Add Cast Spell option if magic is unlocked
@@ -61,19 +87,7 @@ func NewCombatViewModel(player *character.Character, combatState *combat.CombatS
 		actions = append(actions, "Throw The Orb")
 	}
 	
-	return CombatViewModel{
-		player:          player,
-		combatState:     combatState,
-		roller:          roller,
-		selectedAction:  actionAttack,
-		waitingForInput: true,
-		victoryState:    false,
-		defeatState:     false,
-		needsRest:       false,
-		needsEnemyRest:  false,
-		deathSaveActive: false,
-		actions:         actions,
-	}
+	return actions
 }
 
 // Update handles combat view input.
@@ -82,10 +96,20 @@ func (m CombatViewModel) Update(msg tea.Msg) (CombatViewModel, tea.Cmd) {
 	if m.victoryState || m.defeatState {
 		switch msg := msg.(type) {
 		case tea.KeyMsg:
-			if msg.String() == "enter" {
+			switch msg.String() {
+			case "enter":
 				return m, func() tea.Msg {
 					return CombatEndMsg{Victory: m.victoryState}
 				}
+			case "s":
+				return m, func() tea.Msg {
+					return SaveEnemyMsg{}
+				}
+			case "m", "c", "j":
+				format := map[string]string{"m": combat.FormatMarkdown, "c": combat.FormatCSV, "j": combat.FormatJSON}[msg.String()]
+				return m, func() tea.Msg {
+					return ExportCombatMsg{Format: format}
+				}
 			}
 		}
 		return m, nil
@@ -97,10 +121,8 @@ func (m CombatViewModel) Update(msg tea.Msg) (CombatViewModel, tea.Cmd) {
 		case tea.KeyMsg:
 			if msg.String() == "enter" {
 				roll, success := combat.AttemptDeathSave(m.player, m.combatState, m.roller)
+				m.combatState.LogDeathSave(m.player, roll, success)
 				if success {
-					m.combatState.AddLogEntry(fmt.Sprintf("[Death Save] Rolled %d vs Luck %d - SUCCESS!", roll, m.player.Luck))
-					m.combatState.AddLogEntry(fmt.Sprintf("[Death Save] Restored to %d LP. Combat restarted!", m.player.CurrentLP))
-					m.combatState.AddLogEntry(fmt.Sprintf("[Initiative] Player: %d, Enemy: %d", m.combatState.PlayerInitiative, m.combatState.EnemyInitiative))
 					m.deathSaveActive = false
 					m.waitingForInput = m.combatState.PlayerTurn
 					// If it's enemy turn after death save, trigger enemy turn
@@ -111,7 +133,7 @@ func (m CombatViewModel) Update(msg tea.Msg) (CombatViewModel, tea.Cmd) {
 					}
 					return m, nil
 				} else {
-					m.combatState.AddLogEntry(fmt.Sprintf("[Death Save] Rolled %d vs Luck %d - FAILED!", roll, m.player.Luck))
+					m.combatState.LogDefeat()
 					m.defeatState = true
 					return m, nil
 				}
@@ -143,6 +165,10 @@ func (m CombatViewModel) Update(msg tea.Msg) (CombatViewModel, tea.Cmd) {
 				if m.selectedAction < len(m.actions)-1 {
 					m.selectedAction++
 				}
+			case "left", "h":
+				m.combatState.CycleTarget(-1)
+			case "right", "l", "tab":
+				m.combatState.CycleTarget(1)
 			case "enter":
 				return m.handleAction()
 			}
@@ -172,7 +198,7 @@ func (m CombatViewModel) handleAction() (CombatViewModel, tea.Cmd) {
 	
 	case "Attack":
 		// Check if rest is needed before attack
-		if combat.CheckEndurance(m.combatState.RoundsSinceLastRest, m.combatState.EnduranceLimit) {
+		if m.combatState.PlayerMustRest() {
 			m.combatState.AddLogEntry(fmt.Sprintf("[Round %d] Endurance depleted! Must rest.", m.combatState.CurrentRound))
 			m.needsRest = true
 			m.waitingForInput = false
@@ -181,84 +207,11 @@ func (m CombatViewModel) handleAction() (CombatViewModel, tea.Cmd) {
 			}
 		}
 
-		// Check if Doombringer is equipped - apply blood price BEFORE attack
-		isDoombringerEquipped := m.player.EquippedWeapon != nil && m.player.EquippedWeapon.Name == "Doombringer"
-		if isDoombringerEquipped {
-			// Blood price: 10 LP before attack
-			m.player.ModifyLP(-10)
-			m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Doombringer thirsts for blood... -10 LP", m.combatState.CurrentRound))
-			m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Current LP: %d/%d", m.combatState.CurrentRound, m.player.CurrentLP, m.player.MaximumLP))
-			
-			// Check if blood price killed the player
-			if m.player.CurrentLP <= 0 {
-				m.combatState.AddLogEntry("[Defeat] Doombringer has drained your life!")
-				m.defeatState = true
-				return m, nil
-			}
-		}
-
-		// Execute player attack
-		result := combat.ExecutePlayerAttack(m.combatState, m.player, m.roller)
-		
-		if result.Hit {
-			m.combatState.AddLogEntry(fmt.Sprintf("[R%d] You rolled %d (need %d+) - HIT!", m.combatState.CurrentRound, result.Roll, result.Requirement))
-			
-			// Check if The Orb is equipped and enemy is Demonspawn - double damage
-			if m.player.OrbEquipped && m.combatState.Enemy.IsDemonspawn {
-				// Calculate how much damage was actually dealt
-				originalDamage := result.FinalDamage
-				doubledDamage := originalDamage * 2
-				
-				// Apply additional damage to enemy
-				m.combatState.Enemy.CurrentLP -= originalDamage // Subtract the original amount again
-				if m.combatState.Enemy.CurrentLP < 0 {
-					m.combatState.Enemy.CurrentLP = 0
-				}
-				
-				m.combatState.AddLogEntry(fmt.Sprintf("[R%d] The Orb pulses with power! Damage doubled: %d → %d", m.combatState.CurrentRound, originalDamage, doubledDamage))
-				m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Damage: (%d×5) + STR + Weapon - Armor = %d", m.combatState.CurrentRound, result.Roll, doubledDamage))
-				m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Enemy takes %d damage (%d LP remaining)", m.combatState.CurrentRound, doubledDamage, m.combatState.Enemy.CurrentLP))
-				
-				// Update result for Doombringer healing calculation
-				result.FinalDamage = doubledDamage
-				result.TargetLP = m.combatState.Enemy.CurrentLP
-			} else {
-				m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Damage: (%d×5) + STR + Weapon - Armor = %d", m.combatState.CurrentRound, result.Roll, result.FinalDamage))
-				m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Enemy takes %d damage (%d LP remaining)", m.combatState.CurrentRound, result.FinalDamage, result.TargetLP))
-			}
-			
-			// Doombringer soul thirst: heal LP equal to damage dealt (capped at enemy's current LP and MaximumLP)
-			if isDoombringerEquipped && result.FinalDamage > 0 {
-				oldLP := m.player.CurrentLP
-				healAmount := result.FinalDamage
-				
-				// If enemy died from this hit, cap healing at enemy's LP before the hit
-				if result.TargetLP <= 0 {
-					enemyLPBeforeHit := result.TargetLP + result.FinalDamage
-					if enemyLPBeforeHit < healAmount {
-						healAmount = enemyLPBeforeHit
-					}
-				}
-				
-				// Cap healing at MaximumLP
-				if m.player.CurrentLP + healAmount > m.player.MaximumLP {
-					healAmount = m.player.MaximumLP - m.player.CurrentLP
-				}
-				
-				if healAmount > 0 {
-					m.player.ModifyLP(healAmount)
-					actualHeal := m.player.CurrentLP - oldLP
-					m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Doombringer feeds on pain... +%d LP healed!", m.combatState.CurrentRound, actualHeal))
-					m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Current LP: %d/%d", m.combatState.CurrentRound, m.player.CurrentLP, m.player.MaximumLP))
-				} else {
-					m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Doombringer feeds on pain... (already at maximum LP)", m.combatState.CurrentRound))
-				}
-			}
-		} else {
-			m.combatState.AddLogEntry(fmt.Sprintf("[R%d] You rolled %d (need %d+) - MISS!", m.combatState.CurrentRound, result.Roll, result.Requirement))
-			if isDoombringerEquipped {
-				m.combatState.AddLogEntry(fmt.Sprintf("[R%d] No healing from Doombringer on miss", m.combatState.CurrentRound))
-			}
+		result := combat.Attack(m.combatState, m.player, m.roller)
+		m.combatState.LogPlayerAttack(m.player, result)
+		if result.Drained {
+			m.defeatState = true
+			return m, nil
 		}
 
 		m.waitingForInput = false
@@ -267,7 +220,8 @@ func (m CombatViewModel) handleAction() (CombatViewModel, tea.Cmd) {
 		}
 
 	case "Flee Combat":
-		m.combatState.AddLogEntry("[Fled] You fled from combat!")
+		combat.Flee(m.combatState)
+		m.combatState.LogFlee()
 		return m, func() tea.Msg {
 			return CombatEndMsg{Victory: false}
 		}
@@ -275,107 +229,25 @@ func (m CombatViewModel) handleAction() (CombatViewModel, tea.Cmd) {
 	default:
 		// Handle dynamic action names (Healing Stone with charges, Throw Orb)
 		if strings.HasPrefix(actionName, "Use Healing Stone") {
-			// Check if Healing Stone is available
-		if m.player.HealingStoneCharges <= 0 {
-			m.combatState.AddLogEntry("[Healing Stone] The stone is depleted!")
-			m.waitingForInput = false
-			return m, func() tea.Msg {
-				return PlayerAttackCompleteMsg{}
-			}
-		}
-		
-		// Check if already at max LP
-		if m.player.CurrentLP >= m.player.MaximumLP {
-			m.combatState.AddLogEntry("[Healing Stone] You are already at full health!")
-			m.waitingForInput = false
-			return m, func() tea.Msg {
-				return PlayerAttackCompleteMsg{}
+			result, err := combat.UseHealingStone(m.player, m.combatState.Ruleset(), m.roller)
+			if err != nil {
+				m.combatState.AddLogEntry(fmt.Sprintf("[Healing Stone] Cannot use: %v", err))
+			} else {
+				m.combatState.LogHealingStone(m.player, result)
 			}
-		}
-		
-		// Roll healing amount (1d6 × 10)
-		roll := m.roller.Roll1D6()
-		healAmount := roll * 10
-		
-		// Use the healing stone
-		actualHeal, err := m.player.UseHealingStone(healAmount)
-		if err != nil {
-			m.combatState.AddLogEntry(fmt.Sprintf("[Healing Stone] Error: %v", err))
+			m.refreshActions()
 			m.waitingForInput = false
 			return m, func() tea.Msg {
 				return PlayerAttackCompleteMsg{}
 			}
-		}
-		
-		// Log healing
-		m.combatState.AddLogEntry(fmt.Sprintf("[R%d] You invoke the Healing Stone... (rolled %d)", m.combatState.CurrentRound, roll))
-		m.combatState.AddLogEntry(fmt.Sprintf("[R%d] +%d LP restored! (Charges: %d/50)", m.combatState.CurrentRound, actualHeal, m.player.HealingStoneCharges))
-		m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Current LP: %d/%d", m.combatState.CurrentRound, m.player.CurrentLP, m.player.MaximumLP))
-		
-		// Update action list if charges depleted
-		if m.player.HealingStoneCharges <= 0 {
-			m.actions = []string{"Attack", "Flee Combat"}
-		}
-		
-			// Keep waiting for input - it's still player's turn
-			m.waitingForInput = true
-			return m, func() tea.Msg {
-				return PlayerAttackCompleteMsg{}
-			}
 		} else if actionName == "Throw The Orb" {
-			// Check if Orb is still available
-		if m.player.OrbDestroyed || !m.player.OrbPossessed {
-			m.combatState.AddLogEntry("[The Orb] The Orb has been destroyed!")
-			m.waitingForInput = false
-			return m, func() tea.Msg {
-				return PlayerAttackCompleteMsg{}
-			}
-		}
-		
-		// Check if Orb is equipped (can't throw while held)
-		if m.player.OrbEquipped {
-			m.combatState.AddLogEntry("[The Orb] Unequip The Orb before throwing!")
-			m.waitingForInput = false
-			return m, func() tea.Msg {
-				return PlayerAttackCompleteMsg{}
-			}
-		}
-		
-		// Roll 2d6 for throw (hit on 4+)
-		roll := m.roller.Roll2D6()
-		hit := roll >= 4
-		
-		m.combatState.AddLogEntry(fmt.Sprintf("[R%d] You hurl The Orb at the enemy!", m.combatState.CurrentRound))
-		m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Rolled %d (need 4+)", m.combatState.CurrentRound, roll))
-		
-		if m.combatState.Enemy.IsDemonspawn {
-			if hit {
-				// Instant kill
-				m.combatState.Enemy.CurrentLP = 0
-				m.combatState.AddLogEntry("[The Orb] The Orb strikes true! The Demonspawn is annihilated in brilliant light!")
+			result, err := combat.ThrowOrb(m.combatState, m.player, m.roller)
+			if err != nil {
+				m.combatState.AddLogEntry(fmt.Sprintf("[The Orb] Cannot throw: %v", err))
 			} else {
-				// Deal 200 damage
-				m.combatState.Enemy.CurrentLP -= 200
-				if m.combatState.Enemy.CurrentLP < 0 {
-					m.combatState.Enemy.CurrentLP = 0
-				}
-				m.combatState.AddLogEntry(fmt.Sprintf("[The Orb] The Orb's light sears the Demonspawn! 200 damage dealt! (%d LP remaining)", m.combatState.Enemy.CurrentLP))
+				m.combatState.LogOrbThrow(result)
 			}
-		} else {
-			// No effect on non-Demonspawn
-			m.combatState.AddLogEntry("[The Orb] The Orb has no effect on this creature!")
-		}
-		
-		// Destroy the Orb
-		m.player.DestroyOrb()
-		m.combatState.AddLogEntry("[The Orb] The Orb explodes and is destroyed!")
-		
-			// Update actions list to remove Throw Orb option
-			m.actions = []string{"Attack", "Flee Combat"}
-			if m.player.HealingStoneCharges > 0 {
-				m.actions = append(m.actions, fmt.Sprintf("Use Healing Stone (%d charges)", m.player.HealingStoneCharges))
-			}
-			
+			m.refreshActions()
 			m.waitingForInput = false
 			return m, func() tea.Msg {
 				return PlayerAttackCompleteMsg{}
@@ -386,21 +258,18 @@ func (m CombatViewModel) handleAction() (CombatViewModel, tea.Cmd) {
 	return m, nil
 }
 
+// refreshActions rebuilds the action menu after an item is used up.
+func (m *CombatViewModel) refreshActions() {
+	m.actions = combatActions(m.player)
+	if m.selectedAction >= len(m.actions) {
+		m.selectedAction = len(m.actions) - 1
+	}
+}
+
 func (m CombatViewModel) processEnemyTurn() (CombatViewModel, tea.Cmd) {
-	// If player resting, enemy gets free attack
+	// If player resting, every enemy gets a free attack
 	if m.needsRest {
-		m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Enemy attacks while you rest...", m.combatState.CurrentRound))
-		result := combat.ExecuteEnemyAttack(m.combatState, m.player, m.roller)
-		
-		if result.Hit {
-			m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Enemy rolled %d (need %d+) - HIT!", m.combatState.CurrentRound, result.Roll, result.Requirement))
-			m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Enemy deals %d damage (%d LP remaining)", m.combatState.CurrentRound, result.FinalDamage, result.TargetLP))
-		} else {
-			m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Enemy rolled %d (need %d+) - MISS!", m.combatState.CurrentRound, result.Roll, result.Requirement))
-		}
-
-		combat.ProcessRest(m.combatState)
-		m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Rested! Endurance restored.", m.combatState.CurrentRound))
+		m.combatState.LogRest(combat.Rest(m.combatState, m.player, m.roller))
 		m.needsRest = false
 		m.waitingForInput = true
 		
@@ -409,77 +278,12 @@ func (m CombatViewModel) processEnemyTurn() (CombatViewModel, tea.Cmd) {
 		}
 	}
 
-	// Check if enemy needs to rest
-	if combat.CheckEndurance(m.combatState.EnemyRoundsSinceLastRest, m.combatState.EnemyEnduranceLimit) {
-		m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Enemy endurance depleted! Enemy must rest.", m.combatState.CurrentRound))
-		m.needsEnemyRest = true
-		
-		// Player gets free attack while enemy rests
-		m.combatState.AddLogEntry(fmt.Sprintf("[R%d] You attack while the enemy rests...", m.combatState.CurrentRound))
-		
-		// Check if Doombringer is equipped - apply blood price BEFORE attack
-		isDoombringerEquipped := m.player.EquippedWeapon != nil && m.player.EquippedWeapon.Name == "Doombringer"
-		if isDoombringerEquipped {
-			m.player.ModifyLP(-10)
-			m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Doombringer thirsts for blood... -10 LP", m.combatState.CurrentRound))
-			
-			if m.player.CurrentLP <= 0 {
-				m.combatState.AddLogEntry("[Defeat] Doombringer has drained your life!")
-				m.defeatState = true
-				return m, nil
-			}
-		}
-		
-		result := combat.ExecutePlayerAttack(m.combatState, m.player, m.roller)
-		
-		if result.Hit {
-			m.combatState.AddLogEntry(fmt.Sprintf("[R%d] You rolled %d (need %d+) - HIT!", m.combatState.CurrentRound, result.Roll, result.Requirement))
-			m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Enemy takes %d damage (%d LP remaining)", m.combatState.CurrentRound, result.FinalDamage, result.TargetLP))
-			
-			// Doombringer soul thirst during enemy rest
-			if isDoombringerEquipped && result.FinalDamage > 0 {
-				oldLP := m.player.CurrentLP
-				healAmount := result.FinalDamage
-				
-				// If enemy died from this hit, cap healing at enemy's LP before the hit
-				if result.TargetLP <= 0 {
-					enemyLPBeforeHit := result.TargetLP + result.FinalDamage
-					if enemyLPBeforeHit < healAmount {
-						healAmount = enemyLPBeforeHit
-					}
-				}
-				
-				if m.player.CurrentLP + healAmount > m.player.MaximumLP {
-					healAmount = m.player.MaximumLP - m.player.CurrentLP
-				}
-				
-				if healAmount > 0 {
-					m.player.ModifyLP(healAmount)
-					actualHeal := m.player.CurrentLP - oldLP
-					m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Doombringer feeds on pain... +%d LP healed!", m.combatState.CurrentRound, actualHeal))
-				}
-			}
-		} else {
-			m.combatState.AddLogEntry(fmt.Sprintf("[R%d] You rolled %d (need %d+) - MISS!", m.combatState.CurrentRound, result.Roll, result.Requirement))
-		}
-		
-		combat.ProcessEnemyRest(m.combatState)
-		m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Enemy rested! Enemy endurance restored.", m.combatState.CurrentRound))
-		m.needsEnemyRest = false
-		
-		return m, func() tea.Msg {
-			return EnemyAttackCompleteMsg{}
-		}
-	}
-
-	// Normal enemy turn
-	result := combat.ExecuteEnemyAttack(m.combatState, m.player, m.roller)
-	
-	if result.Hit {
-		m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Enemy rolled %d (need %d+) - HIT!", m.combatState.CurrentRound, result.Roll, result.Requirement))
-		m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Enemy deals %d damage (%d LP remaining)", m.combatState.CurrentRound, result.FinalDamage, result.TargetLP))
-	} else {
-		m.combatState.AddLogEntry(fmt.Sprintf("[R%d] Enemy rolled %d (need %d+) - MISS!", m.combatState.CurrentRound, result.Roll, result.Requirement))
+	// The acting enemy attacks, or rests while the player strikes it
+	result := combat.EnemyTurn(m.combatState, m.player, m.roller)
+	m.combatState.LogEnemyTurn(m.player, result)
+	if result.Rested && result.FreeAttack.Drained {
+		m.defeatState = true
+		return m, nil
 	}
 
 	return m, func() tea.Msg {
@@ -490,9 +294,8 @@ func (m CombatViewModel) processEnemyTurn() (CombatViewModel, tea.Cmd) {
 func (m CombatViewModel) checkCombatState() (CombatViewModel, tea.Cmd) {
 	// Check victory
 	if combat.CheckVictory(m.combatState) {
-		m.combatState.AddLogEntry(fmt.Sprintf("[Victory] %s defeated!", m.combatState.Enemy.Name))
-		combat.ResolveCombatVictory(m.player)
-		m.combatState.AddLogEntry(fmt.Sprintf("[Victory] Skill increased to %d. Enemies defeated: %d", m.player.Skill, m.player.EnemiesDefeated))
+		combat.ResolveEncounterVictory(m.player, m.combatState)
+		m.combatState.LogVictory(m.player)
 		m.victoryState = true
 		return m, nil
 	}
@@ -502,11 +305,11 @@ func (m CombatViewModel) checkCombatState() (CombatViewModel, tea.Cmd) {
 		// Check if death save is available
 		if !m.combatState.DeathSaveUsed {
 			m.combatState.AddLogEntry(fmt.Sprintf("[Critical] Your LP dropped to %d!", m.player.CurrentLP))
-			m.combatState.AddLogEntry("[Death Save] Press Enter to roll death save (2d6×10 vs Luck)...")
+			m.combatState.AddLogEntry(fmt.Sprintf("[Death Save] Press Enter to roll death save (2d6×%d vs Luck)...", m.combatState.Ruleset().DeathSaveMultiplier))
 			m.deathSaveActive = true
 			return m, nil
 		} else {
-			m.combatState.AddLogEntry("[Defeat] You have been defeated!")
+			m.combatState.LogDefeat()
 			m.defeatState = true
 			return m, nil
 		}
@@ -536,7 +339,11 @@ func (m CombatViewModel) View() string {
 	s.WriteString("\n\n")
 
 	// Combatant stats - two columns
-	s.WriteString(t.Heading.Render("  Fire*Wolf") + strings.Repeat(" ", 30) + t.Heading.Render("Enemy: "+m.combatState.Enemy.Name) + "\n")
+	enemyLabel := "Enemy: "
+	if len(m.combatState.Opponents) > 1 {
+		enemyLabel = "Target: "
+	}
+	s.WriteString(t.Heading.Render("  Fire*Wolf") + strings.Repeat(" ", 30) + t.Heading.Render(enemyLabel+m.combatState.Enemy.Name) + "\n")
 	
 	// Health bars
 	playerHP := theme.RenderHealthBar(m.player.CurrentLP, m.player.MaximumLP, 20)
@@ -567,6 +374,23 @@ func (m CombatViewModel) View() string {
 	s.WriteString(strings.Repeat(" ", 35))
 	s.WriteString(t.Label.Render(fmt.Sprintf("Armor: -%d", m.combatState.Enemy.ArmorProtection)) + "\n")
 
+	// Every enemy of the encounter, with the player's target marked
+	if len(m.combatState.Opponents) > 1 {
+		s.WriteString("\n")
+		for i, opponent := range m.combatState.Opponents {
+			marker := "  "
+			if i == m.combatState.Target {
+				marker = "▶ "
+			}
+			line := fmt.Sprintf("  %s%-16s ", marker, opponent.Enemy.Name)
+			if opponent.IsAlive() {
+				s.WriteString(t.Label.Render(line) + theme.RenderHealthBar(opponent.Enemy.CurrentLP, opponent.Enemy.MaximumLP, 20) + "\n")
+			} else {
+				s.WriteString(t.MutedText.Render(line+"(slain)") + "\n")
+			}
+		}
+	}
+
 	// Endurance status
 	if m.combatState.EnduranceLimit > 0 {
 		remaining := m.combatState.EnduranceLimit - m.combatState.RoundsSinceLastRest
@@ -584,12 +408,13 @@ func (m CombatViewModel) View() string {
 	s.WriteString(theme.RenderSeparator(60) + "\n")
 
 	// Show last 4 log entries to keep display compact and prevent scrolling
-	logStart := len(m.combatState.CombatLog) - 4
+	log := m.combatState.Log()
+	logStart := len(log) - 4
 	if logStart < 0 {
 		logStart = 0
 	}
-	for i := logStart; i < len(m.combatState.CombatLog); i++ {
-		s.WriteString("  " + t.Body.Render(m.combatState.CombatLog[i]) + "\n")
+	for i := logStart; i < len(log); i++ {
+		s.WriteString("  " + t.Body.Render(log[i]) + "\n")
 	}
 
 	s.WriteString("\n" + theme.RenderSeparator(60) + "\n")
@@ -598,18 +423,22 @@ func (m CombatViewModel) View() string {
 	if m.victoryState {
 		s.WriteString("\n" + theme.RenderSuccess("VICTORY!") + "\n\n")
 		s.WriteString(t.Body.Render("  Press Enter to return to game menu") + "\n")
+		s.WriteString(t.MutedText.Render("  Press s to save this enemy to the bestiary") + "\n")
+		s.WriteString(t.MutedText.Render("  Press m, c or j to export the combat log (Markdown, CSV, JSON)") + "\n")
 		return s.String()
 	}
 
 	if m.defeatState {
 		s.WriteString("\n" + theme.RenderError("DEFEAT", "You have been defeated", "") + "\n\n")
 		s.WriteString(t.Body.Render("  Press Enter to return to game menu") + "\n")
+		s.WriteString(t.MutedText.Render("  Press s to save this enemy to the bestiary") + "\n")
+		s.WriteString(t.MutedText.Render("  Press m, c or j to export the combat log (Markdown, CSV, JSON)") + "\n")
 		return s.String()
 	}
 
 	// Death save prompt
 	if m.deathSaveActive {
-		s.WriteString("\n" + theme.RenderWarning("DEATH SAVE", "Roll 2d6×10 vs Luck to survive") + "\n\n")
+		s.WriteString("\n" + theme.RenderWarning("DEATH SAVE", fmt.Sprintf("Roll 2d6×%d vs Luck to survive", m.combatState.Ruleset().DeathSaveMultiplier)) + "\n\n")
 		s.WriteString(t.Emphasis.Render("  Press Enter to attempt death save") + "\n")
 		return s.String()
 	}
@@ -623,9 +452,13 @@ func (m CombatViewModel) View() string {
 			s.WriteString("  " + theme.RenderMenuItem(action, i == m.selectedAction) + "\n")
 		}
 		
-		s.WriteString("\n" + theme.RenderKeyHelp("↑/↓ Select", "Enter Confirm", "Esc Menu") + "\n")
+		if len(m.combatState.LivingOpponents()) > 1 {
+			s.WriteString("\n" + theme.RenderKeyHelp("↑/↓ Select", "←/→ Target", "Enter Confirm", "Esc Menu") + "\n")
+		} else {
+			s.WriteString("\n" + theme.RenderKeyHelp("↑/↓ Select", "Enter Confirm", "Esc Menu") + "\n")
+		}
 	} else {
-		s.WriteString("\n" + t.Heading.Render("  Enemy Turn...") + "\n")
+		s.WriteString("\n" + t.Heading.Render(fmt.Sprintf("  %s's Turn...", m.combatState.ActingEnemy().Name)) + "\n")
 		s.WriteString(theme.RenderSeparator(60) + "\n\n")
 		s.WriteString(t.MutedText.Render("  Processing enemy action...") + "\n")
 	}
@@ -646,10 +479,19 @@ type CombatEndMsg struct {
 	Victory bool
 }
 
+// SaveEnemyMsg asks for the current enemy to be saved to the bestiary.
+type SaveEnemyMsg struct{}
+
+// ExportCombatMsg asks for the finished fight to be exported in the given
+// format (combat.FormatMarkdown, FormatCSV or FormatJSON).
+type ExportCombatMsg struct {
+	Format string
+}
+
 // CastSpellMsg signals to switch to spell casting screen during combat.
 type CastSpellMsg struct{}
 
-// EnemyTurnMsg signals that the enemy should take a turn.
+// EnemyTurnMsg signals that the acting enemy should take a turn.
 type EnemyTurnMsg struct{}
 
 // PlayerAttackCompleteMsg signals that the player's attack is complete.
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/benoit/saga-demonspawn/internal/dice"
+	"github.com/benoit/saga-demonspawn/pkg/ui/theme"
+)
+
+// DiceEntryModel is the modal asking for the results of physical dice.
+type DiceEntryModel struct {
+	request dice.DiceRequest
+	dice    []int
+	err     string
+	action  tea.Msg // Message to play again once the dice are in
+}
+
+// NewDiceEntryModel asks for the dice of a roll, then replays action.
+func NewDiceEntryModel(request dice.DiceRequest, action tea.Msg) DiceEntryModel {
+	return DiceEntryModel{request: request, action: action}
+}
+
+// AddDie records one die typed as a digit.
+func (m *DiceEntryModel) AddDie(key string) {
+	if len(key) != 1 || key[0] < '0' || key[0] > '9' {
+		return
+	}
+	die := int(key[0] - '0')
+	if die < 1 || die > 6 {
+		m.err = fmt.Sprintf("A die shows 1 to 6, not %d", die)
+		return
+	}
+	if len(m.dice) >= m.request.Dice {
+		m.err = fmt.Sprintf("Only %d dice for this roll; Backspace to correct", m.request.Dice)
+		return
+	}
+	m.dice = append(m.dice, die)
+	m.err = ""
+}
+
+// RemoveDie deletes the last die typed.
+func (m *DiceEntryModel) RemoveDie() {
+	if len(m.dice) > 0 {
+		m.dice = m.dice[:len(m.dice)-1]
+	}
+	m.err = ""
+}
+
+// Complete returns true once every die of the roll has been typed.
+func (m DiceEntryModel) Complete() bool {
+	return len(m.dice) == m.request.Dice
+}
+
+// View renders the dice entry modal.
+func (m DiceEntryModel) View() string {
+	var b strings.Builder
+	t := theme.Current()
+
+	what := m.request.Kind
+	if m.request.Kind == dice.KindCharacteristic {
+		what = "2d6 × 8"
+	}
+	b.WriteString(theme.RenderTitle("ROLL YOUR DICE"))
+	b.WriteString("\n\n")
+	b.WriteString("  " + theme.RenderLabel("Roll", what) + "\n")
+	if m.request.Label != "" {
+		b.WriteString("  " + theme.RenderLabel("For", m.request.Label) + "\n")
+	}
+	b.WriteString("\n")
+
+	for i := 0; i < m.request.Dice; i++ {
+		value := "_"
+		if i < len(m.dice) {
+			value = fmt.Sprintf("%d", m.dice[i])
+		}
+		b.WriteString("  " + theme.RenderLabel(fmt.Sprintf("Die %d", i+1), value) + "\n")
+	}
+
+	if m.err != "" {
+		b.WriteString("\n  " + t.WarningMsg.Render(m.err) + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(theme.RenderKeyHelp("1-6 Die result", "Backspace Correct", "Enter Confirm", "Esc Cancel action"))
+	return b.String()
+}
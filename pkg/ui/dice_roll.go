@@ -4,63 +4,198 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/benoit/saga-demonspawn/internal/character"
 	"github.com/benoit/saga-demonspawn/internal/dice"
 	"github.com/benoit/saga-demonspawn/pkg/ui/theme"
 )
 
-// DiceRollModel handles the dice rolling screen state.
+const (
+	// diceHistoryVisible is how many past rolls are listed at once.
+	diceHistoryVisible = 8
+
+	// diceInputLimit caps the length of a typed expression.
+	diceInputLimit = 40
+
+	// defaultDiceExpression is rolled when Enter is pressed on an empty
+	// input before anything was rolled.
+	defaultDiceExpression = "2d6"
+)
+
+// DiceRollModel handles the dice rolling screen state: an expression
+// typed by the player and the rolls made this session.
 type DiceRollModel struct {
-	dice   dice.Roller
-	result int
-	rolled bool
-	msg    string
+	dice        dice.Roller
+	character   *character.Character
+	inputBuffer string       // Expression being typed
+	history     dice.History // Rolls made this session, oldest first
+	scroll      int          // Rolls hidden below the visible part of the history
+	message     string       // Error from the last roll, if any
 }
 
 // NewDiceRollModel creates a new dice roll model.
 func NewDiceRollModel(roller dice.Roller) DiceRollModel {
 	return DiceRollModel{
-		dice:   roller,
-		result: 0,
-		rolled: false,
-		msg:    "Press '1' for 1d6, '2' for 2d6, or 'ESC' to return.",
+		dice: roller,
 	}
 }
 
-// Reset resets the model state.
-func (m *DiceRollModel) Reset() {
-	m.result = 0
-	m.rolled = false
-	m.msg = "Press '1' for 1d6, '2' for 2d6, or 'ESC' to return."
+// Reset prepares the screen for the given character, keeping the history.
+func (m *DiceRollModel) Reset(char *character.Character) {
+	m.character = char
+	m.inputBuffer = ""
+	m.scroll = 0
+	m.message = ""
 }
 
-// Roll1D6 rolls a single 6-sided die.
-func (m *DiceRollModel) Roll1D6() {
-	m.result = m.dice.Roll1D6()
-	m.rolled = true
-	m.msg = fmt.Sprintf("You rolled 1d6: %d", m.result)
+// AppendInput adds typed text to the expression.
+func (m *DiceRollModel) AppendInput(text string) {
+	if len([]rune(m.inputBuffer+text)) <= diceInputLimit {
+		m.inputBuffer += text
+	}
 }
 
-// Roll2D6 rolls two 6-sided dice.
-func (m *DiceRollModel) Roll2D6() {
-	m.result = m.dice.Roll2D6()
-	m.rolled = true
-	m.msg = fmt.Sprintf("You rolled 2d6: %d", m.result)
+// Backspace removes the last typed character.
+func (m *DiceRollModel) Backspace() {
+	if runes := []rune(m.inputBuffer); len(runes) > 0 {
+		m.inputBuffer = string(runes[:len(runes)-1])
+	}
+}
+
+// Roll rolls the typed expression; an empty input rolls the last one again.
+func (m *DiceRollModel) Roll() {
+	source := strings.TrimSpace(m.inputBuffer)
+	if source == "" {
+		source = defaultDiceExpression
+		if last, ok := m.history.Last(); ok {
+			source = last.Expression
+		}
+	}
+
+	expr, err := dice.ParseExpression(source)
+	if err != nil {
+		m.message = err.Error()
+		return
+	}
+	dice.Label(m.dice, "dice roller")
+	result, err := expr.Roll(m.dice, characterValues(m.character))
+	if err != nil {
+		m.message = err.Error()
+		return
+	}
+
+	m.history.Add(result)
+	m.inputBuffer = ""
+	m.scroll = 0
+	m.message = ""
+}
+
+// ScrollUp shows older rolls.
+func (m *DiceRollModel) ScrollUp() {
+	if m.scroll < m.history.Len()-diceHistoryVisible {
+		m.scroll++
+	}
+}
+
+// ScrollDown shows newer rolls.
+func (m *DiceRollModel) ScrollDown() {
+	if m.scroll > 0 {
+		m.scroll--
+	}
+}
+
+// characterValues returns the values a dice expression can refer to.
+func characterValues(char *character.Character) map[string]int {
+	if char == nil {
+		return nil
+	}
+	return map[string]int{
+		"STR": char.Strength,
+		"SPD": char.Speed,
+		"STA": char.Stamina,
+		"CRG": char.Courage,
+		"LCK": char.Luck,
+		"CHM": char.Charm,
+		"ATT": char.Attraction,
+		"SKL": char.Skill,
+		"LP":  char.CurrentLP,
+		"POW": char.CurrentPOW,
+	}
 }
 
 // View renders the dice roll screen.
 func (m DiceRollModel) View() string {
-	var s strings.Builder
+	var b strings.Builder
+	t := theme.Current()
+
+	b.WriteString(theme.RenderTitle("Dice Roller"))
+	b.WriteString("\n\n")
 
-	s.WriteString(theme.RenderTitle("Dice Roller"))
-	s.WriteString("\n\n")
+	b.WriteString(theme.RenderLabel("Roll", t.Emphasis.Render("["+m.inputBuffer+"_]")))
+	b.WriteString("\n")
+	b.WriteString(t.MutedText.Render("e.g. 2d6, 1d6×10, 4d6kh3+2, 2d6*10<=LCK"))
+	b.WriteString("\n")
+	if m.message != "" {
+		b.WriteString("\n" + t.WarningMsg.Render(m.message) + "\n")
+	}
+	b.WriteString("\n")
 
-	if m.rolled {
-		s.WriteString(fmt.Sprintf("Result: %d\n\n", m.result))
+	results := m.history.Results()
+	if len(results) == 0 {
+		b.WriteString(t.MutedText.Render("No rolls yet."))
+		b.WriteString("\n\n")
+	} else {
+		b.WriteString(t.Heading.Render("HISTORY"))
+		b.WriteString("\n")
+		end := len(results) - m.scroll
+		start := max(0, end-diceHistoryVisible)
+		if start > 0 {
+			b.WriteString(t.MutedText.Render(fmt.Sprintf("  ↑ %d older", start)) + "\n")
+		}
+		for i := end - 1; i >= start; i-- {
+			line := fmt.Sprintf("%3d. %s", i+1, results[i])
+			if i == len(results)-1 {
+				b.WriteString(t.Emphasis.Render(line) + "\n")
+			} else {
+				b.WriteString(t.Body.Render(line) + "\n")
+			}
+		}
+		if m.scroll > 0 {
+			b.WriteString(t.MutedText.Render(fmt.Sprintf("  ↓ %d newer", m.scroll)) + "\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(m.statsView())
 	}
 
-	s.WriteString(m.msg)
-	s.WriteString("\n\n")
-	s.WriteString(theme.Current().MutedText.Render("Press '1' or '2' to roll again, or 'ESC' to exit."))
+	b.WriteString(theme.RenderKeyHelp("Enter Roll (again)", "↑/↓ Scroll history", "Backspace Delete", "Esc Back"))
+	return b.String()
+}
 
-	return s.String()
+// statsView renders the distribution of the rolls so far.
+func (m DiceRollModel) statsView() string {
+	var b strings.Builder
+	stats := m.history.Stats()
+
+	b.WriteString(theme.Current().Heading.Render("STATISTICS"))
+	b.WriteString("\n")
+	b.WriteString(theme.RenderLabel("Rolls", fmt.Sprintf("%d", stats.Rolls)))
+	b.WriteString("  ")
+	b.WriteString(theme.RenderLabel("Mean", fmt.Sprintf("%.1f ± %.1f", stats.Mean, stats.StdDev)))
+	b.WriteString("  ")
+	b.WriteString(theme.RenderLabel("Range", fmt.Sprintf("%d–%d", stats.Min, stats.Max)))
+	b.WriteString("\n")
+	if stats.Tests > 0 {
+		b.WriteString(theme.RenderLabel("Tests passed", fmt.Sprintf("%d of %d (%.0f%%)",
+			stats.Successes, stats.Tests, 100*float64(stats.Successes)/float64(stats.Tests))))
+		b.WriteString("\n")
+	}
+	if stats.Dice > 0 {
+		faces := make([]string, 6)
+		for face := 1; face <= 6; face++ {
+			faces[face-1] = fmt.Sprintf("%d:%d", face, stats.Faces[face])
+		}
+		b.WriteString(theme.RenderLabel(fmt.Sprintf("d6 faces (%d dice)", stats.Dice), strings.Join(faces, "  ")))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.String()
 }
@@ -0,0 +1,204 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/pkg/ui/theme"
+)
+
+// encounterListSize is the number of encounters shown at once.
+const encounterListSize = 10
+
+// encounterLogSize is the number of combat log lines shown at once.
+const encounterLogSize = 12
+
+// EncounterHistoryModel handles the "Encounter History" screen: the
+// character's finished fights, newest first, with a drill-down into each
+// one's details and combat log.
+type EncounterHistoryModel struct {
+	character *character.Character
+	cursor    int  // Selected encounter, 0 being the newest
+	detail    bool // Whether the selected encounter is open
+	scroll    int  // First combat log line shown in the detail view
+}
+
+// NewEncounterHistoryModel creates a new encounter history model.
+func NewEncounterHistoryModel() EncounterHistoryModel {
+	return EncounterHistoryModel{}
+}
+
+// Reset lists the encounters of the given character.
+func (m *EncounterHistoryModel) Reset(char *character.Character) {
+	*m = NewEncounterHistoryModel()
+	m.character = char
+}
+
+// encounters returns the number of encounters in the journal.
+func (m *EncounterHistoryModel) encounters() int {
+	if m.character == nil {
+		return 0
+	}
+	return len(m.character.Encounters)
+}
+
+// Selected returns the selected encounter, or false if there is none.
+func (m *EncounterHistoryModel) Selected() (character.Encounter, bool) {
+	n := m.encounters()
+	if n == 0 {
+		return character.Encounter{}, false
+	}
+	return m.character.Encounters[n-1-m.cursor], true
+}
+
+// MoveUp selects the newer encounter, or scrolls the combat log up.
+func (m *EncounterHistoryModel) MoveUp() {
+	switch {
+	case m.detail && m.scroll > 0:
+		m.scroll--
+	case !m.detail && m.cursor > 0:
+		m.cursor--
+	}
+}
+
+// MoveDown selects the older encounter, or scrolls the combat log down.
+func (m *EncounterHistoryModel) MoveDown() {
+	if !m.detail {
+		if m.cursor < m.encounters()-1 {
+			m.cursor++
+		}
+		return
+	}
+	encounter, _ := m.Selected()
+	if m.scroll < len(encounter.Log)-encounterLogSize {
+		m.scroll++
+	}
+}
+
+// Open shows the details of the selected encounter.
+func (m *EncounterHistoryModel) Open() {
+	if _, ok := m.Selected(); ok {
+		m.detail = true
+		m.scroll = 0
+	}
+}
+
+// Back closes the open encounter. It returns false if none was open, and
+// the screen should be left.
+func (m *EncounterHistoryModel) Back() bool {
+	if !m.detail {
+		return false
+	}
+	m.detail = false
+	return true
+}
+
+// View renders the encounter history screen.
+func (m EncounterHistoryModel) View() string {
+	if m.detail {
+		return m.viewDetail()
+	}
+
+	var b strings.Builder
+	t := theme.Current()
+
+	b.WriteString("\n")
+	b.WriteString(theme.RenderTitle("ENCOUNTER HISTORY"))
+	b.WriteString("\n\n")
+
+	n := m.encounters()
+	if n == 0 {
+		b.WriteString("  " + t.MutedText.Render("No fights recorded yet.") + "\n")
+		b.WriteString("  " + t.MutedText.Render("Every fight is added here when it ends.") + "\n\n")
+		b.WriteString(theme.RenderKeyHelp("Esc Back"))
+		return b.String()
+	}
+
+	// Keep the selected encounter in view
+	start := 0
+	if m.cursor >= encounterListSize {
+		start = m.cursor - encounterListSize + 1
+	}
+	end := min(start+encounterListSize, n)
+	for i := start; i < end; i++ {
+		encounter := m.character.Encounters[n-1-i]
+		section := "-"
+		if encounter.Section > 0 {
+			section = fmt.Sprintf("%d", encounter.Section)
+		}
+		label := fmt.Sprintf("%s  §%-4s %-24s %-12s %2d rounds",
+			encounter.Time.Format("2006-01-02 15:04"), section, truncate(encounter.EnemyNames(), 24),
+			encounter.Outcome, encounter.Rounds)
+		b.WriteString("  " + theme.RenderMenuItem(label, i == m.cursor) + "\n")
+	}
+	if n > encounterListSize {
+		b.WriteString("  " + t.MutedText.Render(fmt.Sprintf("%d encounters", n)) + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(theme.RenderKeyHelp("↑/↓ Select", "Enter Details", "Esc Back"))
+	return b.String()
+}
+
+// viewDetail renders the selected encounter and its combat log.
+func (m EncounterHistoryModel) viewDetail() string {
+	var b strings.Builder
+	t := theme.Current()
+	encounter, _ := m.Selected()
+
+	b.WriteString("\n")
+	b.WriteString(theme.RenderTitle("ENCOUNTER - " + strings.ToUpper(encounter.EnemyNames())))
+	b.WriteString("\n\n")
+
+	b.WriteString("  " + theme.RenderLabel("Date", encounter.Time.Format("2006-01-02 15:04:05")) + "\n")
+	if encounter.Section > 0 {
+		b.WriteString("  " + theme.RenderLabel("Section", fmt.Sprintf("%d", encounter.Section)) + "\n")
+	}
+	b.WriteString(fmt.Sprintf("  %s  %s\n",
+		theme.RenderLabel("Outcome", encounter.Outcome),
+		theme.RenderLabel("Rounds", fmt.Sprintf("%d", encounter.Rounds))))
+	b.WriteString("  " + theme.RenderLabel("LP", fmt.Sprintf("%d → %d", encounter.LPBefore, encounter.LPAfter)) + "\n")
+	if len(encounter.Items) > 0 {
+		b.WriteString("  " + theme.RenderLabel("Items", strings.Join(encounter.Items, ", ")) + "\n")
+	}
+	if len(encounter.Spells) > 0 {
+		b.WriteString("  " + theme.RenderLabel("Spells", strings.Join(encounter.Spells, ", ")) + "\n")
+	}
+	b.WriteString("\n")
+
+	// Enemies
+	b.WriteString(t.Heading.Render("  Enemies") + "\n")
+	b.WriteString(theme.RenderSeparator(50) + "\n")
+	for _, enemy := range encounter.Enemies {
+		b.WriteString(fmt.Sprintf("  %-16s STR %d  SPD %d  STA %d  CRG %d  LCK %d  SKL %d\n",
+			truncate(enemy.Name, 16), enemy.Strength, enemy.Speed, enemy.Stamina, enemy.Courage, enemy.Luck, enemy.Skill))
+		b.WriteString(t.MutedText.Render(fmt.Sprintf("  %-16s LP %d → %d/%d  Weapon +%d  Armor -%d",
+			"", enemy.LPBefore, enemy.LPAfter, enemy.MaximumLP, enemy.WeaponBonus, enemy.ArmorProtection)) + "\n")
+	}
+	b.WriteString("\n")
+
+	// Combat log
+	b.WriteString(t.Heading.Render("  Combat Log") + "\n")
+	b.WriteString(theme.RenderSeparator(50) + "\n")
+	end := min(m.scroll+encounterLogSize, len(encounter.Log))
+	for _, line := range encounter.Log[m.scroll:end] {
+		b.WriteString("  " + line + "\n")
+	}
+	if len(encounter.Log) > encounterLogSize {
+		b.WriteString("  " + t.MutedText.Render(fmt.Sprintf("Lines %d-%d of %d", m.scroll+1, end, len(encounter.Log))) + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(theme.RenderKeyHelp("↑/↓ Scroll", "Esc Back"))
+	return b.String()
+}
+
+// truncate shortens text to at most width characters, ending with "…".
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	return string(runes[:width-1]) + "…"
+}
@@ -14,9 +14,15 @@ func NewGameSessionModel() GameSessionModel {
 		choices: []string{
 			"View Character",
 			"Edit Character Stats",
+			"Go to Section...",
 			"Combat",
 			"Manage Inventory",
 			"Roll Dice",
+			"Test Characteristic",
+			"Statistics",
+			"Encounter History",
+			"Save As...",
+			"Restore Backup...",
 			"Save & Exit",
 		},
 		showMagic: false,
@@ -31,19 +37,31 @@ func (m *GameSessionModel) UpdateMagicVisibility(magicUnlocked bool) {
 		m.choices = []string{
 			"View Character",
 			"Edit Character Stats",
+			"Go to Section...",
 			"Combat",
 			"Cast Spell",
 			"Manage Inventory",
 			"Roll Dice",
+			"Test Characteristic",
+			"Statistics",
+			"Encounter History",
+			"Save As...",
+			"Restore Backup...",
 			"Save & Exit",
 		}
 	} else {
 		m.choices = []string{
 			"View Character",
 			"Edit Character Stats",
+			"Go to Section...",
 			"Combat",
 			"Manage Inventory",
 			"Roll Dice",
+			"Test Characteristic",
+			"Statistics",
+			"Encounter History",
+			"Save As...",
+			"Restore Backup...",
 			"Save & Exit",
 		}
 	}
@@ -312,10 +312,11 @@ func (m *InventoryManagementModel) HandleRecharge() bool {
 	return false
 }
 
-// HandleAcquire processes the 'a' key for acquiring special items
-func (m *InventoryManagementModel) HandleAcquire() {
+// HandleAcquire processes the 'a' key for acquiring special items.
+// Returns true if an item was acquired.
+func (m *InventoryManagementModel) HandleAcquire() bool {
 	if m.cursor >= len(m.items) {
-		return
+		return false
 	}
 
 	item := m.items[m.cursor]
@@ -327,12 +328,14 @@ func (m *InventoryManagementModel) HandleAcquire() {
 			m.character.AcquireHealingStone()
 			m.message = "Acquired Healing Stone! Restore LP during combat."
 			m.rebuildItemList()
+			return true
 		}
 	} else if item.SpecialItem == "doombringer" {
 		if !m.character.DoombringerPossessed {
 			m.character.AcquireDoombringer()
 			m.message = "Acquired Doombringer! Beware its cursed power..."
 			m.rebuildItemList()
+			return true
 		} else {
 			m.message = "You already possess Doombringer"
 		}
@@ -341,12 +344,14 @@ func (m *InventoryManagementModel) HandleAcquire() {
 			m.character.AcquireOrb()
 			m.message = "Acquired The Orb! A powerful weapon against Demonspawn."
 			m.rebuildItemList()
+			return true
 		} else {
 			m.message = "You already possess The Orb"
 		}
 	} else {
 		m.message = "This item cannot be acquired (use this when finding items in the adventure)"
 	}
+	return false
 }
 
 // ConfirmRecharge recharges the Healing Stone
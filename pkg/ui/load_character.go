@@ -1,46 +1,55 @@
 package ui
 
 import (
+	"errors"
+	"fmt"
 	"os"
-	"path/filepath"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/internal/safefile"
 )
 
-// LoadCharacterModel represents the load character screen state.
+// slotNameLimit caps the length of a slot name being typed.
+const slotNameLimit = 40
+
+// LoadCharacterModel represents the load character screen state: the save
+// slots of the save directory, one of which can be renamed in place.
 type LoadCharacterModel struct {
-	files  []string
-	cursor int
-	err    error
+	directory   string
+	slots       []character.SlotInfo
+	cursor      int
+	err         error
+	renaming    bool   // Whether a new name is being typed for the selected slot
+	inputBuffer string // New slot name being typed
+	message     string // Result of the last rename
+	isError     bool   // Whether message describes an error
 }
 
 // NewLoadCharacterModel creates a new load character model.
 func NewLoadCharacterModel() LoadCharacterModel {
 	return LoadCharacterModel{
-		files:  []string{},
+		slots:  []character.SlotInfo{},
 		cursor: 0,
 		err:    nil,
 	}
 }
 
-// Refresh scans for character save files in the specified directory.
+// Refresh scans for save slots in the current directory.
 func (m *LoadCharacterModel) Refresh() {
 	m.RefreshFromDirectory(".")
 }
 
-// RefreshFromDirectory scans the specified directory for character save files.
+// RefreshFromDirectory lists the save slots of the specified directory.
 func (m *LoadCharacterModel) RefreshFromDirectory(directory string) {
-	m.files = []string{}
-	m.cursor = 0
-	m.err = nil
+	*m = NewLoadCharacterModel()
+	m.directory = directory
 
-	// Look for JSON files in specified directory
-	pattern := filepath.Join(directory, "character_*.json")
-	files, err := filepath.Glob(pattern)
+	slots, err := character.ListSlots(directory)
 	if err != nil {
 		m.err = err
 		return
 	}
-
-	m.files = files
+	m.slots = slots
 }
 
 // MoveUp moves the cursor up.
@@ -52,7 +61,7 @@ func (m *LoadCharacterModel) MoveUp() {
 
 // MoveDown moves the cursor down.
 func (m *LoadCharacterModel) MoveDown() {
-	if m.cursor < len(m.files)-1 {
+	if m.cursor < len(m.slots)-1 {
 		m.cursor++
 	}
 }
@@ -62,22 +71,22 @@ func (m *LoadCharacterModel) GetCursor() int {
 	return m.cursor
 }
 
-// GetFiles returns all available save files.
-func (m *LoadCharacterModel) GetFiles() []string {
-	return m.files
+// GetSlots returns all available save slots.
+func (m *LoadCharacterModel) GetSlots() []character.SlotInfo {
+	return m.slots
 }
 
-// GetSelectedFile returns the currently selected file.
+// GetSelectedFile returns the save file of the selected slot.
 func (m *LoadCharacterModel) GetSelectedFile() string {
-	if m.cursor < len(m.files) {
-		return m.files[m.cursor]
+	if m.cursor < len(m.slots) {
+		return m.slots[m.cursor].Path
 	}
 	return ""
 }
 
-// HasFiles returns true if there are save files available.
+// HasFiles returns true if there are save slots available.
 func (m *LoadCharacterModel) HasFiles() bool {
-	return len(m.files) > 0
+	return len(m.slots) > 0
 }
 
 // GetError returns any error encountered during refresh.
@@ -85,13 +94,99 @@ func (m *LoadCharacterModel) GetError() error {
 	return m.err
 }
 
-// GetFileInfo returns formatted information about a save file.
-func GetFileInfo(path string) string {
-	info, err := os.Stat(path)
+// StartRename begins typing a new name for the selected slot.
+func (m *LoadCharacterModel) StartRename() {
+	if !m.HasFiles() {
+		return
+	}
+	slot := m.slots[m.cursor]
+	if slot.Legacy {
+		m.message, m.isError = "Old save files have no slot yet: load and save the character first", true
+		return
+	}
+	m.renaming = true
+	m.inputBuffer = slot.Name
+	m.message = ""
+}
+
+// IsRenaming returns true while a new slot name is being typed.
+func (m *LoadCharacterModel) IsRenaming() bool {
+	return m.renaming
+}
+
+// AppendInput adds typed text to the new slot name.
+func (m *LoadCharacterModel) AppendInput(text string) {
+	if len([]rune(m.inputBuffer+text)) <= slotNameLimit {
+		m.inputBuffer += text
+	}
+}
+
+// Backspace removes the last typed character.
+func (m *LoadCharacterModel) Backspace() {
+	if runes := []rune(m.inputBuffer); len(runes) > 0 {
+		m.inputBuffer = string(runes[:len(runes)-1])
+	}
+}
+
+// GetInputBuffer returns the slot name being typed.
+func (m *LoadCharacterModel) GetInputBuffer() string {
+	return m.inputBuffer
+}
+
+// CancelRename stops renaming without changes.
+func (m *LoadCharacterModel) CancelRename() {
+	m.renaming = false
+	m.inputBuffer = ""
+}
+
+// ConfirmRename renames the selected slot to the typed name and lists the
+// slots again, keeping the selection.
+func (m *LoadCharacterModel) ConfirmRename() {
+	slot := m.slots[m.cursor]
+	saveMu.Lock()
+	err := character.RenameSlot(m.directory, slot.ID, m.inputBuffer)
+	if err == nil {
+		saveEpoch++
+	}
+	saveMu.Unlock()
 	if err != nil {
-		return ""
+		m.message, m.isError = err.Error(), true
+		return
+	}
+
+	cursor := m.cursor
+	m.RefreshFromDirectory(m.directory)
+	m.cursor = min(cursor, max(0, len(m.slots)-1))
+	m.message = fmt.Sprintf("Slot renamed to %q", m.slots[m.cursor].Name)
+}
+
+// SetError shows why the selected slot could not be loaded, e.g. because
+// another instance of saga has it open.
+func (m *LoadCharacterModel) SetError(err error) {
+	m.message, m.isError = err.Error(), true
+	if errors.Is(err, safefile.ErrLocked) {
+		m.message = "Save in use: " + err.Error()
 	}
+}
 
-	// Format: filename (size, modified date)
-	return info.ModTime().Format("2006-01-02 15:04")
+// GetMessage returns the result of the last rename and whether it failed.
+func (m *LoadCharacterModel) GetMessage() (string, bool) {
+	return m.message, m.isError
+}
+
+// GetSlotInfo returns formatted metadata for a save slot: section, LP
+// and when it was last played.
+func GetSlotInfo(slot character.SlotInfo) string {
+	section := "not started"
+	if slot.Section > 0 {
+		section = fmt.Sprintf("section %d", slot.Section)
+	}
+	lastPlayed := slot.LastPlayed
+	if lastPlayed.IsZero() {
+		if info, err := os.Stat(slot.Path); err == nil {
+			lastPlayed = info.ModTime()
+		}
+	}
+	return fmt.Sprintf("%s, LP %d/%d, played %s",
+		section, slot.CurrentLP, slot.MaximumLP, lastPlayed.Format("2006-01-02 15:04"))
 }
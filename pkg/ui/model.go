@@ -2,11 +2,18 @@
 package ui
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/benoit/saga-demonspawn/internal/character"
 	"github.com/benoit/saga-demonspawn/internal/combat"
 	"github.com/benoit/saga-demonspawn/internal/config"
 	"github.com/benoit/saga-demonspawn/internal/dice"
 	"github.com/benoit/saga-demonspawn/internal/help"
+	"github.com/benoit/saga-demonspawn/internal/rules"
+	"github.com/benoit/saga-demonspawn/internal/safefile"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 // Screen represents the different screens in the application.
@@ -37,6 +44,20 @@ const (
 	ScreenSettings
 	// ScreenDiceRoll is the dice rolling interface
 	ScreenDiceRoll
+	// ScreenSection is the gamebook section navigation screen
+	ScreenSection
+	// ScreenResurrection rerolls characteristics after RESURRECTION
+	ScreenResurrection
+	// ScreenTestCharacteristic rolls tests against a characteristic
+	ScreenTestCharacteristic
+	// ScreenSaveAs saves the character to a new named slot
+	ScreenSaveAs
+	// ScreenRestoreBackup rolls the character back to a backup of its slot
+	ScreenRestoreBackup
+	// ScreenStatistics shows the character's lifetime statistics
+	ScreenStatistics
+	// ScreenEncounterHistory browses the character's finished fights
+	ScreenEncounterHistory
 )
 
 // Model is the root Bubble Tea model containing all application state.
@@ -50,9 +71,18 @@ type Model struct {
 	// Dice roller for all random events
 	Dice dice.Roller
 
+	// ManualDice is Dice when physical dice are typed in (see config.ManualDice)
+	ManualDice *dice.ManualRoller
+
 	// Configuration
 	Config *config.Config
 
+	// Rules is the ruleset profile chosen in Settings
+	Rules rules.Ruleset
+
+	// SlotLock marks the character's save slot as open in this instance
+	SlotLock *safefile.Lock
+
 	// Screen-specific models
 	MainMenu        MainMenuModel
 	CharCreation    CharacterCreationModel
@@ -63,10 +93,21 @@ type Model struct {
 	CombatSetup     CombatSetupModel
 	CombatView      CombatViewModel
 	CombatState     *combat.CombatState
+	SectionCombat   *combat.CombatState // Fight as it stood when the current section began
+	FatalCombat     *combat.CombatState // Fight the character died in, resumed by RESURRECTION
+	SavedCombat     *combat.CombatState // Fight saved with the loaded character, offered for resuming
+	Bestiary        *combat.Bestiary    // Enemy library, loaded on first use
 	Inventory       InventoryManagementModel
 	SpellCasting    SpellCastingModel
 	Settings        SettingsModel
 	DiceRoll        DiceRollModel
+	SectionNav      SectionNavModel
+	Resurrection    ResurrectionModel
+	TestChar        TestCharacteristicModel
+	SaveAs          SaveAsModel
+	RestoreBackup   RestoreBackupModel
+	Statistics      StatisticsModel
+	Encounters      EncounterHistoryModel
 
 	// Help modal state
 	ShowingHelp    bool
@@ -74,16 +115,33 @@ type Model struct {
 	HelpScroll     int
 	HelpMaxScroll  int
 
+	// Physical dice modal state
+	EnteringDice bool
+	DiceEntry    DiceEntryModel
+	DeferredMsgs []tea.Msg // Messages that arrived during dice entry, played once it closes
+
+	// Background autosave state
+	Autosaving         bool      // A background save is being written
+	AutosaveDue        bool      // A key event asked for a save not yet started
+	AutosaveGeneration int       // Tick chain in use; bumped when the interval changes
+	LastAutosave       time.Time // When the last background save finished
+	AutosaveErr        error     // Error of the last background save
+
 	// Application state
-	Width  int // Terminal width
-	Height int // Terminal height
-	Err    error // Last error encountered
+	Width  int    // Terminal width
+	Height int    // Terminal height
+	Err    error  // Last error encountered
+	Status string // Notice shown under the current screen until the next key press
 }
 
 // NewModel creates a new root model with initial state.
 func NewModel() Model {
-	roller := dice.NewStandardRoller()
+	return NewModelWithRoller(dice.NewStandardRoller())
+}
 
+// NewModelWithRoller creates a new root model that makes every roll with
+// the given roller, e.g. to record or replay a session.
+func NewModelWithRoller(roller dice.Roller) Model {
 	// Load configuration
 	cfg, err := config.LoadDefault()
 	if err != nil {
@@ -91,10 +149,17 @@ func NewModel() Model {
 		cfg = config.Default()
 	}
 
-	return Model{
+	// Every screen shares the manual roller, which rolls with roller
+	// unless physical dice are switched on
+	manual := dice.NewManualRoller(roller)
+	manual.SetEnabled(cfg.ManualDice)
+	roller = manual
+
+	m := Model{
 		CurrentScreen: ScreenMainMenu,
 		Character:     nil,
 		Dice:          roller,
+		ManualDice:    manual,
 		Config:        cfg,
 		MainMenu:      NewMainMenuModel(),
 		CharCreation:  NewCharacterCreationModel(roller),
@@ -107,6 +172,13 @@ func NewModel() Model {
 		CombatState:   nil,
 		Settings:      NewSettingsModel(cfg),
 		DiceRoll:      NewDiceRollModel(roller),
+		SectionNav:    NewSectionNavModel(),
+		Resurrection:  NewResurrectionModel(roller),
+		TestChar:      NewTestCharacteristicModel(roller),
+		SaveAs:        NewSaveAsModel(),
+		RestoreBackup: NewRestoreBackupModel(),
+		Statistics:    NewStatisticsModel(),
+		Encounters:    NewEncounterHistoryModel(),
 		ShowingHelp:   false,
 		HelpScreen:    help.ScreenGlobal,
 		HelpScroll:    0,
@@ -115,14 +187,80 @@ func NewModel() Model {
 		Height:        24,
 		Err:           nil,
 	}
+	if err := m.LoadRuleset(); err != nil {
+		m.Status = fmt.Sprintf("Ruleset unavailable, playing by the %s rules: %v", m.Rules.Name, err)
+	}
+	return m
+}
+
+// LoadRuleset reads the ruleset profile chosen in the configuration.
+// If it cannot be read, the default rules are used.
+func (m *Model) LoadRuleset() error {
+	m.Rules = rules.Default()
+	if m.Config == nil {
+		return nil
+	}
+	ruleset, err := rules.Find(m.SaveDirectory(), m.Config.Ruleset)
+	if err != nil {
+		return err
+	}
+	m.Rules = ruleset
+	return nil
 }
 
 // LoadCharacter loads a character and transitions to the game session.
 func (m *Model) LoadCharacter(char *character.Character) {
 	m.Character = char
 	m.CurrentScreen = ScreenGameSession
+	m.CombatState = nil
+	m.SectionCombat = nil
+	m.FatalCombat = nil
 	m.CharView.SetCharacter(char)
 	m.CharEdit.SetCharacter(char)
+
+	// A fight the character was saved in is offered for resuming
+	interrupted, err := combat.Interrupted(char)
+	if err != nil {
+		m.Status = fmt.Sprintf("The saved fight could not be restored: %v", err)
+	}
+	m.SavedCombat = interrupted
+}
+
+// ResumeSavedCombat goes back into the fight the loaded character
+// was saved in.
+func (m *Model) ResumeSavedCombat() {
+	if m.SavedCombat == nil {
+		return
+	}
+	m.CombatState = m.SavedCombat
+	m.SavedCombat = nil
+	m.CombatState.AddLogEntry(fmt.Sprintf("[Resumed] The fight against %s goes on!", combat.EnemyNames(m.CombatState.LivingOpponents())))
+	m.CombatView = NewResumedCombatViewModel(m.Character, m.CombatState, m.Dice)
+	m.CurrentScreen = ScreenCombat
+}
+
+// FinishCombat adds the fight that just ended to the character's
+// statistics and encounter journal.
+func (m *Model) FinishCombat() {
+	if m.CombatState == nil || m.Character == nil {
+		return
+	}
+	m.CombatState.TallyStatistics(m.Character)
+	if encounter, ok := m.CombatState.Encounter(m.Character, time.Now()); ok {
+		if err := m.Character.AddEncounter(encounter); err != nil {
+			m.Status = fmt.Sprintf("The fight could not be added to the encounter history: %v", err)
+		}
+	}
+}
+
+// AbandonSavedCombat drops the fight the loaded character was saved
+// in. It is removed from the save the next time the character is saved,
+// and what happened in it is added to the character's statistics.
+func (m *Model) AbandonSavedCombat() {
+	if m.SavedCombat != nil && m.Character != nil {
+		m.SavedCombat.TallyStatistics(m.Character)
+	}
+	m.SavedCombat = nil
 }
 
 // SaveCharacter saves the current character to the configured location.
@@ -130,12 +268,167 @@ func (m *Model) SaveCharacter() error {
 	if m.Character == nil {
 		return nil
 	}
-	// Use configured save directory
-	saveDir := m.Config.SaveDirectory
-	if saveDir == "" {
-		saveDir = "."
+
+	// Keep the fight in progress, or the one not yet resumed, with the character
+	fight := m.CombatState
+	if fight == nil {
+		fight = m.SavedCombat
+	}
+	if err := writeSave(m.Character, fight, m.SaveDirectory(), m.backupPolicyIfEnabled()); err != nil {
+		return err
+	}
+	// A first save, or Save As, gives the character a slot to hold
+	return m.LockSlot()
+}
+
+// LockSlot marks the character's save slot as open in this instance,
+// releasing the slot held before if the character has moved to another.
+// A character without a slot yet needs no lock.
+func (m *Model) LockSlot() error {
+	if m.Character == nil || m.Character.Slot == "" {
+		return nil
+	}
+	path := character.SlotLockPath(m.SaveDirectory(), m.Character.Slot)
+	if m.SlotLock != nil && m.SlotLock.Path() == path {
+		return nil
+	}
+	lock, err := character.LockSlot(m.SaveDirectory(), m.Character.Slot)
+	if err != nil {
+		return err
+	}
+	m.ReleaseSlot()
+	m.SlotLock = lock
+	return nil
+}
+
+// ReleaseSlot lets other instances open the character's save slot again.
+func (m *Model) ReleaseSlot() {
+	m.SlotLock.Unlock()
+	m.SlotLock = nil
+}
+
+// BackupPolicy returns the backup limits set in the configuration.
+func (m *Model) BackupPolicy() character.BackupPolicy {
+	if m.Config == nil {
+		return character.BackupPolicy{}
+	}
+	return character.BackupPolicy{
+		MaxCount: m.Config.BackupCount,
+		MaxAge:   time.Duration(m.Config.BackupMaxAgeDays) * 24 * time.Hour,
+	}
+}
+
+// backupPolicyIfEnabled returns the backup limits, or nil if slots are
+// not backed up before saving.
+func (m *Model) backupPolicyIfEnabled() *character.BackupPolicy {
+	if m.Config == nil || !m.Config.CharacterBackup {
+		return nil
+	}
+	policy := m.BackupPolicy()
+	return &policy
+}
+
+// SaveDirectory returns the configured save location.
+func (m *Model) SaveDirectory() string {
+	if m.Config == nil || m.Config.SaveDirectory == "" {
+		return "."
+	}
+	return m.Config.SaveDirectory
+}
+
+// LoadBestiary reads the enemy library from the save directory.
+// It is read once and kept for the rest of the session.
+func (m *Model) LoadBestiary() error {
+	if m.Bestiary != nil {
+		return nil
+	}
+	bestiary, err := combat.LoadBestiary(combat.BestiaryPath(m.SaveDirectory()))
+	if err != nil {
+		return err
+	}
+	m.Bestiary = bestiary
+	return nil
+}
+
+// SaveEnemyToBestiary stores every enemy of the current fight as a
+// template, tagged with the current section.
+func (m *Model) SaveEnemyToBestiary() error {
+	if m.CombatState == nil || len(m.CombatState.Opponents) == 0 {
+		return fmt.Errorf("no enemy to save")
+	}
+	if err := m.LoadBestiary(); err != nil {
+		return err
+	}
+	section := 0
+	if m.Character != nil {
+		section = m.Character.CurrentSection
+	}
+	for _, opponent := range m.CombatState.Opponents {
+		if err := m.Bestiary.Add(combat.TemplateFromEnemy(opponent.Enemy, section)); err != nil {
+			return err
+		}
+	}
+	return m.Bestiary.Save(combat.BestiaryPath(m.SaveDirectory()))
+}
+
+// EnterSection moves the current character to a gamebook section.
+// Notices from the section rules (POW regeneration, expired spells) are
+// shown on the status line.
+func (m *Model) EnterSection(section int) (character.SectionTransition, error) {
+	if m.Character == nil {
+		return character.SectionTransition{}, fmt.Errorf("no character loaded")
+	}
+	transition, err := m.Character.EnterSection(section)
+	if err != nil {
+		return transition, err
+	}
+
+	// Remember the fight in progress so TIMEWARP can restart it
+	m.SectionCombat = nil
+	if m.CombatState != nil {
+		m.SectionCombat = m.CombatState.Clone()
+	}
+
+	m.RequestAutosave()
+
+	if len(transition.Notices) > 0 {
+		m.Status = fmt.Sprintf("Section %d: %s", section, strings.Join(transition.Notices, " · "))
+	}
+	return transition, nil
+}
+
+// RewindSection rolls the character back to the start of the current section
+// (TIMEWARP, RESURRECTION). POWER spent on the spell stays spent.
+// A fight in progress restarts from where it stood when the section began.
+// After a death, the fatal fight resumes with the enemy's LP as it was then.
+func (m *Model) RewindSection() error {
+	if m.Character == nil {
+		return fmt.Errorf("no character loaded")
+	}
+
+	pow := m.Character.CurrentPOW
+	if err := m.Character.RestoreSectionStart(); err != nil {
+		return err
+	}
+	m.Character.SetPOW(pow)
+
+	switch {
+	case m.FatalCombat != nil:
+		resumed := m.FatalCombat.Clone()
+		combat.ResumeCombat(m.Character, resumed)
+		resumed.AddLogEntry(fmt.Sprintf("[Resurrection] You rise again to face %s!", combat.EnemyNames(resumed.LivingOpponents())))
+		m.FatalCombat = nil
+		m.CombatState = resumed
+		m.CombatView = NewCombatViewModel(m.Character, m.CombatState, m.Dice)
+	case m.CombatState != nil && m.SectionCombat != nil:
+		// The fight so far still counts; its restart is only counted from here
+		m.CombatState.TallyStatistics(m.Character)
+		m.CombatState = m.SectionCombat.Clone()
+		m.CombatState.Tallied = len(m.CombatState.Events)
+		m.CombatState.AddLogEntry("[Timewarp] The fight begins again!")
+		m.CombatView = NewCombatViewModel(m.Character, m.CombatState, m.Dice)
 	}
-	return m.Character.Save(saveDir)
+	return nil
 }
 
 // ShowHelp displays the help modal for the specified screen.
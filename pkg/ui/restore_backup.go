@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/pkg/ui/theme"
+)
+
+// backupListSize is the number of backups shown at once.
+const backupListSize = 8
+
+// RestoreBackupModel handles the "Restore Backup..." screen: the backups
+// of the character's save slot, with what restoring each would change.
+type RestoreBackupModel struct {
+	character *character.Character
+	directory string
+	backups   []character.Backup
+	cursor    int
+	preview   []character.StatChange // Changes restoring the selected backup makes
+	err       error                  // Error listing the backups or reading the selected one
+	message   string                 // Error from the last restore
+}
+
+// NewRestoreBackupModel creates a new restore backup model.
+func NewRestoreBackupModel() RestoreBackupModel {
+	return RestoreBackupModel{}
+}
+
+// Reset lists the backups of the character's slot in the save directory.
+func (m *RestoreBackupModel) Reset(char *character.Character, directory string) {
+	*m = NewRestoreBackupModel()
+	m.character = char
+	m.directory = directory
+	if char == nil || char.Slot == "" {
+		return
+	}
+
+	backups, err := character.ListBackups(directory, char.Slot)
+	if err != nil {
+		m.err = err
+		return
+	}
+	m.backups = backups
+	m.loadPreview()
+}
+
+// MoveUp selects the newer backup.
+func (m *RestoreBackupModel) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+		m.loadPreview()
+	}
+}
+
+// MoveDown selects the older backup.
+func (m *RestoreBackupModel) MoveDown() {
+	if m.cursor < len(m.backups)-1 {
+		m.cursor++
+		m.loadPreview()
+	}
+}
+
+// loadPreview compares the selected backup with the current character.
+func (m *RestoreBackupModel) loadPreview() {
+	m.preview, m.err, m.message = nil, nil, ""
+	if len(m.backups) == 0 {
+		return
+	}
+	backup, err := m.backups[m.cursor].Load()
+	if err != nil {
+		m.err = err
+		return
+	}
+	m.preview = m.character.Diff(backup)
+}
+
+// HasBackups returns true if the slot has backups to restore.
+func (m *RestoreBackupModel) HasBackups() bool {
+	return len(m.backups) > 0
+}
+
+// Selected returns the selected backup.
+func (m *RestoreBackupModel) Selected() character.Backup {
+	if !m.HasBackups() {
+		return character.Backup{}
+	}
+	return m.backups[m.cursor]
+}
+
+// Restore rolls the slot back to the selected backup and returns the
+// restored character, or nil with the reason shown on screen.
+func (m *RestoreBackupModel) Restore(policy character.BackupPolicy) *character.Character {
+	if !m.HasBackups() || m.err != nil {
+		return nil
+	}
+	saveMu.Lock()
+	restored, err := character.RestoreBackup(m.directory, m.backups[m.cursor], policy)
+	if err == nil {
+		saveEpoch++
+	}
+	saveMu.Unlock()
+	if err != nil {
+		m.message = err.Error()
+		return nil
+	}
+	return restored
+}
+
+// View renders the restore backup screen.
+func (m RestoreBackupModel) View() string {
+	var b strings.Builder
+	t := theme.Current()
+
+	b.WriteString("\n")
+	b.WriteString(theme.RenderTitle("RESTORE BACKUP"))
+	b.WriteString("\n\n")
+
+	if m.character != nil && m.character.Slot != "" {
+		b.WriteString("  " + theme.RenderLabel("Slot", character.SlotName(m.directory, m.character.Slot)) + "\n\n")
+	}
+
+	if !m.HasBackups() {
+		if m.err != nil {
+			b.WriteString(theme.RenderError("Cannot list backups", m.err.Error(), "") + "\n")
+		} else {
+			b.WriteString("  " + t.MutedText.Render("No backups of this slot yet.") + "\n")
+			b.WriteString("  " + t.MutedText.Render("A backup is taken each time the slot is saved again") + "\n")
+			b.WriteString("  " + t.MutedText.Render("while Character Backups is on in Settings.") + "\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(theme.RenderKeyHelp("Esc Back"))
+		return b.String()
+	}
+
+	// Keep the selected backup in view
+	start := 0
+	if m.cursor >= backupListSize {
+		start = m.cursor - backupListSize + 1
+	}
+	end := min(start+backupListSize, len(m.backups))
+	for i := start; i < end; i++ {
+		label := m.backups[i].Time.Format("2006-01-02 15:04:05")
+		b.WriteString("  " + theme.RenderMenuItem(label, i == m.cursor) + "\n")
+	}
+	if len(m.backups) > backupListSize {
+		b.WriteString("  " + t.MutedText.Render(fmt.Sprintf("%d backups", len(m.backups))) + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(t.Heading.Render("  Restoring this backup changes") + "\n")
+	switch {
+	case m.err != nil:
+		b.WriteString(theme.RenderError("Cannot read backup", m.err.Error(), "") + "\n")
+	case len(m.preview) == 0:
+		b.WriteString("  " + t.MutedText.Render("Nothing: the backup matches the current character") + "\n")
+	default:
+		for _, change := range m.preview {
+			b.WriteString(fmt.Sprintf("  %-18s %s → %s\n", change.Name, change.From, t.Emphasis.Render(change.To)))
+		}
+	}
+
+	if m.message != "" {
+		b.WriteString("\n  " + t.WarningMsg.Render(m.message) + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(theme.RenderKeyHelp("↑/↓ Select", "Enter Restore", "Esc Back"))
+	return b.String()
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/internal/dice"
+	"github.com/benoit/saga-demonspawn/pkg/ui/theme"
+)
+
+// ResurrectionModel handles rerolling Fire*Wolf's characteristics after
+// a RESURRECTION. It reuses the roll step of character creation.
+type ResurrectionModel struct {
+	character *character.Character
+	dice      dice.Roller
+	rolls     CharacterCreationModel // Roll step shared with character creation
+	pow       int                    // Rerolled POWER (only if magic is unlocked)
+}
+
+// NewResurrectionModel creates a new resurrection model.
+func NewResurrectionModel(roller dice.Roller) ResurrectionModel {
+	return ResurrectionModel{
+		character: nil,
+		dice:      roller,
+		rolls:     NewCharacterCreationModel(roller),
+		pow:       0,
+	}
+}
+
+// Reset prepares the screen for the resurrected character.
+func (m *ResurrectionModel) Reset(char *character.Character) {
+	*m = NewResurrectionModel(m.dice)
+	m.character = char
+}
+
+// RollAll rerolls all characteristics, and POWER if magic is unlocked.
+func (m *ResurrectionModel) RollAll() {
+	m.rolls.RollAll()
+	if m.character != nil && m.character.MagicUnlocked {
+		dice.Label(m.dice, "POW")
+		m.pow = m.dice.RollCharacteristic()
+	}
+}
+
+// AreAllRolled returns true once the characteristics have been rerolled.
+func (m *ResurrectionModel) AreAllRolled() bool {
+	return m.rolls.AreAllRolled()
+}
+
+// Apply gives the rerolled characteristics to the character.
+func (m *ResurrectionModel) Apply() error {
+	if m.character == nil {
+		return fmt.Errorf("no character to resurrect")
+	}
+	if !m.AreAllRolled() {
+		return fmt.Errorf("characteristics have not been rolled")
+	}
+	str, spd, sta, crg, lck, chm, att := m.rolls.GetCharacteristics()
+	return m.character.Resurrect(str, spd, sta, crg, lck, chm, att, m.pow)
+}
+
+// View renders the resurrection screen.
+func (m ResurrectionModel) View() string {
+	var b strings.Builder
+	t := theme.Current()
+
+	b.WriteString("\n")
+	b.WriteString(theme.RenderTitle("RESURRECTION"))
+	b.WriteString("\n\n")
+
+	if m.character == nil {
+		return b.String() + "No character loaded"
+	}
+
+	b.WriteString(t.Body.Render("  Fire*Wolf returns from death with a new body.") + "\n")
+	b.WriteString(t.Body.Render("  Roll 2d6 × 8 for each characteristic:") + "\n\n")
+
+	str, spd, sta, crg, lck, chm, att := m.rolls.GetCharacteristics()
+	rows := []struct {
+		label    string
+		previous int
+		rolled   int
+	}{
+		{"Strength (STR)  ", m.character.Strength, str},
+		{"Speed (SPD)     ", m.character.Speed, spd},
+		{"Stamina (STA)   ", m.character.Stamina, sta},
+		{"Courage (CRG)   ", m.character.Courage, crg},
+		{"Luck (LCK)      ", m.character.Luck, lck},
+		{"Charm (CHM)     ", m.character.Charm, chm},
+		{"Attraction (ATT)", m.character.Attraction, att},
+	}
+	for _, row := range rows {
+		b.WriteString("    " + theme.RenderLabel(row.label, fmt.Sprintf("%3d → ", row.previous)+formatRollColored(row.rolled)) + "\n")
+	}
+	if m.character.MagicUnlocked {
+		b.WriteString("    " + theme.RenderLabel("Power (POW)     ", fmt.Sprintf("%3d → ", m.character.MaximumPOW)+formatRollColored(m.pow)) + "\n")
+	}
+	b.WriteString("\n")
+
+	if m.AreAllRolled() {
+		b.WriteString("  " + theme.RenderLabel("Life Points (LP)", fmt.Sprintf("%d", m.rolls.GetCalculatedLP())) + "\n")
+		b.WriteString("  " + theme.RenderLabel("Skill (SKL)     ", fmt.Sprintf("%d (kept)", m.character.Skill)) + "\n\n")
+		b.WriteString(t.Emphasis.Render("  Press Enter to rise again") + "\n")
+	} else {
+		b.WriteString(t.Emphasis.Render("  Press 'r' to roll all characteristics") + "\n")
+	}
+	b.WriteString(t.MutedText.Render("  Equipment, special items and enemies defeated are kept.") + "\n\n")
+
+	b.WriteString(theme.RenderKeyHelp("r Roll", "Enter Rise again", "? Help"))
+
+	return b.String()
+}
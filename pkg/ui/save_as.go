@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/pkg/ui/theme"
+)
+
+// SaveAsModel handles the "Save As..." screen: typing the name of a new
+// save slot for the current character.
+type SaveAsModel struct {
+	character   *character.Character
+	directory   string
+	current     string // Name of the character's current slot, if any
+	inputBuffer string // Slot name being typed
+	message     string // Error from the last attempt
+}
+
+// NewSaveAsModel creates a new save-as model.
+func NewSaveAsModel() SaveAsModel {
+	return SaveAsModel{}
+}
+
+// Reset prepares the screen for the given character and save directory.
+func (m *SaveAsModel) Reset(char *character.Character, directory string) {
+	*m = NewSaveAsModel()
+	m.character = char
+	m.directory = directory
+	if char != nil && char.Slot != "" {
+		m.current = character.SlotName(directory, char.Slot)
+	}
+}
+
+// AppendInput adds typed text to the slot name.
+func (m *SaveAsModel) AppendInput(text string) {
+	if len([]rune(m.inputBuffer+text)) <= slotNameLimit {
+		m.inputBuffer += text
+	}
+}
+
+// Backspace removes the last typed character.
+func (m *SaveAsModel) Backspace() {
+	if runes := []rune(m.inputBuffer); len(runes) > 0 {
+		m.inputBuffer = string(runes[:len(runes)-1])
+	}
+}
+
+// Save saves the character to a new slot with the typed name. It returns
+// false, with the reason shown on screen, if the slot could not be created.
+func (m *SaveAsModel) Save() bool {
+	if m.character == nil {
+		m.message = "No character loaded"
+		return false
+	}
+	saveMu.Lock()
+	defer saveMu.Unlock()
+	if err := m.character.SaveAs(m.directory, m.inputBuffer); err != nil {
+		m.message = err.Error()
+		return false
+	}
+	saveEpoch++
+	return true
+}
+
+// SlotName returns the name typed, trimmed.
+func (m *SaveAsModel) SlotName() string {
+	return strings.TrimSpace(m.inputBuffer)
+}
+
+// View renders the save-as screen.
+func (m SaveAsModel) View() string {
+	var b strings.Builder
+	t := theme.Current()
+
+	b.WriteString("\n")
+	b.WriteString(theme.RenderTitle("SAVE AS"))
+	b.WriteString("\n\n")
+
+	if m.current != "" {
+		b.WriteString("  " + theme.RenderLabel("Current slot", m.current) + "\n\n")
+	}
+	b.WriteString("  " + theme.RenderLabel("New slot name", t.Emphasis.Render("["+m.inputBuffer+"_]")) + "\n")
+	b.WriteString("  " + t.MutedText.Render("The character is saved to the new slot from now on;") + "\n")
+	b.WriteString("  " + t.MutedText.Render("the current slot keeps its last save.") + "\n")
+
+	if m.message != "" {
+		b.WriteString("\n  " + t.WarningMsg.Render(m.message) + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(theme.RenderKeyHelp("Enter Save", "Backspace Delete", "Esc Cancel"))
+	return b.String()
+}
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/pkg/ui/theme"
+)
+
+// sectionHistoryVisible is how many recent section visits are listed.
+const sectionHistoryVisible = 10
+
+// SectionNavModel handles the "Go to Section" screen state.
+type SectionNavModel struct {
+	character   *character.Character
+	inputBuffer string // Section number being typed
+	message     string // Result of the last navigation
+	isError     bool   // Whether message describes an error
+}
+
+// NewSectionNavModel creates a new section navigation model.
+func NewSectionNavModel() SectionNavModel {
+	return SectionNavModel{
+		character:   nil,
+		inputBuffer: "",
+		message:     "",
+		isError:     false,
+	}
+}
+
+// Reset prepares the screen for the given character.
+func (m *SectionNavModel) Reset(char *character.Character) {
+	*m = NewSectionNavModel()
+	m.character = char
+}
+
+// AppendInput adds a digit to the section number being typed.
+func (m *SectionNavModel) AppendInput(digit string) {
+	if len(m.inputBuffer) < 5 {
+		m.inputBuffer += digit
+	}
+}
+
+// Backspace removes the last typed digit.
+func (m *SectionNavModel) Backspace() {
+	if len(m.inputBuffer) > 0 {
+		m.inputBuffer = m.inputBuffer[:len(m.inputBuffer)-1]
+	}
+}
+
+// GetInputBuffer returns the section number being typed.
+func (m *SectionNavModel) GetInputBuffer() string {
+	return m.inputBuffer
+}
+
+// ParseInput returns the typed section number.
+func (m *SectionNavModel) ParseInput() (int, error) {
+	if m.inputBuffer == "" {
+		return 0, fmt.Errorf("enter a section number")
+	}
+	section, err := strconv.Atoi(m.inputBuffer)
+	if err != nil || section <= 0 {
+		return 0, fmt.Errorf("section must be a positive number")
+	}
+	return section, nil
+}
+
+// SetResult records the outcome of a navigation attempt and clears the input.
+func (m *SectionNavModel) SetResult(message string, isError bool) {
+	m.message = message
+	m.isError = isError
+	if !isError {
+		m.inputBuffer = ""
+	}
+}
+
+// GetMessage returns the last navigation message.
+func (m *SectionNavModel) GetMessage() string {
+	return m.message
+}
+
+// View renders the section navigation screen.
+func (m SectionNavModel) View() string {
+	var b strings.Builder
+	t := theme.Current()
+
+	b.WriteString("\n")
+	b.WriteString(theme.RenderTitle("GO TO SECTION"))
+	b.WriteString("\n\n")
+
+	if m.character == nil {
+		return b.String() + "No character loaded"
+	}
+
+	current := "Not started"
+	if m.character.CurrentSection > 0 {
+		current = fmt.Sprintf("%d", m.character.CurrentSection)
+	}
+	b.WriteString("  " + theme.RenderLabel("Current Section", current) + "\n")
+	b.WriteString("  " + theme.RenderLabel("Sections Visited", fmt.Sprintf("%d", len(m.character.VisitedSections()))) + "\n\n")
+
+	b.WriteString("  " + t.Label.Render("Enter section number: ") +
+		t.Emphasis.Render("["+m.inputBuffer+"_]") + "\n\n")
+
+	if m.message != "" {
+		if m.isError {
+			b.WriteString(theme.RenderError("Navigation Error", m.message, "") + "\n\n")
+		} else {
+			b.WriteString(theme.RenderSuccess(m.message) + "\n\n")
+		}
+	}
+
+	// Recent visit history, most recent first
+	history := m.character.SectionHistory
+	if len(history) > 0 {
+		b.WriteString(t.Heading.Render("  Recent Sections") + "\n")
+		b.WriteString(theme.RenderSeparator(40) + "\n")
+		shown := 0
+		for i := len(history) - 1; i >= 0 && shown < sectionHistoryVisible; i-- {
+			section := history[i]
+			firstVisit := ""
+			if visitedAt, ok := m.character.SectionFirstVisit(section); ok {
+				firstVisit = visitedAt.Format("2006-01-02 15:04")
+			}
+			b.WriteString(fmt.Sprintf("  %s %s\n",
+				t.Value.Render(fmt.Sprintf("%5d", section)),
+				t.MutedText.Render("first visited "+firstVisit)))
+			shown++
+		}
+		if len(history) > sectionHistoryVisible {
+			b.WriteString(t.MutedText.Render(fmt.Sprintf("  ... %d earlier visits", len(history)-sectionHistoryVisible)) + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(theme.RenderKeyHelp("0-9 Type section", "Enter Go", "Esc Back", "? Help"))
+
+	return b.String()
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"github.com/benoit/saga-demonspawn/internal/config"
+	"github.com/benoit/saga-demonspawn/internal/rules"
 )
 
 // SettingField represents individual settings that can be edited.
@@ -13,9 +14,15 @@ const (
 	SettingShowAnimations
 	SettingConfirmActions
 	SettingAutoSave
+	SettingAutoSaveInterval
 	SettingShowRollDetails
+	SettingManualDice
+	SettingRuleset
 	SettingHighContrast
 	SettingReducedMotion
+	SettingCharacterBackup
+	SettingBackupCount
+	SettingBackupMaxAge
 	SettingSave
 	SettingCancel
 	SettingReset
@@ -96,13 +103,67 @@ func (m *SettingsModel) ToggleCurrentSetting() {
 		m.config.AutoSave = !m.config.AutoSave
 	case SettingShowRollDetails:
 		m.config.ShowRollDetails = !m.config.ShowRollDetails
+	case SettingManualDice:
+		m.config.ManualDice = !m.config.ManualDice
 	case SettingHighContrast:
 		m.config.HighContrast = !m.config.HighContrast
 	case SettingReducedMotion:
 		m.config.ReducedMotion = !m.config.ReducedMotion
+	case SettingCharacterBackup:
+		m.config.CharacterBackup = !m.config.CharacterBackup
 	}
 }
 
+// Choices offered for the backup limits.
+var (
+	backupCountChoices  = []int{3, 5, 10, 20, 50}
+	backupMaxAgeChoices = []int{7, 30, 90, 365, 0} // Days; 0 keeps backups forever
+)
+
+// autoSaveIntervalChoices are the minutes offered between background
+// saves; 0 only saves after key events.
+var autoSaveIntervalChoices = []int{1, 2, 5, 10, 15, 30, 0}
+
+// CycleAutoSaveInterval cycles through the autosave intervals.
+func (m *SettingsModel) CycleAutoSaveInterval() {
+	m.config.AutoSaveMinutes = nextChoice(autoSaveIntervalChoices, m.config.AutoSaveMinutes)
+}
+
+// CycleBackupCount cycles through the number of backups kept per slot.
+func (m *SettingsModel) CycleBackupCount() {
+	m.config.BackupCount = nextChoice(backupCountChoices, m.config.BackupCount)
+}
+
+// CycleBackupMaxAge cycles through the age limits for backups.
+func (m *SettingsModel) CycleBackupMaxAge() {
+	m.config.BackupMaxAgeDays = nextChoice(backupMaxAgeChoices, m.config.BackupMaxAgeDays)
+}
+
+// nextChoice returns the choice after current, or the first one if current
+// is not a choice.
+func nextChoice(choices []int, current int) int {
+	for i, choice := range choices {
+		if choice == current {
+			return choices[(i+1)%len(choices)]
+		}
+	}
+	return choices[0]
+}
+
+// CycleRuleset cycles through the built-in ruleset profiles and the
+// custom profiles of the save directory.
+func (m *SettingsModel) CycleRuleset() {
+	ids := rules.List(m.config.SaveDirectory)
+	next := ids[0]
+	for i, id := range ids {
+		if id == m.config.Ruleset {
+			next = ids[(i+1)%len(ids)]
+			break
+		}
+	}
+	m.config.Ruleset = next
+}
+
 // CycleTheme cycles through available themes.
 func (m *SettingsModel) CycleTheme() {
 	switch m.config.Theme {
@@ -7,6 +7,7 @@ import (
 	"github.com/benoit/saga-demonspawn/internal/character"
 	"github.com/benoit/saga-demonspawn/internal/dice"
 	"github.com/benoit/saga-demonspawn/internal/magic"
+	"github.com/benoit/saga-demonspawn/internal/rules"
 	"github.com/benoit/saga-demonspawn/pkg/ui/theme"
 )
 
@@ -14,6 +15,7 @@ import (
 type SpellCastingModel struct {
 	character       *character.Character
 	roller          dice.Roller
+	rules           rules.Ruleset
 	cursor          int
 	spells          []magic.Spell
 	inCombat        bool
@@ -23,16 +25,28 @@ type SpellCastingModel struct {
 	sacrificeAmount int    // Amount of LP to sacrifice
 	naturalCheckMsg string // Result of natural inclination check
 	returnToCombat  bool   // Whether to return to combat screen on exit
+
+	// RETRACE destination picker
+	pickingSection bool  // Whether choosing a section to retrace to
+	sectionOptions []int // Previously visited sections
+	sectionCursor  int   // Selected destination
+	retraceTarget  int   // Chosen destination (0 = none yet)
+
+	// Most recent cast, for the combat log
+	lastSpell string
+	lastCast  magic.CastResult
 }
 
-// NewSpellCastingModel creates a new spell casting model.
-func NewSpellCastingModel(char *character.Character, roller dice.Roller, inCombat bool) SpellCastingModel {
+// NewSpellCastingModel creates a new spell casting model played by the
+// given rules.
+func NewSpellCastingModel(char *character.Character, ruleset rules.Ruleset, roller dice.Roller, inCombat bool) SpellCastingModel {
 	isDead := char.CurrentLP <= 0
 	availableSpells := magic.GetAvailableSpells(inCombat, isDead)
 
 	return SpellCastingModel{
 		character:       char,
 		roller:          roller,
+		rules:           ruleset,
 		cursor:          0,
 		spells:          availableSpells,
 		inCombat:        inCombat,
@@ -78,8 +92,15 @@ func (m *SpellCastingModel) IsNaturalCheckSelected() bool {
 }
 
 // PerformNaturalCheck performs the natural inclination check.
+// The check is rolled once per section and the result is kept on the character.
 func (m *SpellCastingModel) PerformNaturalCheck() {
-	success, roll := magic.NaturalInclinationCheck(m.roller)
+	if m.character.SectionMagic.InclinationChecked {
+		m.naturalCheckMsg = "Natural Inclination Check: Already rolled in this section - " + inclinationStatus(m.character.SectionMagic)
+		return
+	}
+
+	success, roll := magic.NaturalInclinationCheck(m.rules, m.roller)
+	m.character.RecordInclinationCheck(success)
 	if success {
 		m.naturalCheckMsg = fmt.Sprintf("Natural Inclination Check: Rolled %d - Fire*Wolf overcomes his aversion to magic!", roll)
 	} else {
@@ -95,9 +116,26 @@ func (m *SpellCastingModel) AttemptCast() bool {
 		return false
 	}
 
+	// The natural inclination check comes first in every section
+	if !m.character.SectionMagic.InclinationChecked {
+		m.PerformNaturalCheck()
+	}
+
 	// Validate the cast
 	isDead := m.character.CurrentLP <= 0
-	result := magic.ValidateCast(spell, m.character.CurrentPOW, m.character.CurrentLP, m.inCombat, isDead)
+	result := magic.ValidateCast(spell, m.character.CurrentPOW, m.character.CurrentLP, m.inCombat, isDead, m.character.SectionMagic)
+
+	// RETRACE needs a destination before it can be cast
+	if spell.Name == "RETRACE" && m.retraceTarget == 0 && (result.Success || result.RequiresSacrifice) {
+		m.StartRetracePicker()
+		return false
+	}
+
+	// TIMEWARP and RESURRECTION roll back to the start of the current section
+	if (spell.Name == "TIMEWARP" || spell.Name == "RESURRECTION") && m.character.SectionStart == nil && (result.Success || result.RequiresSacrifice) {
+		m.message = fmt.Sprintf("%s needs a section to return to. Use Go to Section first.", spell.Name)
+		return false
+	}
 
 	if result.RequiresSacrifice {
 		// Need confirmation for LP sacrifice
@@ -118,6 +156,52 @@ func (m *SpellCastingModel) AttemptCast() bool {
 	return true
 }
 
+// StartRetracePicker opens the list of sections RETRACE can return to.
+func (m *SpellCastingModel) StartRetracePicker() {
+	m.sectionOptions = m.character.PreviousSections()
+	if len(m.sectionOptions) == 0 {
+		m.message = "You have not visited any other section to retrace to."
+		return
+	}
+	m.pickingSection = true
+	m.sectionCursor = 0
+	m.message = ""
+}
+
+// IsPickingSection returns true while choosing a RETRACE destination.
+func (m *SpellCastingModel) IsPickingSection() bool {
+	return m.pickingSection
+}
+
+// MoveSectionCursorUp moves the destination cursor up.
+func (m *SpellCastingModel) MoveSectionCursorUp() {
+	if m.sectionCursor > 0 {
+		m.sectionCursor--
+	}
+}
+
+// MoveSectionCursorDown moves the destination cursor down.
+func (m *SpellCastingModel) MoveSectionCursorDown() {
+	if m.sectionCursor < len(m.sectionOptions)-1 {
+		m.sectionCursor++
+	}
+}
+
+// ConfirmRetraceTarget selects the highlighted section as the RETRACE destination.
+func (m *SpellCastingModel) ConfirmRetraceTarget() {
+	if m.sectionCursor < len(m.sectionOptions) {
+		m.retraceTarget = m.sectionOptions[m.sectionCursor]
+	}
+	m.pickingSection = false
+}
+
+// CancelRetracePicker closes the destination picker without casting.
+func (m *SpellCastingModel) CancelRetracePicker() {
+	m.pickingSection = false
+	m.retraceTarget = 0
+	m.message = "RETRACE cancelled"
+}
+
 // ConfirmSacrifice confirms LP sacrifice and proceeds with cast.
 func (m *SpellCastingModel) ConfirmSacrifice() bool {
 	m.awaitingConfirm = false
@@ -131,6 +215,7 @@ func (m *SpellCastingModel) ConfirmSacrifice() bool {
 // CancelSacrifice cancels the sacrifice and returns to spell selection.
 func (m *SpellCastingModel) CancelSacrifice() {
 	m.awaitingConfirm = false
+	m.retraceTarget = 0
 	m.message = "Sacrifice cancelled"
 }
 
@@ -142,11 +227,18 @@ func (m *SpellCastingModel) PerformCast() (magic.SpellEffect, bool) {
 		return magic.SpellEffect{}, false
 	}
 
-	// Deduct power cost
+	// A RETRACE destination only applies to this cast
+	retraceTarget := m.retraceTarget
+	m.retraceTarget = 0
+
+	// Deduct power cost; the spell counts as cast in this section even if it fizzles
 	m.character.ModifyPOW(-spell.PowerCost)
+	m.character.RecordSpellCast(spell.Name)
 
 	// Perform FFR check
-	castResult := magic.PerformCast(spell, m.roller)
+	castResult := magic.PerformCast(spell, m.rules, m.roller)
+	m.lastSpell, m.lastCast = spell.Name, castResult
+	m.character.Statistics.RecordSpell(spell.Name, castResult.PowerSpent, castResult.Success)
 	if castResult.FFRFailed {
 		m.message = castResult.Message
 		return magic.SpellEffect{Success: false, Message: castResult.Message}, false
@@ -173,12 +265,11 @@ func (m *SpellCastingModel) PerformCast() (magic.SpellEffect, bool) {
 	case "RESURRECTION":
 		effect = magic.ApplyRESURRECTION()
 	case "RETRACE":
-		// For now, just show message (actual navigation handled by UI layer)
-		effect = magic.ApplyRETRACE("Previous Section")
+		// Navigation itself is handled by the UI layer
+		effect = magic.ApplyRETRACE(retraceTarget)
 	case "TIMEWARP":
+		// The rollback itself is handled by the UI layer
 		effect = magic.ApplyTIMEWARP()
-		// Restore character LP to max (simplified - actual implementation would track section entry LP)
-		m.character.SetLP(m.character.MaximumLP)
 	case "XENOPHOBIA":
 		effect = magic.ApplyXENOPHOBIA()
 		// Effect is handled in combat damage calculation
@@ -192,6 +283,11 @@ func (m *SpellCastingModel) PerformCast() (magic.SpellEffect, bool) {
 	return effect, true
 }
 
+// LastCast returns the name and FFR result of the most recent cast.
+func (m *SpellCastingModel) LastCast() (string, magic.CastResult) {
+	return m.lastSpell, m.lastCast
+}
+
 // GetMessage returns the current message.
 func (m *SpellCastingModel) GetMessage() string {
 	return m.message
@@ -226,6 +322,8 @@ func (m SpellCastingModel) Render() string {
 	// Show natural check message if present
 	if m.naturalCheckMsg != "" {
 		b.WriteString("  " + t.Emphasis.Render(m.naturalCheckMsg) + "\n\n")
+	} else if m.character.SectionMagic.InclinationChecked {
+		b.WriteString("  " + t.MutedText.Render("Natural Inclination: "+inclinationStatus(m.character.SectionMagic)) + "\n\n")
 	}
 
 	// Show awaiting confirmation dialog
@@ -237,6 +335,16 @@ func (m SpellCastingModel) Render() string {
 		return b.String()
 	}
 
+	// Show RETRACE destination picker
+	if m.pickingSection {
+		b.WriteString(t.Heading.Render("  RETRACE - Choose a Section") + "\n\n")
+		for i, section := range m.sectionOptions {
+			b.WriteString("  " + theme.RenderMenuItem(fmt.Sprintf("Section %d", section), i == m.sectionCursor) + "\n")
+		}
+		b.WriteString("\n" + theme.RenderKeyHelp("↑/↓ Select", "Enter Retrace", "Esc Cancel") + "\n")
+		return b.String()
+	}
+
 	// Show spell list with scrolling viewport
 	b.WriteString(t.Heading.Render("  Available Spells") + "\n\n")
 	
@@ -299,7 +407,9 @@ func (m SpellCastingModel) Render() string {
 			}
 
 			context := ""
-			if spell.CombatOnly {
+			if m.character.SectionMagic.HasCast(spell.Name) {
+				context = t.MutedText.Render(" (Cast this section)")
+			} else if spell.CombatOnly {
 				context = t.WarningMsg.Render(" (Combat only)")
 			} else if spell.Name == "CRYPT" || spell.Name == "RETRACE" {
 				context = t.MutedText.Render(" (Non-combat)")
@@ -317,7 +427,7 @@ func (m SpellCastingModel) Render() string {
 		} else {
 			// Render Natural Inclination Check option
 			selected := m.cursor == len(m.spells)
-			checkText := "Natural Inclination Check (Roll 2d6, need 4+)"
+			checkText := fmt.Sprintf("Natural Inclination Check (Roll 2d6, need %d+)", m.rules.InclinationThreshold)
 			
 			if selected {
 				b.WriteString("  " + theme.RenderMenuItem(checkText, true) + "\n\n")
@@ -352,3 +462,11 @@ func (m SpellCastingModel) Render() string {
 
 	return b.String()
 }
+
+// inclinationStatus describes the natural inclination result for the section.
+func inclinationStatus(section character.SectionMagic) string {
+	if section.InclinationPassed {
+		return "Fire*Wolf will use magic in this section."
+	}
+	return "Fire*Wolf refuses to use sorcery this section."
+}
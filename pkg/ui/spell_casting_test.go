@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/internal/combat"
+	"github.com/benoit/saga-demonspawn/internal/dice"
+	"github.com/benoit/saga-demonspawn/internal/magic"
+)
+
+// TestSpellDamageInCombat verifies a spell slaying one of two enemies
+// moves the target on, and slaying the last one wins the fight.
+func TestSpellDamageInCombat(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	m := NewModelWithRoller(dice.NewSeededRoller(1))
+	m.Character, _ = character.New(64, 56, 72, 48, 80, 40, 56)
+	goblin, _ := combat.NewEnemy("Goblin", 40, 35, 30, 25, 20, 0, 40, 40, 5, 0, false)
+	troll, _ := combat.NewEnemy("Troll", 40, 35, 30, 25, 20, 0, 90, 90, 5, 0, false)
+	m.CombatState = combat.NewEncounterState([]*combat.Enemy{goblin, troll}, 3)
+	m.CombatView = NewCombatViewModel(m.Character, m.CombatState, m.Dice)
+	m.CurrentScreen = ScreenMagic
+
+	m.handleSpellEffect("FIREBALL", magic.ApplyFIREBALL())
+	if m.CombatState.Enemy != troll || m.CombatView.victoryState {
+		t.Fatalf("after the Goblin fell: target %s, victory %v; want the Troll and no victory",
+			m.CombatState.Enemy.Name, m.CombatView.victoryState)
+	}
+
+	m.handleSpellEffect("POISON NEEDLE", magic.SpellEffect{Success: true, EnemyKilled: true})
+	if !m.CombatView.victoryState || m.CurrentScreen != ScreenCombat {
+		t.Errorf("after the Troll fell: victory %v on screen %v; want the victory screen", m.CombatView.victoryState, m.CurrentScreen)
+	}
+	if m.Character.EnemiesDefeated != 2 {
+		t.Errorf("EnemiesDefeated = %d, want 2", m.Character.EnemiesDefeated)
+	}
+}
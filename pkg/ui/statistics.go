@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/pkg/ui/theme"
+)
+
+// StatisticsModel handles the "Statistics" screen: the character's
+// lifetime record of fights, attacks, healing, death saves and spells.
+type StatisticsModel struct {
+	character *character.Character
+}
+
+// NewStatisticsModel creates a new statistics model.
+func NewStatisticsModel() StatisticsModel {
+	return StatisticsModel{}
+}
+
+// Reset shows the statistics of the given character.
+func (m *StatisticsModel) Reset(char *character.Character) {
+	m.character = char
+}
+
+// View renders the statistics screen.
+func (m StatisticsModel) View() string {
+	var b strings.Builder
+	t := theme.Current()
+
+	b.WriteString("\n")
+	b.WriteString(theme.RenderTitle("STATISTICS"))
+	b.WriteString("\n\n")
+
+	if m.character == nil {
+		b.WriteString("  " + t.MutedText.Render("No character loaded") + "\n\n")
+		b.WriteString(theme.RenderKeyHelp("Esc Back"))
+		return b.String()
+	}
+	s := m.character.Statistics
+
+	// Fights
+	b.WriteString(t.Heading.Render("  Fights") + "\n")
+	b.WriteString(theme.RenderSeparator(50) + "\n")
+	b.WriteString(fmt.Sprintf("  %s  %s  %s  %s\n",
+		theme.RenderLabel("Won", fmt.Sprintf("%d", s.FightsWon)),
+		theme.RenderLabel("Lost", fmt.Sprintf("%d", s.FightsLost)),
+		theme.RenderLabel("Fled", fmt.Sprintf("%d", s.FightsFled)),
+		theme.RenderLabel("Escaped", fmt.Sprintf("%d", s.FightsEscaped))))
+	b.WriteString("\n")
+
+	// Attacks
+	b.WriteString(t.Heading.Render("  Attacks") + "\n")
+	b.WriteString(theme.RenderSeparator(50) + "\n")
+	b.WriteString("  " + theme.RenderLabel("Attacks Made", fmt.Sprintf("%d (%d hit)", s.AttacksMade, s.AttacksHit)) + "\n")
+	if s.AttacksMade > 0 {
+		b.WriteString("  " + theme.RenderLabel("Hit Rate", fmt.Sprintf("%s (expected %s)",
+			percent(s.HitRate()), percent(s.ExpectedHitRate()))) + "\n")
+	}
+	b.WriteString("\n")
+
+	// Life points
+	b.WriteString(t.Heading.Render("  Life Points") + "\n")
+	b.WriteString(theme.RenderSeparator(50) + "\n")
+	b.WriteString(fmt.Sprintf("  %s  %s\n",
+		theme.RenderLabel("Damage Dealt", fmt.Sprintf("%d", s.DamageDealt)),
+		theme.RenderLabel("Damage Taken", fmt.Sprintf("%d", s.DamageTaken))))
+	b.WriteString("  " + theme.RenderLabel("LP Healed", fmt.Sprintf("%d", s.TotalHealed())) + "\n")
+	for _, source := range []string{character.HealSourceHealingStone, character.HealSourceDoombringer} {
+		if lp := s.LPHealed[source]; lp > 0 {
+			b.WriteString(fmt.Sprintf("    %-16s %d\n", source, lp))
+		}
+	}
+	if s.BloodPricePaid > 0 {
+		b.WriteString("  " + theme.RenderLabel("Blood Price Paid", fmt.Sprintf("%d", s.BloodPricePaid)) + "\n")
+	}
+	b.WriteString("\n")
+
+	// Death saves
+	b.WriteString(t.Heading.Render("  Death Saves") + "\n")
+	b.WriteString(theme.RenderSeparator(50) + "\n")
+	b.WriteString("  " + theme.RenderLabel("Used", fmt.Sprintf("%d (%d passed)", s.DeathSavesUsed, s.DeathSavesPassed)) + "\n")
+	b.WriteString("\n")
+
+	// Spells
+	b.WriteString(t.Heading.Render("  Spells") + "\n")
+	b.WriteString(theme.RenderSeparator(50) + "\n")
+	if len(s.Spells) == 0 {
+		b.WriteString("  " + t.MutedText.Render("No spells cast yet") + "\n")
+	} else {
+		b.WriteString(t.MutedText.Render(fmt.Sprintf("  %-14s %5s %9s %8s", "Spell", "Cast", "Success", "POW")) + "\n")
+		for _, name := range s.SpellNames() {
+			b.WriteString("  " + spellRow(name, s.Spells[name]) + "\n")
+		}
+		b.WriteString("  " + t.Emphasis.Render(spellRow("Total", s.SpellTotals())) + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(theme.RenderKeyHelp("Esc Back"))
+	return b.String()
+}
+
+// spellRow renders a spell's casts, success rate and POW spent.
+func spellRow(name string, spell character.SpellStatistics) string {
+	rate := "-"
+	if spell.Cast > 0 {
+		rate = percent(float64(spell.Succeeded) / float64(spell.Cast))
+	}
+	return fmt.Sprintf("%-14s %5d %9s %8d", name, spell.Cast, rate, spell.POWSpent)
+}
+
+// percent renders a share from 0 to 1 as a percentage, e.g. "58%".
+func percent(share float64) string {
+	return fmt.Sprintf("%.0f%%", share*100)
+}
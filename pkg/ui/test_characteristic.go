@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benoit/saga-demonspawn/internal/character"
+	"github.com/benoit/saga-demonspawn/internal/dice"
+	"github.com/benoit/saga-demonspawn/pkg/ui/theme"
+)
+
+// testLogVisible is how many recent tests are listed.
+const testLogVisible = 8
+
+// TestCharacteristicModel handles the "Test Characteristic" screen: pick a
+// characteristic and a formula, roll, and log the result on the character.
+type TestCharacteristicModel struct {
+	character *character.Character
+	dice      dice.Roller
+	cursor    int // Index into character.Characteristics
+	formula   int // Index into character.TestFormulas
+	message   string
+	isError   bool
+}
+
+// NewTestCharacteristicModel creates a new characteristic test model.
+func NewTestCharacteristicModel(roller dice.Roller) TestCharacteristicModel {
+	return TestCharacteristicModel{
+		dice: roller,
+	}
+}
+
+// Reset prepares the screen for the given character, keeping the choices.
+func (m *TestCharacteristicModel) Reset(char *character.Character) {
+	m.character = char
+	m.message = ""
+	m.isError = false
+}
+
+// MoveUp selects the previous characteristic.
+func (m *TestCharacteristicModel) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// MoveDown selects the next characteristic.
+func (m *TestCharacteristicModel) MoveDown() {
+	if m.cursor < len(character.Characteristics)-1 {
+		m.cursor++
+	}
+}
+
+// NextFormula switches to the next test formula.
+func (m *TestCharacteristicModel) NextFormula() {
+	m.formula = (m.formula + 1) % len(character.TestFormulas)
+}
+
+// Roll tests the selected characteristic and logs the result.
+func (m *TestCharacteristicModel) Roll() {
+	if m.character == nil {
+		m.message, m.isError = "No character loaded", true
+		return
+	}
+
+	abbrev := character.Characteristics[m.cursor]
+	dice.Label(m.dice, dice.LabelCharacteristicTest+": "+abbrev)
+	result, err := m.character.TestCharacteristic(abbrev, character.TestFormulas[m.formula], m.dice.Roll2D6())
+	if err != nil {
+		m.message, m.isError = err.Error(), true
+		return
+	}
+
+	outcome := "failed"
+	if result.Passed {
+		outcome = "passed"
+	}
+	m.message = fmt.Sprintf("%s test %s: rolled %d × %d = %d against %d",
+		character.CharacteristicName(abbrev), outcome, result.Dice, result.Multiplier, result.Roll, result.Value)
+	m.isError = !result.Passed
+}
+
+// View renders the characteristic test screen.
+func (m TestCharacteristicModel) View() string {
+	var b strings.Builder
+	t := theme.Current()
+
+	b.WriteString("\n")
+	b.WriteString(theme.RenderTitle("TEST CHARACTERISTIC"))
+	b.WriteString("\n\n")
+
+	if m.character == nil {
+		return b.String() + "No character loaded"
+	}
+
+	for i, abbrev := range character.Characteristics {
+		value, _ := m.character.Characteristic(abbrev)
+		label := fmt.Sprintf("%-10s (%s)  %3d", character.CharacteristicName(abbrev), abbrev, value)
+		b.WriteString("  " + theme.RenderMenuItem(label, i == m.cursor) + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString("  " + theme.RenderLabel("Formula", character.TestFormulas[m.formula].Name) + "\n\n")
+
+	if m.message != "" {
+		if m.isError {
+			b.WriteString("  " + t.WarningMsg.Render(m.message) + "\n\n")
+		} else {
+			b.WriteString("  " + theme.RenderSuccess(m.message) + "\n\n")
+		}
+	}
+
+	b.WriteString("  " + t.Heading.Render("TEST LOG") + "\n")
+	log := m.character.TestLog
+	if len(log) == 0 {
+		b.WriteString("  " + t.MutedText.Render("No tests yet.") + "\n")
+	}
+	for i := len(log) - 1; i >= 0 && i >= len(log)-testLogVisible; i-- {
+		entry := log[i]
+		line := entry.String()
+		if entry.Section > 0 {
+			line = fmt.Sprintf("§%-4d %s", entry.Section, line)
+		}
+		b.WriteString("  " + t.Body.Render(line) + "\n")
+	}
+	if len(log) > testLogVisible {
+		b.WriteString("  " + t.MutedText.Render(fmt.Sprintf("… and %d earlier tests", len(log)-testLogVisible)) + "\n")
+	}
+	b.WriteString("\n")
+
+	b.WriteString(theme.RenderKeyHelp("↑/↓ Characteristic", "f Formula", "Enter Roll", "Esc Back", "? Help"))
+	return b.String()
+}
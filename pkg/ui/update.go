@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/benoit/saga-demonspawn/internal/character"
@@ -14,12 +15,30 @@ import (
 // Init initializes the Bubble Tea application.
 // This is called once when the program starts.
 func (m Model) Init() tea.Cmd {
-	return nil
+	return m.autosaveTick()
 }
 
 // Update handles incoming messages and updates the model state.
 // This is the core of the Elm Architecture pattern.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var next tea.Model
+	var cmd tea.Cmd
+	if m.ManualDice != nil && m.ManualDice.Enabled() {
+		next, cmd = m.updateWithPhysicalDice(msg)
+	} else {
+		next, cmd = m.update(msg)
+	}
+
+	// Save in the background after key events such as a fight ending
+	updated, ok := next.(Model)
+	if !ok || !updated.AutosaveDue || updated.Autosaving {
+		return next, cmd
+	}
+	return updated, tea.Batch(cmd, updated.startAutosave())
+}
+
+// update applies a message to the model.
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.Width = msg.Width
@@ -33,16 +52,52 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case CastSpellMsg:
 		if m.CurrentScreen == ScreenCombat {
 			// Switch to spell casting screen in combat mode
-			m.SpellCasting = NewSpellCastingModel(m.Character, m.Dice, true)
+			m.SpellCasting = NewSpellCastingModel(m.Character, m.Rules, m.Dice, true)
 			m.CurrentScreen = ScreenMagic
 			return m, nil
 		}
 	
+	case OddsResultMsg:
+		m.CombatSetup.SetOdds(msg)
+		return m, nil
+
+	case AutosaveTickMsg:
+		if msg.Generation != m.AutosaveGeneration {
+			return m, nil
+		}
+		return m, tea.Batch(m.startAutosave(), m.autosaveTick())
+
+	case AutosaveDoneMsg:
+		return m, m.finishAutosave(msg)
+
+	case SaveEnemyMsg:
+		if err := m.SaveEnemyToBestiary(); err != nil {
+			m.Status = fmt.Sprintf("Could not save enemy: %v", err)
+		} else {
+			m.Status = fmt.Sprintf("%s saved to the bestiary", combat.EnemyNames(m.CombatState.Opponents))
+		}
+		return m, nil
+
+	case ExportCombatMsg:
+		path, err := combat.WriteExport(m.CombatState, m.SaveDirectory(), msg.Format, time.Now())
+		if err != nil {
+			m.Status = fmt.Sprintf("Could not export combat log: %v", err)
+		} else {
+			m.Status = "Combat log exported to " + path
+		}
+		return m, nil
+
 	case CombatEndMsg:
+		m.FinishCombat()
+		m.RequestAutosave()
 		if msg.Victory {
 			m.CurrentScreen = ScreenGameSession
 			m.CombatState = nil
 		} else {
+			// Keep the fatal fight so RESURRECTION can resume it
+			if m.Character != nil && m.Character.CurrentLP <= 0 {
+				m.FatalCombat = m.CombatState
+			}
 			m.CurrentScreen = ScreenGameSession
 			m.CombatState = nil
 		}
@@ -60,6 +115,115 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateWithPhysicalDice applies a message when the player rolls real dice.
+// The message is played as an attempt: if it needs dice that have not been
+// typed in yet, the character and fight are put back as they were and the
+// dice modal opens. Once the dice are in, the message is played again.
+func (m Model) updateWithPhysicalDice(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.EnteringDice {
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			return m.handleDiceEntryKeys(msg)
+		case tea.WindowSizeMsg, AutosaveTickMsg, AutosaveDoneMsg, OddsResultMsg:
+			// These roll no dice, so they go on while the dice are typed in
+			return m.update(msg)
+		}
+		// Anything else may roll dice: it is played once the modal closes
+		m.DeferredMsgs = append(m.DeferredMsgs, msg)
+		return m, nil
+	}
+
+	restore := m.snapshotRollState()
+	m.ManualDice.Begin()
+	next, cmd := m.update(msg)
+	request := m.ManualDice.Pending()
+	if request == nil {
+		m.ManualDice.Finish()
+		return next, cmd
+	}
+
+	restore()
+	m.EnteringDice = true
+	m.DiceEntry = NewDiceEntryModel(*request, msg)
+	return m, nil
+}
+
+// snapshotRollState saves the state an action may change through its
+// pointers and returns a function putting it back.
+func (m Model) snapshotRollState() func() {
+	restores := []func(){}
+	if m.Character != nil {
+		char, saved := m.Character, m.Character.Clone()
+		restores = append(restores, func() { *char = *saved })
+	}
+	if m.CombatState != nil {
+		cs, saved := m.CombatState, m.CombatState.Clone()
+		enemies := make([]*combat.Enemy, len(cs.Opponents))
+		for i, opponent := range cs.Opponents {
+			enemies[i] = opponent.Enemy
+		}
+		restores = append(restores, func() {
+			// Put the enemies back in place so every reference stays valid
+			for i, opponent := range saved.Opponents {
+				*enemies[i] = *opponent.Enemy
+				opponent.Enemy = enemies[i]
+			}
+			if saved.Enemy != nil {
+				saved.Enemy = saved.Opponents[saved.Target].Enemy
+			}
+			*cs = *saved
+		})
+	}
+	return func() {
+		for _, restore := range restores {
+			restore()
+		}
+	}
+}
+
+// handleDiceEntryKeys processes key presses in the physical dice modal.
+func (m Model) handleDiceEntryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m.handleKeyPress(msg)
+	case "esc":
+		m.EnteringDice = false
+		m.ManualDice.Cancel()
+		m.Status = "Roll cancelled"
+		return m, m.replayDeferred()
+	case "backspace":
+		m.DiceEntry.RemoveDie()
+	case "enter":
+		if !m.DiceEntry.Complete() {
+			return m, nil
+		}
+		if err := m.ManualDice.Enter(m.DiceEntry.dice); err != nil {
+			m.DiceEntry.err = err.Error()
+			return m, nil
+		}
+		m.EnteringDice = false
+		next, cmd := m.updateWithPhysicalDice(m.DiceEntry.action)
+		if updated, ok := next.(Model); ok && !updated.EnteringDice {
+			return updated, tea.Sequence(cmd, updated.replayDeferred())
+		}
+		return next, cmd
+	default:
+		m.DiceEntry.AddDie(msg.String())
+	}
+	return m, nil
+}
+
+// replayDeferred returns a command sending again, in order, the messages
+// held back while the dice were typed in.
+func (m *Model) replayDeferred() tea.Cmd {
+	cmds := make([]tea.Cmd, len(m.DeferredMsgs))
+	for i, msg := range m.DeferredMsgs {
+		cmds[i] = func() tea.Msg { return msg }
+	}
+	m.DeferredMsgs = nil
+	return tea.Sequence(cmds...)
+}
+
 // handleKeyPress routes key presses to the appropriate screen handler.
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Global quit keys
@@ -71,6 +235,9 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, tea.Quit
 	}
 
+	// Status notices only last until the next key press
+	m.Status = ""
+
 	// Handle help modal if showing
 	if m.ShowingHelp {
 		return m.handleHelpModalKeys(msg)
@@ -124,6 +291,20 @@ func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleSettingsKeys(msg)
 	case ScreenDiceRoll:
 		return m.handleDiceRollKeys(msg)
+	case ScreenSection:
+		return m.handleSectionKeys(msg)
+	case ScreenResurrection:
+		return m.handleResurrectionKeys(msg)
+	case ScreenTestCharacteristic:
+		return m.handleTestCharacteristicKeys(msg)
+	case ScreenSaveAs:
+		return m.handleSaveAsKeys(msg)
+	case ScreenRestoreBackup:
+		return m.handleRestoreBackupKeys(msg)
+	case ScreenStatistics:
+		return m.handleStatisticsKeys(msg)
+	case ScreenEncounterHistory:
+		return m.handleEncounterHistoryKeys(msg)
 	default:
 		return m, nil
 	}
@@ -177,7 +358,25 @@ func (m Model) handleMainMenuKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleLoadCharacterKeys processes key presses on the load character screen.
 func (m Model) handleLoadCharacterKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.LoadChar.IsRenaming() {
+		switch msg.String() {
+		case "enter":
+			m.LoadChar.ConfirmRename()
+		case "esc":
+			m.LoadChar.CancelRename()
+		case "backspace":
+			m.LoadChar.Backspace()
+		default:
+			if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+				m.LoadChar.AppendInput(string(msg.Runes))
+			}
+		}
+		return m, nil
+	}
+
 	switch msg.String() {
+	case "r":
+		m.LoadChar.StartRename()
 	case "up", "k":
 		m.LoadChar.MoveUp()
 	case "down", "j":
@@ -189,6 +388,17 @@ func (m Model) handleLoadCharacterKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			char, err := character.Load(filename)
 			if err != nil {
 				m.Err = err
+				m.LoadChar.SetError(err)
+				return m, nil
+			}
+
+			// Another instance of saga may have the slot open
+			previous := m.Character
+			m.Character = char
+			if err := m.LockSlot(); err != nil {
+				m.Character = previous
+				m.Err = err
+				m.LoadChar.SetError(err)
 				return m, nil
 			}
 			m.LoadCharacter(char)
@@ -277,6 +487,18 @@ func (m Model) handleReviewCharacterKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleGameSessionKeys processes key presses on the game session menu.
 func (m Model) handleGameSessionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// A fight the character was saved in is offered before the menu
+	if m.SavedCombat != nil {
+		switch msg.String() {
+		case "y", "enter":
+			m.ResumeSavedCombat()
+		case "n", "esc":
+			m.AbandonSavedCombat()
+			m.Status = "Saved fight abandoned"
+		}
+		return m, nil
+	}
+
 	switch msg.String() {
 	case "up", "k":
 		m.GameSession.MoveUp()
@@ -289,34 +511,61 @@ func (m Model) handleGameSessionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.CurrentScreen = ScreenCharacterView
 		case "Edit Character Stats":
 			m.CurrentScreen = ScreenCharacterEdit
+		case "Go to Section...":
+			m.SectionNav.Reset(m.Character)
+			m.CurrentScreen = ScreenSection
 		case "Combat":
 			// Start combat setup
 			m.CombatSetup.Reset()
+			if err := m.LoadBestiary(); err != nil {
+				m.Err = err
+				m.Status = fmt.Sprintf("Bestiary unavailable: %v", err)
+			}
+			m.CombatSetup.SetBestiary(m.Bestiary, m.SaveDirectory())
 			m.CurrentScreen = ScreenCombatSetup
 		case "Cast Spell":
 			// Initialize spell casting screen
-			m.SpellCasting = NewSpellCastingModel(m.Character, m.Dice, false)
+			m.SpellCasting = NewSpellCastingModel(m.Character, m.Rules, m.Dice, false)
 			m.CurrentScreen = ScreenMagic
 		case "Manage Inventory":
 			// Initialize inventory with current character
 			m.Inventory = NewInventoryManagementModel(m.Character, false)
 			m.CurrentScreen = ScreenInventory
 		case "Roll Dice":
-			m.DiceRoll.Reset()
+			m.DiceRoll.Reset(m.Character)
 			m.CurrentScreen = ScreenDiceRoll
+		case "Test Characteristic":
+			m.TestChar.Reset(m.Character)
+			m.CurrentScreen = ScreenTestCharacteristic
+		case "Statistics":
+			m.Statistics.Reset(m.Character)
+			m.CurrentScreen = ScreenStatistics
+		case "Encounter History":
+			m.Encounters.Reset(m.Character)
+			m.CurrentScreen = ScreenEncounterHistory
+		case "Save As...":
+			m.SaveAs.Reset(m.Character, m.SaveDirectory())
+			m.CurrentScreen = ScreenSaveAs
+		case "Restore Backup...":
+			m.RestoreBackup.Reset(m.Character, m.SaveDirectory())
+			m.CurrentScreen = ScreenRestoreBackup
 		case "Save & Exit":
 			if err := m.SaveCharacter(); err != nil {
 				m.Err = err
+				m.Status = fmt.Sprintf("Could not save: %v", err)
 				return m, nil
 			}
+			m.ReleaseSlot()
 			m.CurrentScreen = ScreenMainMenu
 		}
 	case "q", "esc":
 		// Save and return to main menu
 		if err := m.SaveCharacter(); err != nil {
 			m.Err = err
+			m.Status = fmt.Sprintf("Could not save: %v", err)
 			return m, nil
 		}
+		m.ReleaseSlot()
 		m.CurrentScreen = ScreenMainMenu
 	}
 	return m, nil
@@ -465,30 +714,39 @@ func (m *Model) applyCharacterEdit() {
 
 // handleCombatSetupKeys processes key presses on the combat setup screen.
 func (m Model) handleCombatSetupKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Esc while typing or picking belongs to the setup screen
+	busy := m.CombatSetup.IsBusy()
+
 	var cmd tea.Cmd
 	m.CombatSetup, cmd = m.CombatSetup.Update(msg)
 	
 	// Handle combat start message
 	if cmd != nil {
 		returnedMsg := cmd()
+		if _, ok := returnedMsg.(SimulateOddsMsg); ok {
+			enemies, err := m.CombatSetup.BuildEncounter()
+			if err != nil || m.Character == nil {
+				m.CombatSetup.SetOdds(OddsResultMsg{Err: fmt.Errorf("nothing to simulate")})
+				return m, nil
+			}
+			return m, simulateOdds(m.Character, enemies, m.Rules)
+		}
 		if _, ok := returnedMsg.(CombatStartMsg); ok {
-			// Start combat - create enemy and initialize combat state
-			name, str, spd, sta, crg, lck, skill, currentLP, maxLP, weaponBonus, armorProtection, isDemonspawn := m.CombatSetup.GetEnemyData()
-			enemy, err := combat.NewEnemy(name, str, spd, sta, crg, lck, skill, currentLP, maxLP, weaponBonus, armorProtection, isDemonspawn)
+			// Start combat - create the enemies and initialize combat state
+			enemies, err := m.CombatSetup.BuildEncounter()
 			if err != nil {
 				m.Err = err
 				return m, nil
 			}
 			
 			// Initialize combat
-			m.CombatState = combat.StartCombat(m.Character, enemy, m.Dice)
-			m.CombatState.AddLogEntry(fmt.Sprintf("Combat begins against %s!", enemy.Name))
-			m.CombatState.AddLogEntry(fmt.Sprintf("[Initiative] Player: %d, Enemy: %d", m.CombatState.PlayerInitiative, m.CombatState.EnemyInitiative))
+			m.CombatState = combat.StartEncounterWithRules(m.Character, enemies, m.Rules, m.Dice)
+			m.FatalCombat = nil
+			m.CombatState.LogStart(m.Character)
 			
-			if m.CombatState.PlayerFirstStrike {
-				m.CombatState.AddLogEntry("You strike first!")
-			} else {
-				m.CombatState.AddLogEntry("Enemy strikes first!")
+			// The first fight of a section is where TIMEWARP returns to
+			if m.SectionCombat == nil {
+				m.SectionCombat = m.CombatState.Clone()
 			}
 			
 			m.CombatView = NewCombatViewModel(m.Character, m.CombatState, m.Dice)
@@ -499,7 +757,7 @@ func (m Model) handleCombatSetupKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	
 	switch msg.String() {
 	case "esc":
-		if !m.CombatSetup.inputMode {
+		if !busy {
 			m.CurrentScreen = ScreenGameSession
 		}
 	}
@@ -513,6 +771,7 @@ func (m Model) handleCombatKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "esc":
 		// Only allow escape back to menu during player turn when waiting for input
 		if m.CombatView.waitingForInput && m.CombatState != nil && m.CombatState.PlayerTurn {
+			m.CombatState.TallyStatistics(m.Character)
 			m.CurrentScreen = ScreenGameSession
 			m.CombatState = nil
 			return m, nil
@@ -540,7 +799,9 @@ func (m Model) handleInventoryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.Inventory.HandleUse()
 	case "a":
 		// Acquire special items (for testing/when finding items)
-		m.Inventory.HandleAcquire()
+		if m.Inventory.HandleAcquire() {
+			m.RequestAutosave()
+		}
 		// Rebuild to reflect changes
 		m.CharView.SetCharacter(m.Character)
 	case "r":
@@ -556,7 +817,7 @@ func (m Model) handleInventoryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if item.SpecialItem != "" {
 				switch item.SpecialItem {
 				case "healing_stone":
-					m.Inventory.message = "Healing Stone: Use during combat to restore 1d6×10 LP. Recharge with 'R' when gamebook allows."
+					m.Inventory.message = fmt.Sprintf("Healing Stone: Use during combat to restore %s LP. Recharge with 'R' when gamebook allows.", m.Rules.HealingStoneFormula())
 				case "doombringer":
 					m.Inventory.message = "Doombringer: +20 damage, -10 LP per attack, heal LP equal to damage dealt on hit."
 				case "orb":
@@ -583,11 +844,7 @@ func (m Model) handleMagicKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// Confirm sacrifice
 			if m.SpellCasting.ConfirmSacrifice() {
 				// Now proceed with cast
-				effect, success := m.SpellCasting.PerformCast()
-				if success {
-					m.handleSpellEffect(effect)
-				}
-				m.SpellCasting.SetCharacter(m.Character)
+				m.castSpell()
 			}
 		case "n", "N", "esc":
 			// Cancel sacrifice
@@ -596,6 +853,25 @@ func (m Model) handleMagicKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Handle RETRACE destination picker
+	if m.SpellCasting.IsPickingSection() {
+		switch msg.String() {
+		case "up", "k":
+			m.SpellCasting.MoveSectionCursorUp()
+		case "down", "j":
+			m.SpellCasting.MoveSectionCursorDown()
+		case "enter":
+			m.SpellCasting.ConfirmRetraceTarget()
+			// Resume the cast now that a destination is known
+			if m.SpellCasting.AttemptCast() {
+				m.castSpell()
+			}
+		case "esc":
+			m.SpellCasting.CancelRetracePicker()
+		}
+		return m, nil
+	}
+
 	// Normal spell selection
 	switch msg.String() {
 	case "up", "k":
@@ -610,11 +886,7 @@ func (m Model) handleMagicKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// Attempt to cast spell
 			if m.SpellCasting.AttemptCast() {
 				// Cast validated, perform the cast
-				effect, success := m.SpellCasting.PerformCast()
-				if success {
-					m.handleSpellEffect(effect)
-				}
-				m.SpellCasting.SetCharacter(m.Character)
+				m.castSpell()
 			}
 		}
 	case "esc", "q":
@@ -628,48 +900,105 @@ func (m Model) handleMagicKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// handleSpellEffect applies the spell effect to the game state.
-func (m *Model) handleSpellEffect(effect magic.SpellEffect) {
+// castSpell performs the validated cast and applies its effect. A cast
+// during a fight is recorded in the combat log, whether it works or not.
+func (m *Model) castSpell() {
+	effect, success := m.SpellCasting.PerformCast()
+	spell, cast := m.SpellCasting.LastCast()
+	if m.CombatState != nil && m.SpellCasting.returnToCombat {
+		m.CombatState.LogSpell(spell, cast.Roll, cast.PowerSpent, cast.Success)
+	}
+	if success {
+		m.handleSpellEffect(spell, effect)
+	}
+	m.SpellCasting.SetCharacter(m.Character)
+}
+
+// handleSpellEffect applies the effect of the given spell to the game state.
+func (m *Model) handleSpellEffect(spell string, effect magic.SpellEffect) {
+	m.RequestAutosave()
+
 	// Handle combat effects
 	if effect.CombatEnded && m.CombatState != nil {
-		if effect.Victory {
-			m.CombatState.AddLogEntry("Combat ended via magic (victory)!")
-		} else {
-			m.CombatState.AddLogEntry("Combat ended via magic (escape)!")
-		}
+		m.CombatState.LogSpellEnd(spell)
+		m.FinishCombat()
 		m.CurrentScreen = ScreenGameSession
 		m.CombatState = nil
 	}
 
-	// Handle enemy damage
-	if effect.DamageDealt > 0 && m.CombatState != nil {
-		m.CombatState.Enemy.CurrentLP -= effect.DamageDealt
-		m.CombatState.AddLogEntry(fmt.Sprintf("Spell deals %d damage to %s!", effect.DamageDealt, m.CombatState.Enemy.Name))
-		if m.CombatState.Enemy.CurrentLP <= 0 {
-			m.CombatState.AddLogEntry(fmt.Sprintf("%s is defeated!", m.CombatState.Enemy.Name))
+	// Handle enemy damage, or the enemy killed outright
+	if (effect.DamageDealt > 0 || effect.EnemyKilled) && m.CombatState != nil {
+		damage := effect.DamageDealt
+		if effect.EnemyKilled {
+			damage = m.CombatState.Enemy.CurrentLP
+		}
+		if m.CombatState.ApplySpellDamage(spell, damage) {
+			// The last enemy fell: show the victory screen
+			combat.ResolveEncounterVictory(m.Character, m.CombatState)
+			m.CombatState.LogVictory(m.Character)
+			m.CombatView.victoryState = true
+			m.CurrentScreen = ScreenCombat
 		}
-	}
-
-	// Handle enemy killed
-	if effect.EnemyKilled && m.CombatState != nil {
-		m.CombatState.Enemy.CurrentLP = 0
-		m.CombatState.AddLogEntry(fmt.Sprintf("%s is killed by magic!", m.CombatState.Enemy.Name))
 	}
 
 	// Handle navigation
 	if effect.NavigateTo != "" {
-		// For now, just show message (actual navigation would require section system)
 		// CRYPT: restore POW to max
 		if effect.NavigateTo == "CRYPT" {
 			m.Character.SetPOW(m.Character.MaximumPOW)
 		}
+		// RETRACE: move back to a previously visited section
+		if effect.TargetSection > 0 {
+			if _, err := m.EnterSection(effect.TargetSection); err != nil {
+				m.Err = err
+			}
+		}
+	}
+
+	// Handle TIMEWARP and RESURRECTION (back to the start of the section)
+	if effect.RewindSection {
+		if err := m.RewindSection(); err != nil {
+			m.Err = err
+			return
+		}
+		if m.CombatState != nil {
+			m.SpellCasting.returnToCombat = true
+		}
 	}
 
 	// Handle RESURRECTION (requires stat reroll)
 	if effect.RequiresReroll {
-		// For now, just restore LP (full implementation would reroll all stats)
-		m.Character.SetLP(m.Character.MaximumLP)
+		m.Resurrection.Reset(m.Character)
+		m.CurrentScreen = ScreenResurrection
+	}
+}
+
+// handleResurrectionKeys processes key presses on the resurrection screen.
+// There is no way back: the characteristics must be rerolled.
+func (m Model) handleResurrectionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "r":
+		m.Resurrection.RollAll()
+	case "enter":
+		if !m.Resurrection.AreAllRolled() {
+			return m, nil
+		}
+		if err := m.Resurrection.Apply(); err != nil {
+			m.Err = err
+			return m, nil
+		}
+		m.CharView.SetCharacter(m.Character)
+		m.CharEdit.SetCharacter(m.Character)
+		if m.CombatState != nil {
+			// Back into the fight that killed Fire*Wolf
+			combat.ResumeCombat(m.Character, m.CombatState)
+			m.CombatView = NewCombatViewModel(m.Character, m.CombatState, m.Dice)
+			m.CurrentScreen = ScreenCombat
+		} else {
+			m.CurrentScreen = ScreenGameSession
+		}
 	}
+	return m, nil
 }
 
 // handleHelpModalKeys processes key presses when help modal is shown.
@@ -706,16 +1035,33 @@ func (m Model) handleSettingsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				scheme = theme.ColorSchemeLight
 			}
 			theme.Init(scheme, cfg.UseUnicode)
+		case SettingBackupCount:
+			m.Settings.CycleBackupCount()
+		case SettingBackupMaxAge:
+			m.Settings.CycleBackupMaxAge()
+		case SettingAutoSaveInterval:
+			m.Settings.CycleAutoSaveInterval()
+		case SettingRuleset:
+			m.Settings.CycleRuleset()
 		case SettingSave:
 			if err := m.Settings.Save(); err == nil {
+				interval := m.Config.AutoSaveInterval()
 				// Update main config
 				*m.Config = *m.Settings.GetConfig()
+				m.ManualDice.SetEnabled(m.Config.ManualDice)
+				if err := m.LoadRuleset(); err != nil {
+					m.Status = fmt.Sprintf("Ruleset unavailable, playing by the %s rules: %v", m.Rules.Name, err)
+				}
 				// Reinitialize theme
 				scheme := theme.ColorSchemeDark
 				if m.Config.Theme == "light" {
 					scheme = theme.ColorSchemeLight
 				}
 				theme.Init(scheme, m.Config.UseUnicode)
+				// Restart the autosave timer on the new interval
+				if m.Config.AutoSaveInterval() != interval {
+					return m, m.RestartAutosave()
+				}
 			}
 		case SettingCancel:
 			m.Settings.Cancel()
@@ -735,13 +1081,140 @@ func (m Model) handleSettingsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // handleDiceRollKeys processes key presses on the dice roll screen.
 func (m Model) handleDiceRollKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
-	case "1":
-		m.DiceRoll.Roll1D6()
-	case "2":
-		m.DiceRoll.Roll2D6()
+	case "enter":
+		m.DiceRoll.Roll()
+	case "up":
+		m.DiceRoll.ScrollUp()
+	case "down":
+		m.DiceRoll.ScrollDown()
+	case "backspace":
+		m.DiceRoll.Backspace()
+	case "esc":
+		m.CurrentScreen = ScreenGameSession
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			m.DiceRoll.AppendInput(string(msg.Runes))
+		}
+	}
+	return m, nil
+}
+
+// handleTestCharacteristicKeys processes key presses on the characteristic
+// test screen.
+func (m Model) handleTestCharacteristicKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		m.TestChar.MoveUp()
+	case "down", "j":
+		m.TestChar.MoveDown()
+	case "f", "tab", "left", "right":
+		m.TestChar.NextFormula()
+	case "enter":
+		m.TestChar.Roll()
+	case "esc", "q":
+		m.CurrentScreen = ScreenGameSession
+	}
+	return m, nil
+}
+
+// handleSaveAsKeys processes key presses on the save-as screen.
+func (m Model) handleSaveAsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		if m.SaveAs.Save() {
+			m.Status = fmt.Sprintf("Saved to slot %q", m.SaveAs.SlotName())
+			if err := m.LockSlot(); err != nil {
+				m.Status = fmt.Sprintf("Saved to slot %q, but it could not be locked: %v", m.SaveAs.SlotName(), err)
+			}
+			m.CurrentScreen = ScreenGameSession
+		}
+	case "backspace":
+		m.SaveAs.Backspace()
+	case "esc":
+		m.CurrentScreen = ScreenGameSession
+	default:
+		if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+			m.SaveAs.AppendInput(string(msg.Runes))
+		}
+	}
+	return m, nil
+}
+
+// handleRestoreBackupKeys processes key presses on the restore backup screen.
+func (m Model) handleRestoreBackupKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		m.RestoreBackup.MoveUp()
+	case "down", "j":
+		m.RestoreBackup.MoveDown()
+	case "enter":
+		backup := m.RestoreBackup.Selected()
+		if restored := m.RestoreBackup.Restore(m.BackupPolicy()); restored != nil {
+			m.LoadCharacter(restored)
+			m.GameSession.UpdateMagicVisibility(restored.MagicUnlocked)
+			m.Status = fmt.Sprintf("Restored the backup from %s", backup.Time.Format("2006-01-02 15:04:05"))
+		}
 	case "esc", "q":
 		m.CurrentScreen = ScreenGameSession
 	}
 	return m, nil
 }
 
+// handleStatisticsKeys processes key presses on the statistics screen.
+func (m Model) handleStatisticsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "b", "esc", "q":
+		m.CurrentScreen = ScreenGameSession
+	}
+	return m, nil
+}
+
+// handleEncounterHistoryKeys processes key presses on the encounter
+// history screen.
+func (m Model) handleEncounterHistoryKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		m.Encounters.MoveUp()
+	case "down", "j":
+		m.Encounters.MoveDown()
+	case "enter":
+		m.Encounters.Open()
+	case "b", "esc", "q":
+		if !m.Encounters.Back() {
+			m.CurrentScreen = ScreenGameSession
+		}
+	}
+	return m, nil
+}
+
+// handleSectionKeys processes key presses on the section navigation screen.
+func (m Model) handleSectionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		section, err := m.SectionNav.ParseInput()
+		if err != nil {
+			m.SectionNav.SetResult(err.Error(), true)
+			return m, nil
+		}
+		transition, err := m.EnterSection(section)
+		if err != nil {
+			m.SectionNav.SetResult(err.Error(), true)
+			return m, nil
+		}
+		if transition.FirstVisit {
+			m.SectionNav.SetResult(fmt.Sprintf("Entered section %d for the first time.", section), false)
+		} else {
+			m.SectionNav.SetResult(fmt.Sprintf("Returned to section %d.", section), false)
+		}
+	case "backspace":
+		m.SectionNav.Backspace()
+	case "esc", "q":
+		m.CurrentScreen = ScreenGameSession
+	default:
+		// Append numeric input
+		if len(msg.String()) == 1 && msg.String()[0] >= '0' && msg.String()[0] <= '9' {
+			m.SectionNav.AppendInput(msg.String())
+		}
+	}
+	return m, nil
+}
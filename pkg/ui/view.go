@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/benoit/saga-demonspawn/internal/combat"
 	"github.com/benoit/saga-demonspawn/internal/help"
+	"github.com/benoit/saga-demonspawn/internal/rules"
 	"github.com/benoit/saga-demonspawn/pkg/ui/theme"
 )
 
@@ -47,18 +49,65 @@ func (m Model) View() string {
 		content = m.viewSettings()
 	case ScreenDiceRoll:
 		content = m.DiceRoll.View()
+	case ScreenSection:
+		content = m.SectionNav.View()
+	case ScreenResurrection:
+		content = m.Resurrection.View()
+	case ScreenTestCharacteristic:
+		content = m.TestChar.View()
+	case ScreenSaveAs:
+		content = m.SaveAs.View()
+	case ScreenRestoreBackup:
+		content = m.RestoreBackup.View()
+	case ScreenStatistics:
+		content = m.Statistics.View()
+	case ScreenEncounterHistory:
+		content = m.Encounters.View()
 	default:
 		content = "Unknown screen"
 	}
 
+	// Status line for notices such as section rules
+	if m.Status != "" {
+		content += "\n" + theme.Current().MutedText.Render("  "+m.Status)
+	}
+
+	// Background autosave indicator
+	if indicator := m.autosaveIndicator(); indicator != "" {
+		content += "\n" + indicator
+	}
+
 	// Overlay help modal if showing
 	if m.ShowingHelp {
 		content = m.renderHelpOverlay(content)
 	}
 
+	// Overlay the physical dice modal if waiting for dice
+	if m.EnteringDice {
+		content += "\n\n" + m.DiceEntry.View()
+	}
+
 	return content
 }
 
+// autosaveIndicator renders the state of background saves while a
+// character is open, or "" before the first one.
+func (m Model) autosaveIndicator() string {
+	if !m.canAutosave() {
+		return ""
+	}
+	t := theme.Current()
+	switch {
+	case m.Autosaving:
+		return t.MutedText.Render("  Autosaving...")
+	case m.AutosaveErr != nil:
+		return t.WarningMsg.Render(fmt.Sprintf("  Autosave failed: %v", m.AutosaveErr))
+	case !m.LastAutosave.IsZero():
+		return t.MutedText.Render("  Autosaved at " + m.LastAutosave.Format("15:04:05"))
+	}
+	return ""
+}
+
 // viewMainMenu renders the main menu.
 func (m Model) viewMainMenu() string {
 	var b strings.Builder
@@ -110,20 +159,39 @@ func (m Model) viewLoadCharacter() string {
 		return b.String()
 	}
 
-	files := m.LoadChar.GetFiles()
+	slots := m.LoadChar.GetSlots()
 	cursor := m.LoadChar.GetCursor()
+	t := theme.Current()
 
-	b.WriteString(theme.Current().Body.Render("  Select a character to load:") + "\n\n")
+	b.WriteString(t.Body.Render("  Select a character to load:") + "\n\n")
 
-	for i, file := range files {
+	for i, slot := range slots {
 		selected := i == cursor
-		fileInfo := GetFileInfo(file)
-		text := fmt.Sprintf("%s (%s)", file, fileInfo)
-		b.WriteString("  " + theme.RenderMenuItem(text, selected) + "\n")
+		name := slot.Name
+		if slot.Legacy {
+			name += " (old save)"
+		}
+		if selected && m.LoadChar.IsRenaming() {
+			name = t.Emphasis.Render("[" + m.LoadChar.GetInputBuffer() + "_]")
+		}
+		b.WriteString("  " + theme.RenderMenuItem(name, selected) + "\n")
+		b.WriteString("      " + t.MutedText.Render(GetSlotInfo(slot)) + "\n")
+	}
+
+	if message, isError := m.LoadChar.GetMessage(); message != "" {
+		if isError {
+			b.WriteString("\n  " + t.WarningMsg.Render(message) + "\n")
+		} else {
+			b.WriteString("\n  " + theme.RenderSuccess(message) + "\n")
+		}
 	}
 
 	b.WriteString("\n")
-	b.WriteString(theme.RenderKeyHelp("↑/↓ Select", "Enter Load", "Esc Cancel", "? Help"))
+	if m.LoadChar.IsRenaming() {
+		b.WriteString(theme.RenderKeyHelp("Type New name", "Enter Rename", "Esc Cancel"))
+	} else {
+		b.WriteString(theme.RenderKeyHelp("↑/↓ Select", "Enter Load", "r Rename slot", "Esc Cancel", "? Help"))
+	}
 
 	return b.String()
 }
@@ -140,13 +208,26 @@ func (m Model) viewGameSession() string {
 		// Character status line with health bar
 		b.WriteString("  " + theme.Current().Heading.Render("Fire*Wolf") + "\n")
 		b.WriteString("  " + theme.RenderHealthBar(m.Character.CurrentLP, m.Character.MaximumLP, 30) + "\n")
-		b.WriteString(fmt.Sprintf("  " + theme.RenderLabel("Skill", fmt.Sprintf("%d", m.Character.Skill))))
+		b.WriteString("  " + theme.RenderLabel("Skill", fmt.Sprintf("%d", m.Character.Skill)))
+		if m.Character.CurrentSection > 0 {
+			b.WriteString("  |  " + theme.RenderLabel("Section", fmt.Sprintf("%d", m.Character.CurrentSection)))
+		}
 		if m.Character.MagicUnlocked {
 			b.WriteString("  |  " + theme.RenderPOWMeter(m.Character.CurrentPOW, m.Character.MaximumPOW, 20))
 		}
 		b.WriteString("\n\n")
 	}
 
+	if fight := m.SavedCombat; fight != nil {
+		t := theme.Current()
+		b.WriteString("  " + t.Emphasis.Render("A fight was in progress when this character was saved.") + "\n\n")
+		b.WriteString("  " + theme.RenderLabel("Against", combat.EnemyNames(fight.LivingOpponents())) + "\n")
+		b.WriteString("  " + theme.RenderLabel("Round", fmt.Sprintf("%d", fight.CurrentRound)) + "\n\n")
+		b.WriteString("  Resume the fight?\n\n")
+		b.WriteString(theme.RenderKeyHelp("y/Enter Resume", "n/Esc Abandon"))
+		return b.String()
+	}
+
 	choices := m.GameSession.GetChoices()
 	cursor := m.GameSession.GetCursor()
 
@@ -584,23 +665,45 @@ func (m Model) viewSettings() string {
 	b.WriteString("\n")
 
 	// Gameplay section
+	interval := "after events only"
+	if cfg.AutoSaveMinutes > 0 {
+		interval = fmt.Sprintf("every %d min", cfg.AutoSaveMinutes)
+	}
 	b.WriteString(theme.Current().Heading.Render("  Gameplay") + "\n")
 	renderSetting(&b, 3, cursor, "Confirm Actions", boolToString(cfg.ConfirmActions))
-	renderSetting(&b, 4, cursor, "Auto-save on Exit", boolToString(cfg.AutoSave))
-	renderSetting(&b, 5, cursor, "Show Roll Details", boolToString(cfg.ShowRollDetails))
+	renderSetting(&b, 4, cursor, "Auto-save", boolToString(cfg.AutoSave))
+	renderSetting(&b, 5, cursor, "Auto-save Interval", interval)
+	renderSetting(&b, 6, cursor, "Show Roll Details", boolToString(cfg.ShowRollDetails))
+	renderSetting(&b, 7, cursor, "Enter Physical Dice", boolToString(cfg.ManualDice))
+	renderSetting(&b, 8, cursor, "Ruleset", rulesetLabel(cfg.SaveDirectory, cfg.Ruleset))
 	b.WriteString("\n")
 
 	// Accessibility section
 	b.WriteString(theme.Current().Heading.Render("  Accessibility") + "\n")
-	renderSetting(&b, 6, cursor, "High Contrast", boolToString(cfg.HighContrast))
-	renderSetting(&b, 7, cursor, "Reduced Motion", boolToString(cfg.ReducedMotion))
+	renderSetting(&b, 9, cursor, "High Contrast", boolToString(cfg.HighContrast))
+	renderSetting(&b, 10, cursor, "Reduced Motion", boolToString(cfg.ReducedMotion))
+	b.WriteString("\n")
+
+	// Files section
+	maxAge := "never"
+	if cfg.BackupMaxAgeDays > 0 {
+		maxAge = fmt.Sprintf("%d days", cfg.BackupMaxAgeDays)
+	}
+	backupCount := "no limit"
+	if cfg.BackupCount > 0 {
+		backupCount = fmt.Sprintf("%d", cfg.BackupCount)
+	}
+	b.WriteString(theme.Current().Heading.Render("  Files") + "\n")
+	renderSetting(&b, 11, cursor, "Character Backups", boolToString(cfg.CharacterBackup))
+	renderSetting(&b, 12, cursor, "Backups Kept per Slot", backupCount)
+	renderSetting(&b, 13, cursor, "Delete Backups After", maxAge)
 	b.WriteString("\n")
 
 	// Actions
 	b.WriteString(theme.Current().Heading.Render("  Actions") + "\n")
-	renderAction(&b, 8, cursor, "[Save]")
-	renderAction(&b, 9, cursor, "[Cancel]")
-	renderAction(&b, 10, cursor, "[Reset to Defaults]")
+	renderAction(&b, 14, cursor, "[Save]")
+	renderAction(&b, 15, cursor, "[Cancel]")
+	renderAction(&b, 16, cursor, "[Reset to Defaults]")
 	b.WriteString("\n")
 
 	// Status message
@@ -649,6 +752,15 @@ func renderAction(b *strings.Builder, index, cursor int, label string) {
 	b.WriteString(fmt.Sprintf("%s%s\n", prefix, label))
 }
 
+// rulesetLabel names a ruleset profile for the settings screen.
+func rulesetLabel(directory, id string) string {
+	ruleset, err := rules.Find(directory, id)
+	if err != nil {
+		return id + " (unreadable)"
+	}
+	return ruleset.Name
+}
+
 // boolToString converts a boolean to a user-friendly string.
 func boolToString(value bool) string {
 	if value {